@@ -54,6 +54,7 @@ import (
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 
+	"github.com/arangodb/kube-arangodb/pkg/backup/handlers/arango/backup"
 	"github.com/arangodb/kube-arangodb/pkg/client"
 	"github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/scheme"
 	"github.com/arangodb/kube-arangodb/pkg/logging"
@@ -75,6 +76,7 @@ const (
 	defaultAlpineImage          = "alpine:3.7"
 	defaultMetricsExporterImage = "arangodb/arangodb-exporter:0.1.6"
 	defaultArangoImage          = "arangodb/arangodb:latest"
+	defaultCRDReadyTimeout      = time.Minute
 
 	UBIImageEnv             util.EnvironmentVariable = "RELATED_IMAGE_UBI"
 	ArangoImageEnv          util.EnvironmentVariable = "RELATED_IMAGE_DATABASE"
@@ -110,12 +112,62 @@ var (
 
 		alpineImage, metricsExporterImage, arangoImage string
 
-		singleMode bool
-		scope      string
+		singleMode      bool
+		reconcileDryRun bool
+		scope           string
+
+		crdReadyTimeout time.Duration
 	}
 	chaosOptions struct {
 		allowed bool
 	}
+	backupOptions struct {
+		healthFreshness         time.Duration
+		importedBackupsEditable bool
+
+		ownerReferenceController         bool
+		ownerReferenceBlockOwnerDeletion bool
+		ownerReferenceDisabled           bool
+
+		clockSkewThreshold time.Duration
+		useServerTime      bool
+
+		credentialsTimeout time.Duration
+
+		defaultTimeout                     time.Duration
+		defaultAllowInconsistent           bool
+		defaultUploadRepositoryURL         string
+		defaultUploadCredentialsSecretName string
+		defaultStateTimeout                time.Duration
+
+		importNamePrefix string
+		importLabels     map[string]string
+
+		retentionExcludeImported bool
+		autoDeleteFailedAfter    time.Duration
+		stateHistoryLimit        int
+		trimFinalStatus          bool
+
+		deploymentConcurrency int
+		globalConcurrency     int
+		drainTimeout          time.Duration
+		lockWarningThreshold  time.Duration
+
+		allNamespaces bool
+
+		deploymentSelector string
+
+		callbackURL           string
+		callbackAuthorization string
+
+		requeueBaseDelay time.Duration
+		requeueMaxDelay  time.Duration
+		jobPollInterval  time.Duration
+		jobPollMaxDelay  time.Duration
+
+		refreshInterval time.Duration
+		refreshJitter   float64
+	}
 	livenessProbe              probe.LivenessProbe
 	deploymentProbe            probe.ReadyProbe
 	deploymentReplicationProbe probe.ReadyProbe
@@ -139,8 +191,43 @@ func init() {
 	f.StringVar(&operatorOptions.alpineImage, "operator.alpine-image", UBIImageEnv.GetOrDefault(defaultAlpineImage), "Docker image used for alpine containers")
 	f.StringVar(&operatorOptions.metricsExporterImage, "operator.metrics-exporter-image", MetricsExporterImageEnv.GetOrDefault(defaultMetricsExporterImage), "Docker image used for metrics containers by default")
 	f.StringVar(&operatorOptions.arangoImage, "operator.arango-image", ArangoImageEnv.GetOrDefault(defaultArangoImage), "Docker image used for arango by default")
+	f.DurationVar(&backupOptions.healthFreshness, "backup.health-freshness", backup.NewDefaultConfig().HealthFreshness, "Maximum age of the Ready backup to consider deployment backup as fresh in health metrics")
+	f.BoolVar(&backupOptions.importedBackupsEditable, "backup.imported-editable", false, "Allow to change spec of the imported backups")
+	f.BoolVar(&backupOptions.ownerReferenceController, "backup.owner-reference.controller", backup.NewDefaultConfig().OwnerReferenceController, "Set Controller flag on the deployment owner reference of the ArangoBackup")
+	f.BoolVar(&backupOptions.ownerReferenceBlockOwnerDeletion, "backup.owner-reference.block-owner-deletion", backup.NewDefaultConfig().OwnerReferenceBlockOwnerDeletion, "Set BlockOwnerDeletion flag on the deployment owner reference of the ArangoBackup")
+	f.BoolVar(&backupOptions.ownerReferenceDisabled, "backup.owner-reference.disabled", backup.NewDefaultConfig().OwnerReferenceDisabled, "Do not add the deployment owner reference to the ArangoBackup, so it is kept when the deployment is removed")
+	f.DurationVar(&backupOptions.clockSkewThreshold, "backup.clock-skew-threshold", backup.NewDefaultConfig().ClockSkewThreshold, "Maximum accepted clock skew between operator and database server before warning is reported")
+	f.BoolVar(&backupOptions.useServerTime, "backup.use-server-time", false, "Use database server time for backup age calculations")
+	f.DurationVar(&backupOptions.credentialsTimeout, "backup.credentials-timeout", backup.NewDefaultConfig().CredentialsTimeout, "Time to wait for the missing authentication secret of the deployment before ArangoBackup fails")
+	f.DurationVar(&backupOptions.defaultTimeout, "backup.default.timeout", 0, "Default timeout of the backup creation used when not set in ArangoBackup spec")
+	f.BoolVar(&backupOptions.defaultAllowInconsistent, "backup.default.allow-inconsistent", false, "Allow inconsistent backups by default when consistency is not set in ArangoBackup spec")
+	f.StringVar(&backupOptions.defaultUploadRepositoryURL, "backup.default.upload-repository-url", "", "Default repository URL of the ArangoBackup upload")
+	f.StringVar(&backupOptions.defaultUploadCredentialsSecretName, "backup.default.upload-credentials-secret-name", "", "Default credentials secret of the ArangoBackup upload, used together with the default repository URL")
+	f.DurationVar(&backupOptions.defaultStateTimeout, "backup.default.state-timeout", 0, "Default time the ArangoBackup can stay in one of the operation states before it fails, used when not set in ArangoBackup spec")
+	f.StringVar(&backupOptions.importNamePrefix, "backup.import.name-prefix", backup.NewDefaultConfig().Import.NamePrefix, "Name prefix of the ArangoBackups imported from the deployment, {deployment} and {date} placeholders are replaced, UUID suffix is always appended")
+	f.StringToStringVar(&backupOptions.importLabels, "backup.import.labels", nil, "Labels added to the ArangoBackups imported from the deployment, values support {deployment} and {date} placeholders")
+	f.BoolVar(&backupOptions.retentionExcludeImported, "backup.retention.exclude-imported", false, "Exclude imported backups from the ArangoBackup retention")
+	f.DurationVar(&backupOptions.autoDeleteFailedAfter, "backup.auto-delete-failed-after", 0, "Time after which ArangoBackups in Failed state are deleted, 0 disables deletion")
+	f.IntVar(&backupOptions.stateHistoryLimit, "backup.state-history-limit", 0, "Number of recent state transitions kept in the ArangoBackup status, 0 disables the history")
+	f.BoolVar(&backupOptions.trimFinalStatus, "backup.trim-final-status", false, "Drop progress and state history from the ArangoBackup status once backup reaches final state")
+	f.IntVar(&backupOptions.deploymentConcurrency, "backup.deployment-concurrency", backup.NewDefaultConfig().DeploymentConcurrency, "Maximum number of ArangoBackup operations running in parallel on one deployment, additional operations wait in queue")
+	f.IntVar(&backupOptions.globalConcurrency, "backup.global-concurrency", 0, "Maximum number of ArangoBackups handled in parallel by the operator across all deployments, 0 disables the limit")
+	f.DurationVar(&backupOptions.drainTimeout, "backup.drain-timeout", backup.NewDefaultConfig().DrainTimeout, "Time to wait for in-flight ArangoBackup operations on operator shutdown, 0 aborts them immediately")
+	f.DurationVar(&backupOptions.lockWarningThreshold, "backup.lock-warning-threshold", backup.NewDefaultConfig().LockWarningThreshold, "Time after which deployment lock held without any finished ArangoBackup operation is reported as stuck, 0 disables detection")
+	f.BoolVar(&backupOptions.allNamespaces, "backup.all-namespaces", false, "Handle ArangoBackups of the deployments in all namespaces, requires cluster wide permissions of the operator")
+	f.StringVar(&backupOptions.deploymentSelector, "backup.deployment-selector", "", "Label selector of the ArangoDeployments for which ArangoBackups are imported and managed, all deployments are handled if not set")
+	f.StringVar(&backupOptions.callbackURL, "backup.callback.url", "", "URL notified with POST request when ArangoBackup reaches Ready or Failed state")
+	f.StringVar(&backupOptions.callbackAuthorization, "backup.callback.authorization", "", "Value of the Authorization header send with the ArangoBackup callback request")
+	f.DurationVar(&backupOptions.refreshInterval, "backup.refresh.interval", backup.NewDefaultConfig().RefreshInterval, "Interval of the ArangoBackup import and check of the deployments, needs to be at least 10s, 0 disables periodic refresh")
+	f.Float64Var(&backupOptions.refreshJitter, "backup.refresh.jitter", backup.NewDefaultConfig().RefreshJitter, "Fraction of the refresh interval by which every ArangoBackup refresh is randomly moved, 0 disables jitter")
+	f.DurationVar(&backupOptions.requeueBaseDelay, "backup.requeue.base-delay", backup.NewDefaultConfig().RequeueBaseDelay, "Initial delay of the ArangoBackup handling without state transition, grows exponentially")
+	f.DurationVar(&backupOptions.requeueMaxDelay, "backup.requeue.max-delay", backup.NewDefaultConfig().RequeueMaxDelay, "Maximum delay of the ArangoBackup handling without state transition")
+	f.DurationVar(&backupOptions.jobPollInterval, "backup.job-poll-interval", backup.NewDefaultConfig().JobPollInterval, "Initial delay of the ArangoBackup handling while upload or download job is running on the server")
+	f.DurationVar(&backupOptions.jobPollMaxDelay, "backup.job-poll-max-delay", backup.NewDefaultConfig().JobPollMaxDelay, "Maximum delay of the ArangoBackup handling while upload or download job is running on the server")
 	f.BoolVar(&chaosOptions.allowed, "chaos.allowed", false, "Set to allow chaos in deployments. Only activated when allowed and enabled in deployment")
+	f.BoolVar(&operatorOptions.reconcileDryRun, "operator.reconcile-dry-run", false, "Enable reconcile dry run of all ArangoDeployments, plan actions are logged instead of applied")
 	f.BoolVar(&operatorOptions.singleMode, "mode.single", false, "Enable single mode in Operator. WARNING: There should be only one replica of Operator, otherwise Operator can take unexpected actions")
+	f.DurationVar(&operatorOptions.crdReadyTimeout, "crd.ready-timeout", defaultCRDReadyTimeout, "Maximum time to wait for CRDs to be established during operator startup")
 	f.StringVar(&operatorOptions.scope, "scope", scope.DefaultScope.String(), "Define scope on which Operator works. Legacy - pre 1.1.0 scope with limited cluster access")
 
 	features.Init(&cmdMain)
@@ -321,7 +408,60 @@ func newOperatorConfigAndDeps(id, namespace, name string) (operator.Config, oper
 		MetricsExporterImage:        operatorOptions.metricsExporterImage,
 		ArangoImage:                 operatorOptions.arangoImage,
 		SingleMode:                  operatorOptions.singleMode,
+		ReconcileDryRun:             operatorOptions.reconcileDryRun,
 		Scope:                       scope,
+		CRDReadyTimeout:             operatorOptions.crdReadyTimeout,
+		BackupConfig: backup.Config{
+			HealthFreshness:         backupOptions.healthFreshness,
+			ImportedBackupsEditable: backupOptions.importedBackupsEditable,
+
+			OwnerReferenceController:         backupOptions.ownerReferenceController,
+			OwnerReferenceBlockOwnerDeletion: backupOptions.ownerReferenceBlockOwnerDeletion,
+			OwnerReferenceDisabled:           backupOptions.ownerReferenceDisabled,
+
+			ClockSkewThreshold: backupOptions.clockSkewThreshold,
+			UseServerTime:      backupOptions.useServerTime,
+
+			CredentialsTimeout: backupOptions.credentialsTimeout,
+
+			Defaults: backup.SpecDefaults{
+				Timeout:                     backupOptions.defaultTimeout,
+				AllowInconsistent:           backupOptions.defaultAllowInconsistent,
+				UploadRepositoryURL:         backupOptions.defaultUploadRepositoryURL,
+				UploadCredentialsSecretName: backupOptions.defaultUploadCredentialsSecretName,
+				StateTimeout:                backupOptions.defaultStateTimeout,
+			},
+
+			Import: backup.ImportTemplate{
+				NamePrefix: backupOptions.importNamePrefix,
+				Labels:     backupOptions.importLabels,
+			},
+
+			RetentionExcludeImported: backupOptions.retentionExcludeImported,
+			AutoDeleteFailedAfter:    backupOptions.autoDeleteFailedAfter,
+			StateHistoryLimit:        backupOptions.stateHistoryLimit,
+			TrimFinalStatus:          backupOptions.trimFinalStatus,
+
+			DeploymentConcurrency: backupOptions.deploymentConcurrency,
+			GlobalConcurrency:     backupOptions.globalConcurrency,
+			DrainTimeout:          backupOptions.drainTimeout,
+			LockWarningThreshold:  backupOptions.lockWarningThreshold,
+
+			AllNamespaces: backupOptions.allNamespaces,
+
+			DeploymentSelector: backupOptions.deploymentSelector,
+
+			CallbackURL:           backupOptions.callbackURL,
+			CallbackAuthorization: backupOptions.callbackAuthorization,
+
+			RequeueBaseDelay: backupOptions.requeueBaseDelay,
+			RequeueMaxDelay:  backupOptions.requeueMaxDelay,
+			JobPollInterval:  backupOptions.jobPollInterval,
+			JobPollMaxDelay:  backupOptions.jobPollMaxDelay,
+
+			RefreshInterval: backupOptions.refreshInterval,
+			RefreshJitter:   backupOptions.refreshJitter,
+		},
 	}
 	deps := operator.Dependencies{
 		LogService:                 logService,
@@ -23,12 +23,48 @@
 package v1
 
 import (
+	"strings"
+
 	"github.com/arangodb/kube-arangodb/pkg/apis/backup"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	FinalizerArangoBackup = backup.ArangoBackupCRDName + "/cleanup"
+
+	// FinalizerArangoBackupPolicy is set on the ArangoBackupPolicy with cascade enabled, backups created by the policy are removed before the policy is gone
+	FinalizerArangoBackupPolicy = backup.ArangoBackupPolicyCRDName + "/cascade"
+
+	// AnnotationArangoBackupDryRun set to "true" stops the backup after validation, backup is not created on the server
+	AnnotationArangoBackupDryRun = backup.ArangoBackupGroupName + "/dry-run"
+
+	// AnnotationArangoBackupPaused set to "true" pauses reconciliation of the backup, deletion is still handled
+	AnnotationArangoBackupPaused = backup.ArangoBackupGroupName + "/paused"
+
+	// AnnotationArangoDeploymentClientTimeout set on the ArangoDeployment overrides timeout of the backup requests send to it (e.g. "2m")
+	AnnotationArangoDeploymentClientTimeout = backup.ArangoBackupGroupName + "/client-timeout"
+
+	// AnnotationArangoDeploymentDefaultTimeout set on the ArangoDeployment overrides operator default timeout of the backup creation (e.g. "5m")
+	AnnotationArangoDeploymentDefaultTimeout = backup.ArangoBackupGroupName + "/default-timeout"
+
+	// AnnotationArangoDeploymentDefaultAllowInconsistent set on the ArangoDeployment overrides operator default of the inconsistent backups ("true" or "false")
+	AnnotationArangoDeploymentDefaultAllowInconsistent = backup.ArangoBackupGroupName + "/default-allow-inconsistent"
+
+	// AnnotationArangoDeploymentDefaultStateTimeout set on the ArangoDeployment overrides operator default state timeout of the backups (e.g. "1h")
+	AnnotationArangoDeploymentDefaultStateTimeout = backup.ArangoBackupGroupName + "/default-state-timeout"
+
+	// AnnotationArangoDeploymentDefaultUploadRepositoryURL set on the ArangoDeployment overrides operator default upload repository of the backups
+	AnnotationArangoDeploymentDefaultUploadRepositoryURL = backup.ArangoBackupGroupName + "/default-upload-repository-url"
+
+	// AnnotationArangoDeploymentDefaultUploadCredentialsSecretName set on the ArangoDeployment overrides operator default upload credentials secret of the backups
+	AnnotationArangoDeploymentDefaultUploadCredentialsSecretName = backup.ArangoBackupGroupName + "/default-upload-credentials-secret-name"
+
+	// AnnotationArangoDeploymentBackupAdoptionKey set on the ArangoDeployment names the label or annotation of the ArangoBackups
+	// which holds ID of the existing server backup, such ArangoBackups are adopted instead of creating new backups
+	AnnotationArangoDeploymentBackupAdoptionKey = backup.ArangoBackupGroupName + "/adoption-key"
+
+	// LabelArangoBackupID holds ID of the server backup referenced by the ArangoBackup
+	LabelArangoBackupID = backup.ArangoBackupGroupName + "/id"
 )
 
 var (
@@ -58,3 +94,13 @@ type ArangoBackup struct {
 	Spec   ArangoBackupSpec   `json:"spec"`
 	Status ArangoBackupStatus `json:"status"`
 }
+
+// IsDryRun returns true if only validation of the backup is requested
+func (a *ArangoBackup) IsDryRun() bool {
+	return strings.EqualFold(a.Annotations[AnnotationArangoBackupDryRun], "true")
+}
+
+// IsPaused returns true if reconciliation of the backup is paused
+func (a *ArangoBackup) IsPaused() bool {
+	return strings.EqualFold(a.Annotations[AnnotationArangoBackupPaused], "true")
+}
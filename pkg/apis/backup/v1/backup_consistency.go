@@ -0,0 +1,91 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+type ArangoBackupConsistencyLevel string
+
+const (
+	// ArangoBackupConsistencyLevelStrict requires global lock, backup fails if it can not be acquired (default)
+	ArangoBackupConsistencyLevelStrict ArangoBackupConsistencyLevel = "strict"
+	// ArangoBackupConsistencyLevelEventual allows creation of potentially inconsistent backup without global lock
+	ArangoBackupConsistencyLevelEventual ArangoBackupConsistencyLevel = "eventual"
+	// ArangoBackupConsistencyLevelSnapshot requests backup based on snapshot read
+	ArangoBackupConsistencyLevelSnapshot ArangoBackupConsistencyLevel = "snapshot"
+)
+
+// Get returns consistency level or default value if not set
+func (a *ArangoBackupConsistencyLevel) Get() ArangoBackupConsistencyLevel {
+	if a == nil {
+		return ArangoBackupConsistencyLevelStrict
+	}
+
+	return *a
+}
+
+func (a *ArangoBackupConsistencyLevel) Validate() error {
+	switch v := a.Get(); v {
+	case ArangoBackupConsistencyLevelStrict, ArangoBackupConsistencyLevelEventual:
+		return nil
+	case ArangoBackupConsistencyLevelSnapshot:
+		return fmt.Errorf("consistency level %s is not supported by the server", v)
+	default:
+		return fmt.Errorf("consistency level %s is not supported", v)
+	}
+}
+
+// GetAllowInconsistent returns true if backup can be created without global lock
+func (a *ArangoBackupSpec) GetAllowInconsistent() bool {
+	if a.ConsistencyLevel != nil {
+		return a.ConsistencyLevel.Get() == ArangoBackupConsistencyLevelEventual
+	}
+
+	if a.Options != nil {
+		return util.BoolOrDefault(a.Options.AllowInconsistent)
+	}
+
+	return false
+}
+
+func (a *ArangoBackupSpec) validateConsistencyLevel() error {
+	if a.ConsistencyLevel == nil {
+		return nil
+	}
+
+	if err := a.ConsistencyLevel.Validate(); err != nil {
+		return err
+	}
+
+	if a.Options != nil && a.Options.AllowInconsistent != nil {
+		if *a.Options.AllowInconsistent != (a.ConsistencyLevel.Get() == ArangoBackupConsistencyLevelEventual) {
+			return fmt.Errorf("consistency level %s conflicts with allowInconsistent option", a.ConsistencyLevel.Get())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+// ArangoBackupJobError contains details of the failed upload or download job
+type ArangoBackupJobError struct {
+	// JobID is the ID of the failed transfer job
+	JobID string `json:"jobID"`
+
+	// Servers contains errors reported by the servers
+	Servers []ArangoBackupJobServerError `json:"servers,omitempty"`
+}
+
+// ArangoBackupJobServerError contains error reported by the server for the transfer job
+type ArangoBackupJobServerError struct {
+	// Server is the ID of the server which reported the error
+	Server string `json:"server"`
+
+	// Code is the ArangoDB error code
+	Code int `json:"code,omitempty"`
+
+	// Message is the ArangoDB error message
+	Message string `json:"message,omitempty"`
+}
+
+func (a *ArangoBackupJobError) Equal(b *ArangoBackupJobError) bool {
+	if a == b {
+		return true
+	}
+
+	if a == nil && b != nil || a != nil && b == nil {
+		return false
+	}
+
+	if a.JobID != b.JobID || len(a.Servers) != len(b.Servers) {
+		return false
+	}
+
+	for id := range a.Servers {
+		if a.Servers[id] != b.Servers[id] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,61 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ArangoBackupLabelMaxLength defines maximum length of the label passed to the ArangoDB server.
+// Label becomes part of the backup ID, which is used as directory name on the server
+const ArangoBackupLabelMaxLength = 64
+
+var arangoBackupLabelRE = regexp.MustCompile(`^[a-zA-Z0-9._\-]+$`)
+
+// GetLabel returns label of the backup or empty string if not set
+func (a *ArangoBackupSpecOptions) GetLabel() string {
+	if a == nil {
+		return ""
+	}
+
+	return a.Label
+}
+
+// validateLabel ensures that label can be accepted by the ArangoDB server
+func (a *ArangoBackupSpecOptions) validateLabel() error {
+	label := a.GetLabel()
+	if label == "" {
+		return nil
+	}
+
+	if len(label) > ArangoBackupLabelMaxLength {
+		return fmt.Errorf("label can not be longer than %d characters", ArangoBackupLabelMaxLength)
+	}
+
+	if !arangoBackupLabelRE.MatchString(label) {
+		return fmt.Errorf("label %s can contain only letters, digits, '.', '_' and '-'", label)
+	}
+
+	return nil
+}
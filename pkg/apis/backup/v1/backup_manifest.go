@@ -0,0 +1,101 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"fmt"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ArangoBackupManifestTarget string
+
+const (
+	// ArangoBackupManifestTargetStatus stores manifest in the status of the ArangoBackup
+	ArangoBackupManifestTargetStatus ArangoBackupManifestTarget = "Status"
+	// ArangoBackupManifestTargetConfigMap stores manifest in the ConfigMap owned by the ArangoBackup
+	ArangoBackupManifestTargetConfigMap ArangoBackupManifestTarget = "ConfigMap"
+)
+
+// Get returns target or default value if not set
+func (a *ArangoBackupManifestTarget) Get() ArangoBackupManifestTarget {
+	if a == nil {
+		return ArangoBackupManifestTargetStatus
+	}
+
+	return *a
+}
+
+func (a *ArangoBackupManifestTarget) Validate() error {
+	switch v := a.Get(); v {
+	case ArangoBackupManifestTargetStatus, ArangoBackupManifestTargetConfigMap:
+		return nil
+	default:
+		return fmt.Errorf("manifest target %s is not supported", v)
+	}
+}
+
+type ArangoBackupManifest struct {
+	CreationTimestamp meta.Time `json:"createdAt"`
+
+	// Collections contains list of the collections present in the deployment right after backup was created.
+	// Empty when manifest is stored in ConfigMap
+	Collections []ArangoBackupManifestCollection `json:"collections,omitempty"`
+
+	// ConfigMapName is the name of the ConfigMap with manifest
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Message for the manifest generation
+	Message string `json:"message,omitempty"`
+}
+
+func (a *ArangoBackupManifest) Equal(b *ArangoBackupManifest) bool {
+	if a == b {
+		return true
+	}
+
+	if a == nil && b != nil || a != nil && b == nil {
+		return false
+	}
+
+	if len(a.Collections) != len(b.Collections) {
+		return false
+	}
+
+	for id := range a.Collections {
+		if a.Collections[id] != b.Collections[id] {
+			return false
+		}
+	}
+
+	return a.CreationTimestamp.Equal(&b.CreationTimestamp) &&
+		a.ConfigMapName == b.ConfigMapName &&
+		a.Message == b.Message
+}
+
+type ArangoBackupManifestCollection struct {
+	Database    string `json:"database"`
+	Name        string `json:"name"`
+	Count       int64  `json:"count"`
+	SizeInBytes int64  `json:"sizeInBytes,omitempty"`
+}
@@ -25,6 +25,7 @@ package v1
 import (
 	"fmt"
 
+	"github.com/arangodb/kube-arangodb/pkg/apis/backup"
 	deployment "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 
 	"github.com/arangodb/kube-arangodb/pkg/backup/utils"
@@ -53,6 +54,27 @@ type ArangoBackupPolicy struct {
 	Status ArangoBackupPolicyStatus `json:"status"`
 }
 
+// AsOwner creates an OwnerReference for the given policy
+func (a *ArangoBackupPolicy) AsOwner() metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: SchemeGroupVersion.String(),
+		Kind:       backup.ArangoBackupPolicyResourceKind,
+		Name:       a.Name,
+		UID:        a.UID,
+	}
+}
+
+// IsOwnerOf returns true if the backup was created by the policy
+func (a *ArangoBackupPolicy) IsOwnerOf(b *ArangoBackup) bool {
+	for _, owner := range b.OwnerReferences {
+		if owner.Kind == backup.ArangoBackupPolicyResourceKind && owner.UID == a.UID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (a *ArangoBackupPolicy) NewBackup(d *deployment.ArangoDeployment) *ArangoBackup {
 	policyName := a.Name
 
@@ -61,7 +83,9 @@ func (a *ArangoBackupPolicy) NewBackup(d *deployment.ArangoDeployment) *ArangoBa
 			Name: d.Name,
 		},
 		Upload:     a.Spec.BackupTemplate.Upload.DeepCopy(),
+		Uploads:    append([]ArangoBackupSpecOperation(nil), a.Spec.BackupTemplate.Uploads...),
 		Options:    a.Spec.BackupTemplate.Options.DeepCopy(),
+		Retention:  a.Spec.BackupTemplate.Retention.DeepCopy(),
 		PolicyName: &policyName,
 	}
 
@@ -70,6 +94,10 @@ func (a *ArangoBackupPolicy) NewBackup(d *deployment.ArangoDeployment) *ArangoBa
 			Name:      fmt.Sprintf("%s-%s", d.Name, utils.RandomString(8)),
 			Namespace: a.Namespace,
 
+			OwnerReferences: []metav1.OwnerReference{
+				a.AsOwner(),
+			},
+
 			Labels:      d.Labels,
 			Annotations: d.Annotations,
 
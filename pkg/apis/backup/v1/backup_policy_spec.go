@@ -23,6 +23,7 @@
 package v1
 
 import (
+	"github.com/arangodb/kube-arangodb/pkg/util"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,10 +33,22 @@ type ArangoBackupPolicySpec struct {
 	DeploymentSelector *meta.LabelSelector `json:"selector,omitempty"`
 
 	BackupTemplate ArangoBackupTemplate `json:"template"`
+
+	// Cascade removes ArangoBackup objects created by the policy when the policy is deleted
+	Cascade *bool `json:"cascade,omitempty"`
+}
+
+// GetCascade returns true if backups created by the policy should be removed together with the policy
+func (a ArangoBackupPolicySpec) GetCascade() bool {
+	return util.BoolOrDefault(a.Cascade)
 }
 
 type ArangoBackupTemplate struct {
 	Options *ArangoBackupSpecOptions `json:"options,omitempty"`
 
 	Upload *ArangoBackupSpecOperation `json:"upload,omitempty"`
+
+	Uploads []ArangoBackupSpecOperation `json:"uploads,omitempty"`
+
+	Retention *ArangoBackupSpecRetention `json:"retention,omitempty"`
 }
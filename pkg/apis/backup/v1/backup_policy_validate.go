@@ -44,5 +44,18 @@ func (a *ArangoBackupPolicySpec) Validate() error {
 		return fmt.Errorf("invalid schedule format")
 	}
 
+	if err := a.BackupTemplate.Retention.Validate(); err != nil {
+		return err
+	}
+
+	uploads := ArangoBackupSpec{
+		Upload:  a.BackupTemplate.Upload,
+		Uploads: a.BackupTemplate.Uploads,
+	}
+
+	if err := uploads.validateUploads(); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,91 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/arangodb/go-driver"
+	deployment "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+// ArangoBackupRestoreTarget contains result of the validation of the restore target deployment
+type ArangoBackupRestoreTarget struct {
+	// Source is the name of the deployment in which backup was created
+	Source string `json:"source"`
+
+	// Target is the name of the deployment in which backup is going to be restored
+	Target string `json:"target"`
+
+	// Ready is true if backup can be restored in the target deployment
+	Ready bool `json:"ready"`
+
+	// Message with the reason why backup can not be restored in the target deployment
+	Message string `json:"message,omitempty"`
+}
+
+func (a *ArangoBackupRestoreTarget) Equal(b *ArangoBackupRestoreTarget) bool {
+	if a == b {
+		return true
+	}
+
+	if a == nil && b != nil || a != nil && b == nil {
+		return false
+	}
+
+	return a.Source == b.Source &&
+		a.Target == b.Target &&
+		a.Ready == b.Ready &&
+		a.Message == b.Message
+}
+
+// RestoreIncompatibility returns reason why backup can not be restored in the target deployment with given spec
+// and current image or empty string. Only state of the target deployment is checked.
+func (a *ArangoBackup) RestoreIncompatibility(target string, spec *deployment.DeploymentSpec, image *deployment.ImageInfo) string {
+	if !spec.Database.GetMaintenance() {
+		return fmt.Sprintf("target deployment %s is not in maintenance mode", target)
+	}
+
+	if a.Status.Backup == nil {
+		return "backup details are missing"
+	}
+
+	// Hot backup can be restored only in the deployment with the same number of dbservers
+	if spec.Mode.Get().HasDBServers() {
+		if count := spec.DBServers.GetCount(); count != int(a.Status.Backup.NumberOfDBServers) {
+			return fmt.Sprintf("target deployment %s has %d dbservers, backup was created with %d", target, count, a.Status.Backup.NumberOfDBServers)
+		}
+	}
+
+	if image == nil {
+		return fmt.Sprintf("version of the target deployment %s is not yet known", target)
+	}
+
+	backupVersion := driver.Version(a.Status.Backup.Version)
+
+	if backupVersion.Major() != image.ArangoDBVersion.Major() || backupVersion.Minor() != image.ArangoDBVersion.Minor() {
+		return fmt.Sprintf("backup version %s is not compatible with target deployment version %s", backupVersion, image.ArangoDBVersion)
+	}
+
+	return ""
+}
@@ -0,0 +1,74 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArangoBackupSpecRetention defines how long backup is kept before it is deleted by the operator
+type ArangoBackupSpecRetention struct {
+	// MaxCount keeps backup only while it is one of the MaxCount newest Ready backups
+	// of the same deployment and policy
+	MaxCount *int `json:"maxCount,omitempty"`
+
+	// MaxAge keeps backup only until it reaches given age
+	MaxAge *meta.Duration `json:"maxAge,omitempty"`
+}
+
+// GetMaxCount returns MaxCount and true if limit is set
+func (a *ArangoBackupSpecRetention) GetMaxCount() (int, bool) {
+	if a == nil || a.MaxCount == nil {
+		return 0, false
+	}
+
+	return *a.MaxCount, true
+}
+
+// GetMaxAge returns MaxAge and true if limit is set
+func (a *ArangoBackupSpecRetention) GetMaxAge() (time.Duration, bool) {
+	if a == nil || a.MaxAge == nil {
+		return 0, false
+	}
+
+	return a.MaxAge.Duration, true
+}
+
+func (a *ArangoBackupSpecRetention) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	if max, ok := a.GetMaxCount(); ok && max < 1 {
+		return fmt.Errorf("retention max count needs to be greater than 0")
+	}
+
+	if max, ok := a.GetMaxAge(); ok && max <= 0 {
+		return fmt.Errorf("retention max age needs to be greater than 0")
+	}
+
+	return nil
+}
@@ -22,28 +22,163 @@
 
 package v1
 
+import (
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
 type ArangoBackupSpec struct {
 	// Deployment
 	Deployment ArangoBackupSpecDeployment `json:"deployment,omitempty"`
 
 	Options *ArangoBackupSpecOptions `json:"options,omitempty"`
 
+	// ConsistencyLevel defines required consistency of the backup. Possible values: strict (default), eventual
+	ConsistencyLevel *ArangoBackupConsistencyLevel `json:"consistencyLevel,omitempty"`
+
 	// Download
 	Download *ArangoBackupSpecDownload `json:"download,omitempty"`
 
 	// Upload
 	Upload *ArangoBackupSpecOperation `json:"upload,omitempty"`
 
+	// Uploads defines additional upload destinations, backup is uploaded to each of them after Upload
+	Uploads []ArangoBackupSpecOperation `json:"uploads,omitempty"`
+
 	PolicyName *string `json:"policyName,omitempty"`
+
+	// GenerateManifest requests generation of the manifest with backup content when backup is created by the operator
+	GenerateManifest *bool `json:"generateManifest,omitempty"`
+
+	// ManifestTarget defines where manifest is stored. Possible values: Status (default), ConfigMap.
+	// Manifest too large for the status is stored in ConfigMap
+	ManifestTarget *ArangoBackupManifestTarget `json:"manifestTarget,omitempty"`
+
+	// RestoreTargetDeployment defines deployment, different from the source deployment, in which backup is allowed to be restored
+	RestoreTargetDeployment *ArangoBackupSpecDeployment `json:"restoreTargetDeployment,omitempty"`
+
+	// Policy defines behavior of the backup operations
+	Policy *ArangoBackupSpecPolicy `json:"policy,omitempty"`
+
+	// User defines database user used for backup operations instead of the deployment credentials
+	User *ArangoBackupSpecUser `json:"user,omitempty"`
+
+	// Retention defines how long backup is kept before it is deleted by the operator
+	Retention *ArangoBackupSpecRetention `json:"retention,omitempty"`
+}
+
+// GetUploads returns all upload destinations of the backup, Upload first
+func (a *ArangoBackupSpec) GetUploads() []ArangoBackupSpecOperation {
+	if a.Upload == nil {
+		return nil
+	}
+
+	return append([]ArangoBackupSpecOperation{*a.Upload}, a.Uploads...)
+}
+
+type ArangoBackupSpecUser struct {
+	// CredentialsSecretName is the name of the secret with username and password of the database user
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+type ArangoBackupSpecPolicy struct {
+	// RejectIfRunning rejects backup if other backup of the same deployment is in progress instead of queueing it
+	RejectIfRunning *bool `json:"rejectIfRunning,omitempty"`
+
+	// MaxFinalizeRetries defines number of failed finalize attempts after which finalizer is removed without cleanup
+	MaxFinalizeRetries *int `json:"maxFinalizeRetries,omitempty"`
+
+	// AvailableAfter defines phase after which backup is marked as available
+	AvailableAfter *ArangoBackupAvailableAfter `json:"availableAfter,omitempty"`
+}
+
+type ArangoBackupAvailableAfter string
+
+const (
+	// ArangoBackupAvailableAfterReady marks backup as available once it is created (default)
+	ArangoBackupAvailableAfterReady ArangoBackupAvailableAfter = "Ready"
+	// ArangoBackupAvailableAfterUpload marks backup as available once upload is completed
+	ArangoBackupAvailableAfterUpload ArangoBackupAvailableAfter = "Upload"
+)
+
+// GetAvailableAfter returns AvailableAfter or Ready if not set
+func (a *ArangoBackupSpecPolicy) GetAvailableAfter() ArangoBackupAvailableAfter {
+	if a == nil || a.AvailableAfter == nil {
+		return ArangoBackupAvailableAfterReady
+	}
+
+	return *a.AvailableAfter
+}
+
+// GetRejectIfRunning returns RejectIfRunning flag or false if not set
+func (a *ArangoBackupSpecPolicy) GetRejectIfRunning() bool {
+	if a == nil {
+		return false
+	}
+
+	return util.BoolOrDefault(a.RejectIfRunning)
+}
+
+// GetMaxFinalizeRetries returns MaxFinalizeRetries and true if limit is set
+func (a *ArangoBackupSpecPolicy) GetMaxFinalizeRetries() (int, bool) {
+	if a == nil || a.MaxFinalizeRetries == nil {
+		return 0, false
+	}
+
+	return *a.MaxFinalizeRetries, true
 }
 
 type ArangoBackupSpecDeployment struct {
 	Name string `json:"name,omitempty"`
+	// Namespace of the deployment. Deployment needs to be in the same namespace as backup,
+	// cross-namespace references are not supported.
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type ArangoBackupSpecOptions struct {
 	Timeout           *float32 `json:"timeout,omitempty"`
 	AllowInconsistent *bool    `json:"allowInconsistent,omitempty"`
+
+	// MaxSizeBytes defines maximum size of the backup, larger backups are marked as Failed
+	MaxSizeBytes *uint64 `json:"maxSizeBytes,omitempty"`
+
+	// DeleteIfSizeExceeded removes backup from the deployment when it exceeds MaxSizeBytes
+	DeleteIfSizeExceeded *bool `json:"deleteIfSizeExceeded,omitempty"`
+
+	// Label is passed to the ArangoDB server and becomes part of the backup ID
+	Label string `json:"label,omitempty"`
+
+	// StateTimeoutSeconds defines how long backup can stay in one of the operation states (e.g. Uploading)
+	// before it is marked as Failed and running server job is aborted. Timeout of the backup creation on the server is set with Timeout
+	StateTimeoutSeconds *int `json:"stateTimeoutSeconds,omitempty"`
+}
+
+// GetMaxSizeBytes returns MaxSizeBytes and true if limit is set
+func (a *ArangoBackupSpecOptions) GetMaxSizeBytes() (uint64, bool) {
+	if a == nil || a.MaxSizeBytes == nil {
+		return 0, false
+	}
+
+	return *a.MaxSizeBytes, true
+}
+
+// GetStateTimeout returns StateTimeoutSeconds as duration and true if timeout is set
+func (a *ArangoBackupSpecOptions) GetStateTimeout() (time.Duration, bool) {
+	if a == nil || a.StateTimeoutSeconds == nil {
+		return 0, false
+	}
+
+	return time.Duration(*a.StateTimeoutSeconds) * time.Second, true
+}
+
+// GetDeleteIfSizeExceeded returns DeleteIfSizeExceeded flag or false if not set
+func (a *ArangoBackupSpecOptions) GetDeleteIfSizeExceeded() bool {
+	if a == nil {
+		return false
+	}
+
+	return util.BoolOrDefault(a.DeleteIfSizeExceeded)
 }
 
 type ArangoBackupSpecOperation struct {
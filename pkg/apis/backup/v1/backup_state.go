@@ -40,25 +40,35 @@ const (
 	ArangoBackupStateUploadError   state.State = "UploadError"
 	ArangoBackupStateReady         state.State = "Ready"
 	ArangoBackupStateDeleted       state.State = "Deleted"
+	ArangoBackupStateDeleting      state.State = "Deleting"
 	ArangoBackupStateFailed        state.State = "Failed"
 	ArangoBackupStateUnavailable   state.State = "Unavailable"
+
+	ArangoBackupStateWaitingForCredentials state.State = "WaitingForCredentials"
+	ArangoBackupStateRejected              state.State = "Rejected"
+	ArangoBackupStateValidationOnly        state.State = "ValidationOnly"
 )
 
 var ArangoBackupStateMap = state.Map{
-	ArangoBackupStateNone:          {ArangoBackupStatePending},
-	ArangoBackupStatePending:       {ArangoBackupStateScheduled, ArangoBackupStateFailed},
+	ArangoBackupStateNone:          {ArangoBackupStatePending, ArangoBackupStateReady, ArangoBackupStateFailed},
+	ArangoBackupStatePending:       {ArangoBackupStateScheduled, ArangoBackupStateFailed, ArangoBackupStateRejected, ArangoBackupStateValidationOnly},
 	ArangoBackupStateScheduled:     {ArangoBackupStateDownload, ArangoBackupStateCreate, ArangoBackupStateFailed},
-	ArangoBackupStateDownload:      {ArangoBackupStateDownloading, ArangoBackupStateFailed, ArangoBackupStateDownloadError},
+	ArangoBackupStateDownload:      {ArangoBackupStateDownloading, ArangoBackupStateFailed, ArangoBackupStateDownloadError, ArangoBackupStateWaitingForCredentials},
 	ArangoBackupStateDownloading:   {ArangoBackupStateReady, ArangoBackupStateFailed, ArangoBackupStateDownloadError},
 	ArangoBackupStateDownloadError: {ArangoBackupStatePending, ArangoBackupStateFailed},
-	ArangoBackupStateCreate:        {ArangoBackupStateReady, ArangoBackupStateFailed},
+	ArangoBackupStateCreate:        {ArangoBackupStateReady, ArangoBackupStateFailed, ArangoBackupStateWaitingForCredentials},
 	ArangoBackupStateUpload:        {ArangoBackupStateUploading, ArangoBackupStateFailed, ArangoBackupStateDeleted, ArangoBackupStateUploadError},
-	ArangoBackupStateUploading:     {ArangoBackupStateReady, ArangoBackupStateFailed, ArangoBackupStateUploadError},
+	ArangoBackupStateUploading:     {ArangoBackupStateReady, ArangoBackupStateFailed, ArangoBackupStateUploadError, ArangoBackupStateUpload},
 	ArangoBackupStateUploadError:   {ArangoBackupStateFailed, ArangoBackupStateReady},
-	ArangoBackupStateReady:         {ArangoBackupStateDeleted, ArangoBackupStateFailed, ArangoBackupStateUpload, ArangoBackupStateUnavailable},
+	ArangoBackupStateReady:         {ArangoBackupStateDeleted, ArangoBackupStateFailed, ArangoBackupStateUpload, ArangoBackupStateUnavailable, ArangoBackupStateDeleting},
 	ArangoBackupStateDeleted:       {ArangoBackupStateFailed, ArangoBackupStateReady},
-	ArangoBackupStateFailed:        {ArangoBackupStatePending},
+	ArangoBackupStateDeleting:      {ArangoBackupStateFailed},
+	ArangoBackupStateFailed:        {ArangoBackupStatePending, ArangoBackupStateDeleting},
 	ArangoBackupStateUnavailable:   {ArangoBackupStateReady, ArangoBackupStateDeleted, ArangoBackupStateFailed},
+
+	ArangoBackupStateWaitingForCredentials: {ArangoBackupStateScheduled, ArangoBackupStateFailed},
+	ArangoBackupStateRejected:              {},
+	ArangoBackupStateValidationOnly:        {},
 }
 
 type ArangoBackupState struct {
@@ -92,6 +102,10 @@ func (a *ArangoBackupState) Equal(b *ArangoBackupState) bool {
 type ArangoBackupProgress struct {
 	JobID    string `json:"jobID"`
 	Progress string `json:"progress"`
+	// StartedAt is the time when the job was started on the server
+	StartedAt meta.Time `json:"startedAt,omitempty"`
+	// RepositoryURL is the destination of the running upload job
+	RepositoryURL string `json:"repositoryURL,omitempty"`
 }
 
 func (a *ArangoBackupProgress) Equal(b *ArangoBackupProgress) bool {
@@ -104,5 +118,7 @@ func (a *ArangoBackupProgress) Equal(b *ArangoBackupProgress) bool {
 	}
 
 	return a.JobID == b.JobID &&
-		a.Progress == b.Progress
+		a.Progress == b.Progress &&
+		a.StartedAt.Equal(&b.StartedAt) &&
+		a.RepositoryURL == b.RepositoryURL
 }
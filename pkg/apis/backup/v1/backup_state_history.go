@@ -0,0 +1,76 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/backup/state"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArangoBackupStateTransition describes transition of the backup between two states
+type ArangoBackupStateTransition struct {
+	From    state.State `json:"from"`
+	To      state.State `json:"to"`
+	Time    meta.Time   `json:"time"`
+	Message string      `json:"message,omitempty"`
+}
+
+func (a ArangoBackupStateTransition) Equal(b ArangoBackupStateTransition) bool {
+	return a.From == b.From &&
+		a.To == b.To &&
+		a.Time.Equal(&b.Time) &&
+		a.Message == b.Message
+}
+
+// ArangoBackupStateHistory keeps recent state transitions of the backup, oldest first
+type ArangoBackupStateHistory []ArangoBackupStateTransition
+
+func (a ArangoBackupStateHistory) Equal(b ArangoBackupStateHistory) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Append returns history with the transition added. Oldest transitions are dropped to keep at most limit entries.
+func (a ArangoBackupStateHistory) Append(transition ArangoBackupStateTransition, limit int) ArangoBackupStateHistory {
+	if limit <= 0 {
+		return a
+	}
+
+	history := append(ArangoBackupStateHistory{}, a...)
+	history = append(history, transition)
+
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+
+	return history
+}
@@ -24,6 +24,7 @@ package v1
 
 import (
 	shared "github.com/arangodb/kube-arangodb/pkg/apis/shared/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,8 +32,20 @@ import (
 // an ArangoBackup.
 type ArangoBackupStatus struct {
 	ArangoBackupState `json:",inline"`
-	Backup            *ArangoBackupDetails `json:"backup,omitempty"`
-	Available         bool                 `json:"available"`
+	Backup            *ArangoBackupDetails       `json:"backup,omitempty"`
+	Available         bool                       `json:"available"`
+	Manifest          *ArangoBackupManifest      `json:"manifest,omitempty"`
+	RestoreTarget     *ArangoBackupRestoreTarget `json:"restoreTarget,omitempty"`
+	FinalizeRetries   int                        `json:"finalizeRetries,omitempty"`
+	JobError          *ArangoBackupJobError      `json:"jobError,omitempty"`
+	// EncryptionSecretVersion keeps resource version of the deployment encryption key secret used during backup creation
+	EncryptionSecretVersion string `json:"encryptionSecretVersion,omitempty"`
+	// EncryptionSecretWarnedVersion keeps resource version of the encryption key secret for which the key change was reported
+	EncryptionSecretWarnedVersion string `json:"encryptionSecretWarnedVersion,omitempty"`
+	// History keeps recent state transitions of the backup, if enabled in the operator
+	History ArangoBackupStateHistory `json:"history,omitempty"`
+	// Paused is true when reconciliation of the backup is paused with the paused annotation
+	Paused bool `json:"paused,omitempty"`
 }
 
 func (a *ArangoBackupStatus) Equal(b *ArangoBackupStatus) bool {
@@ -46,20 +59,47 @@ func (a *ArangoBackupStatus) Equal(b *ArangoBackupStatus) bool {
 
 	return a.ArangoBackupState.Equal(&b.ArangoBackupState) &&
 		a.Backup.Equal(b.Backup) &&
-		a.Available == b.Available
+		a.Available == b.Available &&
+		a.Manifest.Equal(b.Manifest) &&
+		a.RestoreTarget.Equal(b.RestoreTarget) &&
+		a.FinalizeRetries == b.FinalizeRetries &&
+		a.JobError.Equal(b.JobError) &&
+		a.EncryptionSecretVersion == b.EncryptionSecretVersion &&
+		a.EncryptionSecretWarnedVersion == b.EncryptionSecretWarnedVersion &&
+		a.History.Equal(b.History) &&
+		a.Paused == b.Paused
+}
+
+// IsImported returns true if backup was discovered on the server and imported by the operator
+func (a *ArangoBackupStatus) IsImported() bool {
+	if a.Backup == nil || a.Backup.Imported == nil {
+		return false
+	}
+
+	return *a.Backup.Imported
 }
 
 type ArangoBackupDetails struct {
-	ID                      string          `json:"id"`
-	Version                 string          `json:"version"`
-	PotentiallyInconsistent *bool           `json:"potentiallyInconsistent,omitempty"`
-	SizeInBytes             uint64          `json:"sizeInBytes,omitempty"`
-	NumberOfDBServers       uint            `json:"numberOfDBServers,omitempty"`
-	Uploaded                *bool           `json:"uploaded,omitempty"`
-	Downloaded              *bool           `json:"downloaded,omitempty"`
-	Imported                *bool           `json:"imported,omitempty"`
-	CreationTimestamp       meta.Time       `json:"createdAt"`
-	Keys                    shared.HashList `json:"keys,omitempty"`
+	ID                      string `json:"id"`
+	Version                 string `json:"version"`
+	PotentiallyInconsistent *bool  `json:"potentiallyInconsistent,omitempty"`
+	// ConsistencyLevel is the consistency level achieved by the server
+	ConsistencyLevel  ArangoBackupConsistencyLevel `json:"consistencyLevel,omitempty"`
+	SizeInBytes       uint64                       `json:"sizeInBytes,omitempty"`
+	NumberOfDBServers uint                         `json:"numberOfDBServers,omitempty"`
+	Uploaded          *bool                        `json:"uploaded,omitempty"`
+	// UploadedTo lists repositories to which upload is completed
+	UploadedTo        []string        `json:"uploadedTo,omitempty"`
+	Downloaded        *bool           `json:"downloaded,omitempty"`
+	Imported          *bool           `json:"imported,omitempty"`
+	CreationTimestamp meta.Time       `json:"createdAt"`
+	Keys              shared.HashList `json:"keys,omitempty"`
+	// ServerVersion is the ArangoDB server version of the deployment at the time of backup
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// DurationSeconds is the time the backup creation took, not set for imported backups
+	DurationSeconds *float32 `json:"durationSeconds,omitempty"`
+	// Label is the label passed to the ArangoDB server during backup creation
+	Label string `json:"label,omitempty"`
 }
 
 func (a *ArangoBackupDetails) Equal(b *ArangoBackupDetails) bool {
@@ -73,14 +113,19 @@ func (a *ArangoBackupDetails) Equal(b *ArangoBackupDetails) bool {
 
 	return a.ID == b.ID &&
 		a.Version == b.Version &&
+		a.Label == b.Label &&
 		a.SizeInBytes == b.SizeInBytes &&
 		a.NumberOfDBServers == b.NumberOfDBServers &&
 		a.CreationTimestamp.Equal(&b.CreationTimestamp) &&
 		compareBoolPointer(a.PotentiallyInconsistent, b.PotentiallyInconsistent) &&
+		a.ConsistencyLevel == b.ConsistencyLevel &&
 		compareBoolPointer(a.Uploaded, b.Uploaded) &&
+		util.CompareStringArray(a.UploadedTo, b.UploadedTo) &&
 		compareBoolPointer(a.Downloaded, b.Downloaded) &&
 		compareBoolPointer(a.Imported, b.Imported) &&
-		a.Keys.Equal(b.Keys)
+		a.Keys.Equal(b.Keys) &&
+		a.ServerVersion == b.ServerVersion &&
+		compareFloat32Pointer(a.DurationSeconds, b.DurationSeconds)
 }
 
 func compareBoolPointer(a, b *bool) bool {
@@ -98,3 +143,15 @@ func compareBoolPointer(a, b *bool) bool {
 
 	return false
 }
+
+func compareFloat32Pointer(a, b *float32) bool {
+	if a == nil && b != nil || a != nil && b == nil {
+		return false
+	}
+
+	if a == b {
+		return true
+	}
+
+	return *a == *b
+}
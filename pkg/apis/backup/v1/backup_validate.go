@@ -22,13 +22,35 @@
 
 package v1
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/deployment"
+)
 
 func (a *ArangoBackup) Validate() error {
 	if err := a.Spec.Validate(); err != nil {
 		return err
 	}
 
+	if err := a.Spec.Deployment.ValidateNamespace(a.Namespace); err != nil {
+		return err
+	}
+
+	if t := a.Spec.RestoreTargetDeployment; t != nil {
+		if err := t.ValidateNamespace(a.Namespace); err != nil {
+			return err
+		}
+	}
+
+	// Upload requirement is enforced only until the backup is created, so the removal
+	// of the upload from the spec does not fail a backup which already exists on the server
+	if a.Status.Backup == nil && a.Spec.Upload == nil {
+		if after := a.Spec.Policy.GetAvailableAfter(); after == ArangoBackupAvailableAfterUpload {
+			return fmt.Errorf("available after %s requires upload to be specified", after)
+		}
+	}
+
 	if err := a.Status.Validate(); err != nil {
 		return err
 	}
@@ -36,11 +58,47 @@ func (a *ArangoBackup) Validate() error {
 	return nil
 }
 
+// ValidateImported ensures that spec of the imported backup was not changed to trigger new operations.
+// Only labels and annotations of imported backups can be changed.
+func (a *ArangoBackup) ValidateImported() error {
+	if !a.Status.IsImported() {
+		return nil
+	}
+
+	if a.Spec.Download != nil {
+		return fmt.Errorf("download can not be specified for imported backup")
+	}
+
+	if a.Spec.Upload != nil || len(a.Spec.Uploads) > 0 {
+		return fmt.Errorf("upload can not be specified for imported backup")
+	}
+
+	if a.Spec.Options != nil {
+		return fmt.Errorf("options can not be specified for imported backup")
+	}
+
+	for _, owner := range a.OwnerReferences {
+		if owner.Kind != deployment.ArangoDeploymentResourceKind {
+			continue
+		}
+
+		if owner.Name != a.Spec.Deployment.Name {
+			return fmt.Errorf("deployment of imported backup can not be changed from %s to %s", owner.Name, a.Spec.Deployment.Name)
+		}
+	}
+
+	return nil
+}
+
 func (a *ArangoBackupSpec) Validate() error {
 	if a.Deployment.Name == "" {
 		return fmt.Errorf("deployment name can not be empty")
 	}
 
+	if err := a.validateConsistencyLevel(); err != nil {
+		return err
+	}
+
 	if a.Download != nil {
 		if err := a.Download.Validate(); err != nil {
 			return err
@@ -53,6 +111,90 @@ func (a *ArangoBackupSpec) Validate() error {
 		}
 	}
 
+	if a.Download != nil && a.Upload != nil {
+		return fmt.Errorf("download and upload can not be specified at the same time")
+	}
+
+	if err := a.validateUploads(); err != nil {
+		return err
+	}
+
+	if a.User != nil && a.User.CredentialsSecretName == "" {
+		return fmt.Errorf("user credentials secret name can not be empty")
+	}
+
+	if max, ok := a.Options.GetMaxSizeBytes(); ok && max == 0 {
+		return fmt.Errorf("max size bytes needs to be greater than 0")
+	}
+
+	if err := a.Options.validateLabel(); err != nil {
+		return err
+	}
+
+	if timeout, ok := a.Options.GetStateTimeout(); ok && timeout <= 0 {
+		return fmt.Errorf("state timeout seconds needs to be greater than 0")
+	}
+
+	if max, ok := a.Policy.GetMaxFinalizeRetries(); ok && max < 0 {
+		return fmt.Errorf("max finalize retries can not be negative")
+	}
+
+	switch after := a.Policy.GetAvailableAfter(); after {
+	case ArangoBackupAvailableAfterReady:
+	case ArangoBackupAvailableAfterUpload:
+	default:
+		return fmt.Errorf("available after %s is not supported", after)
+	}
+
+	if err := a.ManifestTarget.Validate(); err != nil {
+		return err
+	}
+
+	if err := a.Retention.Validate(); err != nil {
+		return err
+	}
+
+	if t := a.RestoreTargetDeployment; t != nil {
+		if t.Name == "" {
+			return fmt.Errorf("restore target deployment name can not be empty")
+		}
+
+		if t.Name == a.Deployment.Name {
+			return fmt.Errorf("restore target deployment needs to be different from the source deployment")
+		}
+	}
+
+	return nil
+}
+
+func (a *ArangoBackupSpec) validateUploads() error {
+	if len(a.Uploads) > 0 && a.Upload == nil {
+		return fmt.Errorf("uploads require upload to be specified")
+	}
+
+	repositories := map[string]bool{}
+
+	for _, upload := range a.GetUploads() {
+		if err := upload.Validate(); err != nil {
+			return err
+		}
+
+		if repositories[upload.RepositoryURL] {
+			return fmt.Errorf("upload repository %s is specified more than once", upload.RepositoryURL)
+		}
+
+		repositories[upload.RepositoryURL] = true
+	}
+
+	return nil
+}
+
+// ValidateNamespace ensures that deployment reference points to the backup namespace.
+func (a ArangoBackupSpecDeployment) ValidateNamespace(namespace string) error {
+	if a.Namespace != "" && a.Namespace != namespace {
+		return fmt.Errorf("deployment %s/%s is not in backup namespace %s, cross-namespace references are not supported", a.Namespace, a.Name, namespace)
+	}
+
 	return nil
 }
 
@@ -71,6 +71,11 @@ func (in *ArangoBackupDetails) DeepCopyInto(out *ArangoBackupDetails) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UploadedTo != nil {
+		in, out := &in.UploadedTo, &out.UploadedTo
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Downloaded != nil {
 		in, out := &in.Downloaded, &out.Downloaded
 		*out = new(bool)
@@ -87,6 +92,11 @@ func (in *ArangoBackupDetails) DeepCopyInto(out *ArangoBackupDetails) {
 		*out = make(sharedv1.HashList, len(*in))
 		copy(*out, *in)
 	}
+	if in.DurationSeconds != nil {
+		in, out := &in.DurationSeconds, &out.DurationSeconds
+		*out = new(float32)
+		**out = **in
+	}
 	return
 }
 
@@ -100,6 +110,43 @@ func (in *ArangoBackupDetails) DeepCopy() *ArangoBackupDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoBackupJobError) DeepCopyInto(out *ArangoBackupJobError) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]ArangoBackupJobServerError, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoBackupJobError.
+func (in *ArangoBackupJobError) DeepCopy() *ArangoBackupJobError {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoBackupJobError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoBackupJobServerError) DeepCopyInto(out *ArangoBackupJobServerError) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoBackupJobServerError.
+func (in *ArangoBackupJobServerError) DeepCopy() *ArangoBackupJobServerError {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoBackupJobServerError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArangoBackupList) DeepCopyInto(out *ArangoBackupList) {
 	*out = *in
@@ -133,6 +180,44 @@ func (in *ArangoBackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoBackupManifest) DeepCopyInto(out *ArangoBackupManifest) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+	if in.Collections != nil {
+		in, out := &in.Collections, &out.Collections
+		*out = make([]ArangoBackupManifestCollection, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoBackupManifest.
+func (in *ArangoBackupManifest) DeepCopy() *ArangoBackupManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoBackupManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoBackupManifestCollection) DeepCopyInto(out *ArangoBackupManifestCollection) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoBackupManifestCollection.
+func (in *ArangoBackupManifestCollection) DeepCopy() *ArangoBackupManifestCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoBackupManifestCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArangoBackupPolicy) DeepCopyInto(out *ArangoBackupPolicy) {
 	*out = *in
@@ -203,6 +288,11 @@ func (in *ArangoBackupPolicySpec) DeepCopyInto(out *ArangoBackupPolicySpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.BackupTemplate.DeepCopyInto(&out.BackupTemplate)
+	if in.Cascade != nil {
+		in, out := &in.Cascade, &out.Cascade
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -236,6 +326,7 @@ func (in *ArangoBackupPolicyStatus) DeepCopy() *ArangoBackupPolicyStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArangoBackupProgress) DeepCopyInto(out *ArangoBackupProgress) {
 	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
 	return
 }
 
@@ -249,6 +340,22 @@ func (in *ArangoBackupProgress) DeepCopy() *ArangoBackupProgress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoBackupRestoreTarget) DeepCopyInto(out *ArangoBackupRestoreTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoBackupRestoreTarget.
+func (in *ArangoBackupRestoreTarget) DeepCopy() *ArangoBackupRestoreTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoBackupRestoreTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArangoBackupSpec) DeepCopyInto(out *ArangoBackupSpec) {
 	*out = *in
@@ -258,6 +365,11 @@ func (in *ArangoBackupSpec) DeepCopyInto(out *ArangoBackupSpec) {
 		*out = new(ArangoBackupSpecOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConsistencyLevel != nil {
+		in, out := &in.ConsistencyLevel, &out.ConsistencyLevel
+		*out = new(ArangoBackupConsistencyLevel)
+		**out = **in
+	}
 	if in.Download != nil {
 		in, out := &in.Download, &out.Download
 		*out = new(ArangoBackupSpecDownload)
@@ -268,11 +380,46 @@ func (in *ArangoBackupSpec) DeepCopyInto(out *ArangoBackupSpec) {
 		*out = new(ArangoBackupSpecOperation)
 		**out = **in
 	}
+	if in.Uploads != nil {
+		in, out := &in.Uploads, &out.Uploads
+		*out = make([]ArangoBackupSpecOperation, len(*in))
+		copy(*out, *in)
+	}
 	if in.PolicyName != nil {
 		in, out := &in.PolicyName, &out.PolicyName
 		*out = new(string)
 		**out = **in
 	}
+	if in.GenerateManifest != nil {
+		in, out := &in.GenerateManifest, &out.GenerateManifest
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManifestTarget != nil {
+		in, out := &in.ManifestTarget, &out.ManifestTarget
+		*out = new(ArangoBackupManifestTarget)
+		**out = **in
+	}
+	if in.RestoreTargetDeployment != nil {
+		in, out := &in.RestoreTargetDeployment, &out.RestoreTargetDeployment
+		*out = new(ArangoBackupSpecDeployment)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(ArangoBackupSpecPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(ArangoBackupSpecUser)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ArangoBackupSpecRetention)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -348,6 +495,21 @@ func (in *ArangoBackupSpecOptions) DeepCopyInto(out *ArangoBackupSpecOptions) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.MaxSizeBytes != nil {
+		in, out := &in.MaxSizeBytes, &out.MaxSizeBytes
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.DeleteIfSizeExceeded != nil {
+		in, out := &in.DeleteIfSizeExceeded, &out.DeleteIfSizeExceeded
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StateTimeoutSeconds != nil {
+		in, out := &in.StateTimeoutSeconds, &out.StateTimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -361,6 +523,79 @@ func (in *ArangoBackupSpecOptions) DeepCopy() *ArangoBackupSpecOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoBackupSpecPolicy) DeepCopyInto(out *ArangoBackupSpecPolicy) {
+	*out = *in
+	if in.RejectIfRunning != nil {
+		in, out := &in.RejectIfRunning, &out.RejectIfRunning
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxFinalizeRetries != nil {
+		in, out := &in.MaxFinalizeRetries, &out.MaxFinalizeRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.AvailableAfter != nil {
+		in, out := &in.AvailableAfter, &out.AvailableAfter
+		*out = new(ArangoBackupAvailableAfter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoBackupSpecPolicy.
+func (in *ArangoBackupSpecPolicy) DeepCopy() *ArangoBackupSpecPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoBackupSpecPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoBackupSpecRetention) DeepCopyInto(out *ArangoBackupSpecRetention) {
+	*out = *in
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoBackupSpecRetention.
+func (in *ArangoBackupSpecRetention) DeepCopy() *ArangoBackupSpecRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoBackupSpecRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoBackupSpecUser) DeepCopyInto(out *ArangoBackupSpecUser) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoBackupSpecUser.
+func (in *ArangoBackupSpecUser) DeepCopy() *ArangoBackupSpecUser {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoBackupSpecUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArangoBackupState) DeepCopyInto(out *ArangoBackupState) {
 	*out = *in
@@ -368,7 +603,7 @@ func (in *ArangoBackupState) DeepCopyInto(out *ArangoBackupState) {
 	if in.Progress != nil {
 		in, out := &in.Progress, &out.Progress
 		*out = new(ArangoBackupProgress)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -383,6 +618,45 @@ func (in *ArangoBackupState) DeepCopy() *ArangoBackupState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ArangoBackupStateHistory) DeepCopyInto(out *ArangoBackupStateHistory) {
+	{
+		in := &in
+		*out = make(ArangoBackupStateHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoBackupStateHistory.
+func (in ArangoBackupStateHistory) DeepCopy() ArangoBackupStateHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoBackupStateHistory)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArangoBackupStateTransition) DeepCopyInto(out *ArangoBackupStateTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArangoBackupStateTransition.
+func (in *ArangoBackupStateTransition) DeepCopy() *ArangoBackupStateTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(ArangoBackupStateTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArangoBackupStatus) DeepCopyInto(out *ArangoBackupStatus) {
 	*out = *in
@@ -392,6 +666,28 @@ func (in *ArangoBackupStatus) DeepCopyInto(out *ArangoBackupStatus) {
 		*out = new(ArangoBackupDetails)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Manifest != nil {
+		in, out := &in.Manifest, &out.Manifest
+		*out = new(ArangoBackupManifest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreTarget != nil {
+		in, out := &in.RestoreTarget, &out.RestoreTarget
+		*out = new(ArangoBackupRestoreTarget)
+		**out = **in
+	}
+	if in.JobError != nil {
+		in, out := &in.JobError, &out.JobError
+		*out = new(ArangoBackupJobError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make(ArangoBackupStateHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -418,6 +714,16 @@ func (in *ArangoBackupTemplate) DeepCopyInto(out *ArangoBackupTemplate) {
 		*out = new(ArangoBackupSpecOperation)
 		**out = **in
 	}
+	if in.Uploads != nil {
+		in, out := &in.Uploads, &out.Uploads
+		*out = make([]ArangoBackupSpecOperation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ArangoBackupSpecRetention)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
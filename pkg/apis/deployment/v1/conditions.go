@@ -61,6 +61,8 @@ const (
 	ConditionTypeTerminating ConditionType = "Terminating"
 	// ConditionTypeTerminating indicates that the deployment is up to date.
 	ConditionTypeUpToDate ConditionType = "UpToDate"
+	// ConditionTypeUpgradePending indicates that upgrade of the members to the new image is pending.
+	ConditionTypeUpgradePending ConditionType = "UpgradePending"
 )
 
 // Condition represents one current condition of a deployment or deployment member.
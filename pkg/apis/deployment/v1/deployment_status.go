@@ -73,6 +73,9 @@ type DeploymentStatus struct {
 	// Hashes keep status of hashes in deployment
 	Hashes DeploymentStatusHashes `json:"hashes,omitempty"`
 
+	// TopologyRebalance keeps the last rotation done to fix topology spread constraints
+	TopologyRebalance *DeploymentTopologyRebalance `json:"topologyRebalance,omitempty"`
+
 	// ForceStatusReload if set to true forces a reload of the status from the custom resource.
 	ForceStatusReload *bool `json:"force-status-reload,omitempty"`
 }
@@ -92,7 +95,8 @@ func (ds *DeploymentStatus) Equal(other DeploymentStatus) bool {
 		ds.Conditions.Equal(other.Conditions) &&
 		ds.Plan.Equal(other.Plan) &&
 		ds.AcceptedSpec.Equal(other.AcceptedSpec) &&
-		ds.SecretHashes.Equal(other.SecretHashes)
+		ds.SecretHashes.Equal(other.SecretHashes) &&
+		ds.TopologyRebalance.Equal(other.TopologyRebalance)
 }
 
 // IsForceReload returns true if ForceStatusReload is set to true
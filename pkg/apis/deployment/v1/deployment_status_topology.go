@@ -0,0 +1,51 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentTopologyRebalance keeps the last rotation of a member done to rebalance topology domains
+type DeploymentTopologyRebalance struct {
+	// Time of the rotation
+	Time meta.Time `json:"time"`
+	// Group of the rotated member
+	Group ServerGroup `json:"group"`
+	// Skew between the most and the least loaded domain before the rotation
+	Skew int32 `json:"skew"`
+}
+
+func (d *DeploymentTopologyRebalance) Equal(other *DeploymentTopologyRebalance) bool {
+	if d == nil {
+		return other == nil
+	}
+
+	if other == nil {
+		return false
+	}
+
+	return d.Time.Equal(&other.Time) &&
+		d.Group == other.Group &&
+		d.Skew == other.Skew
+}
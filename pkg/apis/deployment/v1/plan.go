@@ -97,6 +97,8 @@ const (
 	ActionTypeBackupRestore ActionType = "BackupRestore"
 	// ActionTypeBackupRestoreClean restore plan
 	ActionTypeBackupRestoreClean ActionType = "BackupRestoreClean"
+	// ActionTypeBackupRestoreRefused marks restore as failed when backup is not allowed to be restored in the deployment
+	ActionTypeBackupRestoreRefused ActionType = "BackupRestoreRefused"
 	// ActionTypeEncryptionKeyAdd add new encryption key to list
 	ActionTypeEncryptionKeyAdd ActionType = "EncryptionKeyAdd"
 	// ActionTypeEncryptionKeyRemove removes encryption key to list
@@ -125,6 +127,8 @@ const (
 	ActionTypeEnableMaintenance ActionType = "EnableMaintenance"
 	// ActionTypeEnableMaintenance disables maintenance on cluster.
 	ActionTypeDisableMaintenance ActionType = "DisableMaintenance"
+	// ActionTypeTopologyRebalanceUpdate records rotation done to rebalance topology domains in the status
+	ActionTypeTopologyRebalanceUpdate ActionType = "TopologyRebalanceUpdate"
 )
 
 const (
@@ -22,8 +22,35 @@
 
 package v1
 
+import (
+	"github.com/arangodb/kube-arangodb/pkg/apis/shared"
+	"github.com/pkg/errors"
+)
+
 type ServerGroupEnvVars []ServerGroupEnvVar
 
+// Validate if env vars have names and are not defined more than once
+func (s ServerGroupEnvVars) Validate() error {
+	var validationErrors []error
+
+	names := map[string]bool{}
+
+	for _, env := range s {
+		if env.Name == "" {
+			validationErrors = append(validationErrors, errors.Errorf("env name can not be empty"))
+			continue
+		}
+
+		if names[env.Name] {
+			validationErrors = append(validationErrors, errors.Errorf("env with name %s defined more than once", env.Name))
+		}
+
+		names[env.Name] = true
+	}
+
+	return shared.WithErrors(validationErrors...)
+}
+
 type ServerGroupEnvVar struct {
 	Name  string `json:"name" protobuf:"bytes,1,opt,name=name"`
 	Value string `json:"value,omitempty"`
@@ -0,0 +1,93 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"sort"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/shared"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+)
+
+var (
+	reservedServerGroupInitContainerNames = []string{
+		k8sutil.InitLifecycleContainerName,
+		k8sutil.InitDataContainerName,
+	}
+)
+
+// IsReservedServerGroupInitContainerName check if container name is reserved for init containers managed by operator
+func IsReservedServerGroupInitContainerName(name string) bool {
+	for _, reservedName := range reservedServerGroupInitContainerNames {
+		if reservedName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ServerGroupInitContainers defines changes of the init containers managed by operator.
+// Only environment variables can be added, image and command of the reserved init containers can not be changed
+type ServerGroupInitContainers struct {
+	// ReservedEnv defines additional environment variables of the reserved init containers, keyed by container name
+	ReservedEnv map[string]ServerGroupEnvVars `json:"reservedEnv,omitempty"`
+}
+
+// GetReservedEnv returns additional environment variables of the reserved init container with given name
+func (s *ServerGroupInitContainers) GetReservedEnv(name string) ServerGroupEnvVars {
+	if s == nil {
+		return nil
+	}
+
+	return s.ReservedEnv[name]
+}
+
+// Validate if ServerGroupSpec init containers are valid
+func (s *ServerGroupInitContainers) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	var validationErrors []error
+
+	names := make([]string, 0, len(s.ReservedEnv))
+	for name := range s.ReservedEnv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !IsReservedServerGroupInitContainerName(name) {
+			validationErrors = append(validationErrors, errors.Errorf("container with name %s is not reserved init container", name))
+			continue
+		}
+
+		if err := s.ReservedEnv[name].Validate(); err != nil {
+			validationErrors = append(validationErrors, shared.PrefixResourceErrors(name, err))
+		}
+	}
+
+	return shared.PrefixResourceErrors("reservedEnv", validationErrors...)
+}
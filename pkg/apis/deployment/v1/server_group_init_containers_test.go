@@ -0,0 +1,147 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InitContainers_Validation(t *testing.T) {
+	cases := []struct {
+		name           string
+		initContainers *ServerGroupInitContainers
+		fail           bool
+		failedFields   map[string]string
+	}{
+		{
+			name: "Nil definition",
+		},
+		{
+			name: "Env of reserved init containers",
+
+			initContainers: &ServerGroupInitContainers{
+				ReservedEnv: map[string]ServerGroupEnvVars{
+					"uuid": {
+						{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+					},
+					"init-lifecycle": {
+						{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+						{Name: "NO_PROXY", Value: "localhost"},
+					},
+				},
+			},
+		},
+		{
+			name: "Env of not reserved container",
+
+			fail: true,
+			failedFields: map[string]string{
+				"reservedEnv": "container with name server is not reserved init container",
+			},
+
+			initContainers: &ServerGroupInitContainers{
+				ReservedEnv: map[string]ServerGroupEnvVars{
+					"server": {
+						{Name: "HTTP_PROXY"},
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid env",
+
+			fail: true,
+			failedFields: map[string]string{
+				"reservedEnv.init-lifecycle": "env name can not be empty",
+				"reservedEnv.uuid":           "env with name HTTP_PROXY defined more than once",
+			},
+
+			initContainers: &ServerGroupInitContainers{
+				ReservedEnv: map[string]ServerGroupEnvVars{
+					"uuid": {
+						{Name: "HTTP_PROXY"},
+						{Name: "HTTP_PROXY"},
+					},
+					"init-lifecycle": {
+						{Value: "value"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.initContainers.Validate()
+
+			if c.fail {
+				require.Error(t, err)
+
+				mergedErr, ok := err.(shared.MergedErrors)
+				require.True(t, ok, "Is not MergedError type")
+
+				require.Equal(t, len(mergedErr.Errors()), len(c.failedFields), "Count of expected fields and merged errors does not match")
+
+				for _, fieldError := range mergedErr.Errors() {
+					resourceErr, ok := fieldError.(shared.ResourceError)
+					require.True(t, ok, "Is not ResourceError type")
+
+					errValue, ok := c.failedFields[resourceErr.Prefix]
+					require.True(t, ok, "unexpected prefix %s", resourceErr.Prefix)
+
+					require.EqualError(t, resourceErr.Err, errValue)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_InitContainers_ReservedNames(t *testing.T) {
+	for _, name := range reservedServerGroupInitContainerNames {
+		require.True(t, IsReservedServerGroupInitContainerName(name), name)
+		require.True(t, IsReservedServerGroupContainerName(name), name)
+	}
+
+	require.False(t, IsReservedServerGroupInitContainerName(validName))
+}
+
+func Test_InitContainers_GetReservedEnv(t *testing.T) {
+	var initContainers *ServerGroupInitContainers
+	require.Nil(t, initContainers.GetReservedEnv("uuid"))
+
+	initContainers = &ServerGroupInitContainers{
+		ReservedEnv: map[string]ServerGroupEnvVars{
+			"uuid": {
+				{Name: "HTTP_PROXY"},
+			},
+		},
+	}
+	require.Len(t, initContainers.GetReservedEnv("uuid"), 1)
+	require.Nil(t, initContainers.GetReservedEnv("init-lifecycle"))
+}
@@ -0,0 +1,90 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/shared"
+	sharedv1 "github.com/arangodb/kube-arangodb/pkg/apis/shared/v1"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+)
+
+var (
+	reservedServerGroupContainerNames = []string{
+		k8sutil.ServerContainerName,
+		k8sutil.ExporterContainerName,
+		k8sutil.InitLifecycleContainerName,
+		k8sutil.InitDataContainerName,
+	}
+)
+
+// IsReservedServerGroupContainerName check if container name is reserved for containers managed by operator
+func IsReservedServerGroupContainerName(name string) bool {
+	for _, reservedName := range reservedServerGroupContainerNames {
+		if reservedName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ServerGroupSidecars definition of additional containers which need to be started in the Pod
+type ServerGroupSidecars []core.Container
+
+// Validate if ServerGroupSpec sidecars are valid and does not collide
+func (s ServerGroupSidecars) Validate() error {
+	var validationErrors []error
+
+	mappedContainers := map[string]int{}
+
+	for id, container := range s {
+		if i, ok := mappedContainers[container.Name]; ok {
+			mappedContainers[container.Name] = i + 1
+		} else {
+			mappedContainers[container.Name] = 1
+		}
+
+		if err := shared.PrefixResourceErrors("name", sharedv1.AsKubernetesResourceName(&container.Name).Validate()); err != nil {
+			validationErrors = append(validationErrors, shared.PrefixResourceErrors(fmt.Sprintf("%d", id), err))
+		}
+	}
+
+	for containerName, count := range mappedContainers {
+		if IsReservedServerGroupContainerName(containerName) {
+			validationErrors = append(validationErrors, errors.Errorf("container with name %s is reserved", containerName))
+		}
+
+		if count == 1 {
+			continue
+		}
+
+		validationErrors = append(validationErrors, errors.Errorf("container with name %s defined more than once: %d", containerName, count))
+	}
+
+	return shared.WithErrors(validationErrors...)
+}
@@ -0,0 +1,143 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/shared"
+
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+)
+
+func Test_Sidecars_Validation(t *testing.T) {
+	cases := []struct {
+		name         string
+		sidecars     ServerGroupSidecars
+		fail         bool
+		failedFields map[string]string
+	}{
+		{
+			name: "Nil definition",
+		},
+		{
+			name: "Invalid name",
+
+			fail: true,
+			failedFields: map[string]string{
+				"0.name": labelValidationError,
+			},
+
+			sidecars: []core.Container{
+				{
+					Name: invalidName,
+				},
+			},
+		},
+		{
+			name: "Reserved name",
+
+			fail: true,
+			failedFields: map[string]string{
+				"": fmt.Sprintf("container with name %s is reserved", reservedServerGroupContainerNames[0]),
+			},
+
+			sidecars: []core.Container{
+				{
+					Name: reservedServerGroupContainerNames[0],
+				},
+			},
+		},
+		{
+			name: "Defined multiple sidecars with same name",
+
+			fail: true,
+			failedFields: map[string]string{
+				"": "container with name valid defined more than once: 2",
+			},
+
+			sidecars: []core.Container{
+				{
+					Name: validName,
+				},
+				{
+					Name: validName,
+				},
+			},
+		},
+		{
+			name: "Defined multiple sidecars",
+
+			sidecars: []core.Container{
+				{
+					Name: validName,
+				},
+				{
+					Name: "valid-2",
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.sidecars.Validate()
+
+			if c.fail {
+				require.Error(t, err)
+
+				mergedErr, ok := err.(shared.MergedErrors)
+				require.True(t, ok, "Is not MergedError type")
+
+				require.Equal(t, len(mergedErr.Errors()), len(c.failedFields), "Count of expected fields and merged errors does not match")
+
+				for _, fieldError := range mergedErr.Errors() {
+					resourceErr, ok := fieldError.(shared.ResourceError)
+					if !ok {
+						resourceErr = shared.ResourceError{
+							Prefix: "",
+							Err:    fieldError,
+						}
+					}
+
+					errValue, ok := c.failedFields[resourceErr.Prefix]
+					require.True(t, ok, "unexpected prefix %s", resourceErr.Prefix)
+
+					require.EqualError(t, resourceErr.Err, errValue)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_Sidecars_ReservedNames(t *testing.T) {
+	for _, name := range reservedServerGroupContainerNames {
+		require.True(t, IsReservedServerGroupContainerName(name), name)
+	}
+
+	require.False(t, IsReservedServerGroupContainerName(validName))
+}
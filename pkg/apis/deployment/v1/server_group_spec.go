@@ -90,8 +90,12 @@ type ServerGroupSpec struct {
 	Affinity *core.PodAffinity `json:"affinity,omitempty"`
 	// NodeAffinity specified additional nodeAffinity settings in ArangoDB Pod definitions
 	NodeAffinity *core.NodeAffinity `json:"nodeAffinity,omitempty"`
+	// TopologySpreadConstraints specifies how Pods of this group are spread across topology domains
+	TopologySpreadConstraints []core.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 	// Sidecars specifies a list of additional containers to be started
-	Sidecars []core.Container `json:"sidecars,omitempty"`
+	Sidecars ServerGroupSidecars `json:"sidecars,omitempty"`
+	// InitContainers specifies changes of the init containers managed by operator
+	InitContainers *ServerGroupInitContainers `json:"initContainers,omitempty"`
 	// SecurityContext specifies security context for group
 	SecurityContext *ServerGroupSpecSecurityContext `json:"securityContext,omitempty"`
 	// Volumes define list of volumes mounted to pod
@@ -352,6 +356,11 @@ func (s ServerGroupSpec) GetTolerations() []core.Toleration {
 	return s.Tolerations
 }
 
+// GetTopologySpreadConstraints returns the value of topologySpreadConstraints.
+func (s ServerGroupSpec) GetTopologySpreadConstraints() []core.TopologySpreadConstraint {
+	return s.TopologySpreadConstraints
+}
+
 // GetServiceAccountName returns the value of serviceAccountName.
 func (s ServerGroupSpec) GetServiceAccountName() string {
 	return util.StringOrDefault(s.ServiceAccountName)
@@ -470,8 +479,11 @@ func (s *ServerGroupSpec) validate() error {
 
 	return shared.WithErrors(
 		shared.PrefixResourceError("volumes", s.Volumes.Validate()),
+		shared.PrefixResourceError("sidecars", s.Sidecars.Validate()),
+		shared.PrefixResourceError("initContainers", s.InitContainers.Validate()),
 		shared.PrefixResourceError("volumeMounts", s.VolumeMounts.Validate()),
 		s.validateVolumes(),
+		shared.PrefixResourceError("topologySpreadConstraints", s.validateTopologySpreadConstraints()),
 	)
 }
 
@@ -564,6 +576,9 @@ func (s *ServerGroupSpec) SetDefaultsFrom(source ServerGroupSpec) {
 	if s.Tolerations == nil {
 		s.Tolerations = source.Tolerations
 	}
+	if s.TopologySpreadConstraints == nil {
+		s.TopologySpreadConstraints = source.TopologySpreadConstraints
+	}
 	if s.ServiceAccountName == nil {
 		s.ServiceAccountName = util.NewStringOrNil(source.ServiceAccountName)
 	}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/arangodb/kube-arangodb/pkg/util"
 	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
 )
 
 func TestServerGroupSpecValidateCount(t *testing.T) {
@@ -131,3 +132,40 @@ func TestServerGroupSpecValidateArgs(t *testing.T) {
 	assert.Error(t, ServerGroupSpec{Count: util.NewInt(1), Args: []string{"--master.endpoint=http://something"}}.Validate(ServerGroupSyncMasters, true, DeploymentModeCluster, EnvironmentDevelopment))
 	assert.Error(t, ServerGroupSpec{Count: util.NewInt(1), Args: []string{"--mq.type=strange"}}.Validate(ServerGroupSyncMasters, true, DeploymentModeCluster, EnvironmentDevelopment))
 }
+
+func TestServerGroupSpecValidateTopologySpreadConstraints(t *testing.T) {
+	validate := func(constraints ...core.TopologySpreadConstraint) error {
+		return ServerGroupSpec{Count: util.NewInt(2), TopologySpreadConstraints: constraints}.Validate(ServerGroupCoordinators, true, DeploymentModeCluster, EnvironmentDevelopment)
+	}
+
+	zone := core.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: core.DoNotSchedule,
+	}
+	host := core.TopologySpreadConstraint{
+		MaxSkew:           2,
+		TopologyKey:       "kubernetes.io/hostname",
+		WhenUnsatisfiable: core.ScheduleAnyway,
+	}
+
+	// Valid
+	assert.Nil(t, validate())
+	assert.Nil(t, validate(zone))
+	assert.Nil(t, validate(zone, host))
+
+	// Invalid
+	invalid := zone
+	invalid.MaxSkew = 0
+	assert.Error(t, validate(invalid))
+
+	invalid = zone
+	invalid.TopologyKey = ""
+	assert.Error(t, validate(invalid))
+
+	invalid = zone
+	invalid.WhenUnsatisfiable = "Unknown"
+	assert.Error(t, validate(invalid))
+
+	assert.Error(t, validate(zone, zone))
+}
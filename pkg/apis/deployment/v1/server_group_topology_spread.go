@@ -0,0 +1,66 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/shared"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+)
+
+func (s *ServerGroupSpec) validateTopologySpreadConstraints() error {
+	validateErrors := make([]error, len(s.TopologySpreadConstraints))
+	keys := map[string]bool{}
+
+	for id, constraint := range s.TopologySpreadConstraints {
+		if keys[constraint.TopologyKey] {
+			validateErrors[id] = shared.PrefixResourceErrors(fmt.Sprintf("%d", id),
+				errors.Errorf("Duplicated topologyKey %s", constraint.TopologyKey))
+			continue
+		}
+		keys[constraint.TopologyKey] = true
+
+		validateErrors[id] = shared.PrefixResourceErrors(fmt.Sprintf("%d", id), validateTopologySpreadConstraint(constraint))
+	}
+
+	return shared.WithErrors(validateErrors...)
+}
+
+func validateTopologySpreadConstraint(constraint core.TopologySpreadConstraint) error {
+	if constraint.MaxSkew < 1 {
+		return errors.Errorf("MaxSkew needs to be greater than 0, got %d", constraint.MaxSkew)
+	}
+
+	if constraint.TopologyKey == "" {
+		return errors.Errorf("TopologyKey can not be empty")
+	}
+
+	switch constraint.WhenUnsatisfiable {
+	case core.DoNotSchedule, core.ScheduleAnyway:
+		return nil
+	default:
+		return errors.Errorf("Unsupported whenUnsatisfiable value %s", constraint.WhenUnsatisfiable)
+	}
+}
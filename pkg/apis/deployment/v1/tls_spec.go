@@ -61,6 +61,8 @@ type TLSSpec struct {
 	TTL          *Duration      `json:"ttl,omitempty"`
 	SNI          *TLSSNISpec    `json:"sni,omitempty"`
 	Mode         *TLSRotateMode `json:"mode,omitempty"`
+	// ClientCertificateSecretName holds name of the kubernetes.io/tls secret with client certificate presented by the operator clients
+	ClientCertificateSecretName *string `json:"clientCertificateSecretName,omitempty"`
 }
 
 const (
@@ -73,6 +75,11 @@ func (s TLSSpec) GetCASecretName() string {
 	return util.StringOrDefault(s.CASecretName)
 }
 
+// GetClientCertificateSecretName returns the value of clientCertificateSecretName.
+func (s TLSSpec) GetClientCertificateSecretName() string {
+	return util.StringOrDefault(s.ClientCertificateSecretName)
+}
+
 // GetAltNames returns the value of altNames.
 func (s TLSSpec) GetAltNames() []string {
 	return s.AltNames
@@ -125,6 +132,11 @@ func (s TLSSpec) Validate() error {
 		if err := s.GetTTL().Validate(); err != nil {
 			return maskAny(err)
 		}
+		if s.ClientCertificateSecretName != nil {
+			if err := k8sutil.ValidateResourceName(s.GetClientCertificateSecretName()); err != nil {
+				return maskAny(err)
+			}
+		}
 	}
 	return nil
 }
@@ -157,4 +169,7 @@ func (s *TLSSpec) SetDefaultsFrom(source TLSSpec) {
 	if s.SNI == nil {
 		s.SNI = source.SNI.DeepCopy()
 	}
+	if s.ClientCertificateSecretName == nil {
+		s.ClientCertificateSecretName = util.NewStringOrNil(source.ClientCertificateSecretName)
+	}
 }
@@ -37,12 +37,14 @@ func TestTLSSpecValidate(t *testing.T) {
 	assert.Nil(t, TLSSpec{CASecretName: util.NewString("None"), AltNames: []string{}}.Validate())
 	assert.Nil(t, TLSSpec{CASecretName: util.NewString("None"), AltNames: []string{"foo"}}.Validate())
 	assert.Nil(t, TLSSpec{CASecretName: util.NewString("None"), AltNames: []string{"email@example.com", "127.0.0.1"}}.Validate())
+	assert.Nil(t, TLSSpec{CASecretName: util.NewString("foo"), ClientCertificateSecretName: util.NewString("client")}.Validate())
 
 	// Not valid
 	assert.Error(t, TLSSpec{CASecretName: nil}.Validate())
 	assert.Error(t, TLSSpec{CASecretName: util.NewString("")}.Validate())
 	assert.Error(t, TLSSpec{CASecretName: util.NewString("Foo")}.Validate())
 	assert.Error(t, TLSSpec{CASecretName: util.NewString("foo"), AltNames: []string{"@@"}}.Validate())
+	assert.Error(t, TLSSpec{CASecretName: util.NewString("foo"), ClientCertificateSecretName: util.NewString("Client")}.Validate())
 }
 
 func TestTLSSpecIsSecure(t *testing.T) {
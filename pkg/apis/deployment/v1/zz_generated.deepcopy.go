@@ -511,6 +511,11 @@ func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Hashes.DeepCopyInto(&out.Hashes)
+	if in.TopologyRebalance != nil {
+		in, out := &in.TopologyRebalance, &out.TopologyRebalance
+		*out = new(DeploymentTopologyRebalance)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ForceStatusReload != nil {
 		in, out := &in.ForceStatusReload, &out.ForceStatusReload
 		*out = new(bool)
@@ -674,6 +679,23 @@ func (in *DeploymentStatusMembers) DeepCopy() *DeploymentStatusMembers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentTopologyRebalance) DeepCopyInto(out *DeploymentTopologyRebalance) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentTopologyRebalance.
+func (in *DeploymentTopologyRebalance) DeepCopy() *DeploymentTopologyRebalance {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentTopologyRebalance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalAccessSpec) DeepCopyInto(out *ExternalAccessSpec) {
 	*out = *in
@@ -1162,6 +1184,59 @@ func (in *ServerGroupProbesSpec) DeepCopy() *ServerGroupProbesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerGroupInitContainers) DeepCopyInto(out *ServerGroupInitContainers) {
+	*out = *in
+	if in.ReservedEnv != nil {
+		in, out := &in.ReservedEnv, &out.ReservedEnv
+		*out = make(map[string]ServerGroupEnvVars, len(*in))
+		for key, val := range *in {
+			var outVal []ServerGroupEnvVar
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(ServerGroupEnvVars, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupInitContainers.
+func (in *ServerGroupInitContainers) DeepCopy() *ServerGroupInitContainers {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGroupInitContainers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ServerGroupSidecars) DeepCopyInto(out *ServerGroupSidecars) {
+	{
+		in := &in
+		*out = make(ServerGroupSidecars, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupSidecars.
+func (in ServerGroupSidecars) DeepCopy() ServerGroupSidecars {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGroupSidecars)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerGroupSpec) DeepCopyInto(out *ServerGroupSpec) {
 	*out = *in
@@ -1289,13 +1364,25 @@ func (in *ServerGroupSpec) DeepCopyInto(out *ServerGroupSpec) {
 		*out = new(corev1.NodeAffinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Sidecars != nil {
 		in, out := &in.Sidecars, &out.Sidecars
-		*out = make([]corev1.Container, len(*in))
+		*out = make(ServerGroupSidecars, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = new(ServerGroupInitContainers)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
 		*out = new(ServerGroupSpecSecurityContext)
@@ -1770,6 +1857,11 @@ func (in *TLSSpec) DeepCopyInto(out *TLSSpec) {
 		*out = new(TLSRotateMode)
 		**out = **in
 	}
+	if in.ClientCertificateSecretName != nil {
+		in, out := &in.ClientCertificateSecretName, &out.ClientCertificateSecretName
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -0,0 +1,105 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// adoptionID returns ID of the server backup stored in the label or annotation named by the adoption key of the deployment.
+// Returns empty string if deployment has no adoption key or backup does not carry it.
+func adoptionID(deployment *database.ArangoDeployment, backup *backupApi.ArangoBackup) string {
+	key := deployment.GetAnnotations()[backupApi.AnnotationArangoDeploymentBackupAdoptionKey]
+	if key == "" {
+		return ""
+	}
+
+	if id := backup.GetLabels()[key]; id != "" {
+		return id
+	}
+
+	return backup.GetAnnotations()[key]
+}
+
+// hasAdoptableArangoBackup returns true if one of the backups, which is not yet handled, references the server backup by adoption key
+func hasAdoptableArangoBackup(deployment *database.ArangoDeployment, backupMeta driver.BackupMeta, backups []backupApi.ArangoBackup) bool {
+	for _, backup := range backups {
+		if backup.Spec.Deployment.Name != deployment.Name || backup.Status.State != backupApi.ArangoBackupStateNone {
+			continue
+		}
+
+		if adoptionID(deployment, &backup) == string(backupMeta.ID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// adoptBackup matches new ArangoBackup with the existing server backup referenced by the adoption key of the deployment.
+// Returns nil status if backup is not adoptable.
+func (h *handler) adoptBackup(backup *backupApi.ArangoBackup) (*backupApi.ArangoBackupStatus, error) {
+	deployment, err := h.client.DatabaseV1().ArangoDeployments(backup.Namespace).Get(backup.Spec.Deployment.Name, meta.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, newTemporaryError(err)
+	}
+
+	id := adoptionID(deployment, backup)
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := h.arangoClientFactory(deployment, backup)
+	if err != nil {
+		return nil, newTemporaryError(err)
+	}
+
+	backupMeta, err := client.Get(h.ctx, driver.BackupID(id))
+	if err != nil {
+		if driver.IsNotFound(err) {
+			return nil, newFatalErrorf("backup %s to adopt not found on deployment %s", id, deployment.Name)
+		}
+
+		return nil, newTemporaryError(err)
+	}
+
+	h.eventRecorder.Normal(backup, AdoptedBackup, "Adopted backup %s with version %s from deployment %s",
+		backupMeta.ID,
+		backupMeta.Version,
+		deployment.Name)
+
+	return wrapUpdateStatus(backup,
+		updateStatusState(backupApi.ArangoBackupStateReady, ""),
+		updateStatusBackup(backupMeta),
+		updateStatusBackupServerVersion(h.serverVersion(client)),
+		updateStatusBackupImported(util.NewBool(true)))
+}
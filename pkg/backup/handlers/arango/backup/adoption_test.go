@@ -0,0 +1,176 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testAdoptionKey = "legacy.example.com/backup-id"
+
+func Test_Adoption_Label(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	backupMeta, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	deployment.Annotations = map[string]string{
+		backupApi.AnnotationArangoDeploymentBackupAdoptionKey: testAdoptionKey,
+	}
+	obj.Labels = map[string]string{
+		testAdoptionKey: string(backupMeta.ID),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, false)
+	compareBackupMeta(t, backupMeta.BackupMeta, newObj)
+	require.True(t, *newObj.Status.Backup.Imported)
+}
+
+func Test_Adoption_Annotation(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	backupMeta, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	deployment.Annotations = map[string]string{
+		backupApi.AnnotationArangoDeploymentBackupAdoptionKey: testAdoptionKey,
+	}
+	obj.Annotations = map[string]string{
+		testAdoptionKey: string(backupMeta.ID),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, false)
+	compareBackupMeta(t, backupMeta.BackupMeta, newObj)
+}
+
+func Test_Adoption_WithoutKey(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	backupMeta, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	obj.Labels = map[string]string{
+		testAdoptionKey: string(backupMeta.ID),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStatePending, false)
+}
+
+func Test_Adoption_MissingBackup(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{
+		getError: driver.ArangoError{
+			Code: 404,
+		},
+	})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	deployment.Annotations = map[string]string{
+		backupApi.AnnotationArangoDeploymentBackupAdoptionKey: testAdoptionKey,
+	}
+	obj.Labels = map[string]string{
+		testAdoptionKey: "missing",
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, false)
+	require.Contains(t, newObj.Status.Message, "backup missing to adopt not found")
+}
+
+func Test_Adoption_RefreshDoesNotDuplicate(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	backupMeta, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	deployment.Annotations = map[string]string{
+		backupApi.AnnotationArangoDeploymentBackupAdoptionKey: testAdoptionKey,
+	}
+	obj.Labels = map[string]string{
+		testAdoptionKey: string(backupMeta.ID),
+	}
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	// Act
+	_, err = handler.refreshDeployment(deployment)
+	require.NoError(t, err)
+
+	// Assert
+	backups, err := handler.client.BackupV1().ArangoBackups(obj.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, backups.Items, 1)
+	require.Equal(t, obj.Name, backups.Items[0].Name)
+}
@@ -23,7 +23,9 @@
 package backup
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/backup/utils"
 
@@ -47,9 +49,10 @@ type ArangoClientFactory func(deployment *database.ArangoDeployment, backup *bac
 
 // ArangoBackupProgress progress info
 type ArangoBackupProgress struct {
-	Progress          int
-	Failed, Completed bool
-	FailMessage       string
+	Progress                     int
+	Failed, Completed, Cancelled bool
+	FailMessage                  string
+	Errors                       []backupApi.ArangoBackupJobServerError
 }
 
 // ArangoBackupCreateResponse create response
@@ -60,17 +63,23 @@ type ArangoBackupCreateResponse struct {
 
 // ArangoBackupClient interface with backup functionality for database
 type ArangoBackupClient interface {
-	Create() (ArangoBackupCreateResponse, error)
-	Get(driver.BackupID) (driver.BackupMeta, error)
+	Create(context.Context) (ArangoBackupCreateResponse, error)
+	Get(context.Context, driver.BackupID) (driver.BackupMeta, error)
 
-	Upload(driver.BackupID) (driver.BackupTransferJobID, error)
-	Download(driver.BackupID) (driver.BackupTransferJobID, error)
+	Upload(context.Context, driver.BackupID, backupApi.ArangoBackupSpecOperation) (driver.BackupTransferJobID, error)
+	Download(context.Context, driver.BackupID) (driver.BackupTransferJobID, error)
 
-	Progress(driver.BackupTransferJobID) (ArangoBackupProgress, error)
-	Abort(driver.BackupTransferJobID) error
+	Progress(context.Context, driver.BackupTransferJobID) (ArangoBackupProgress, error)
+	Abort(context.Context, driver.BackupTransferJobID) error
 
-	Exists(driver.BackupID) (bool, error)
-	Delete(driver.BackupID) error
+	Exists(context.Context, driver.BackupID) (bool, error)
+	Delete(context.Context, driver.BackupID) error
 
-	List() (map[driver.BackupID]driver.BackupMeta, error)
+	List(context.Context) (map[driver.BackupID]driver.BackupMeta, error)
+
+	Manifest(context.Context) ([]backupApi.ArangoBackupManifestCollection, error)
+
+	Time(context.Context) (time.Time, error)
+
+	ServerVersion(context.Context) (driver.Version, error)
 }
@@ -26,6 +26,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"sort"
 	"time"
 
 	"github.com/arangodb/go-driver"
@@ -42,11 +44,31 @@ type arangoClientBackupImpl struct {
 	backup     *backupApi.ArangoBackup
 	driver     driver.Client
 	kubecli    kubernetes.Interface
+	timeout    time.Duration
 }
 
 func newArangoClientBackupFactory(handler *handler) ArangoClientFactory {
 	return func(deployment *database.ArangoDeployment, backup *backupApi.ArangoBackup) (ArangoBackupClient, error) {
 		ctx := context.Background()
+
+		if backup != nil && backup.Spec.User != nil {
+			auth, err := backupUserAuthentication(handler.kubeClient.CoreV1().Secrets(deployment.Namespace), backup.Spec.User)
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = arangod.WithAuthentication(ctx, auth)
+		}
+
+		cert, err := deploymentClientCertificate(handler.kubeClient.CoreV1().Secrets(deployment.Namespace), deployment)
+		if err != nil {
+			return nil, err
+		}
+
+		if cert != nil {
+			ctx = arangod.WithClientCertificate(ctx, *cert)
+		}
+
 		client, err := arangod.CreateArangodDatabaseClient(ctx, handler.kubeClient.CoreV1(), deployment, false)
 		if err != nil {
 			return nil, err
@@ -57,12 +79,13 @@ func newArangoClientBackupFactory(handler *handler) ArangoClientFactory {
 			backup:     backup,
 			driver:     client,
 			kubecli:    handler.kubeClient,
+			timeout:    handler.deploymentClientTimeout(deployment),
 		}, nil
 	}
 }
 
-func (ac *arangoClientBackupImpl) List() (map[driver.BackupID]driver.BackupMeta, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultArangoClientTimeout)
+func (ac *arangoClientBackupImpl) List(ctx context.Context) (map[driver.BackupID]driver.BackupMeta, error) {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
 	defer cancel()
 
 	backups, err := ac.driver.Backup().List(ctx, nil)
@@ -73,28 +96,35 @@ func (ac *arangoClientBackupImpl) List() (map[driver.BackupID]driver.BackupMeta,
 	return backups, nil
 }
 
-func (ac *arangoClientBackupImpl) Create() (ArangoBackupCreateResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultArangoClientTimeout)
-	defer cancel()
-
-	co := driver.BackupCreateOptions{}
+// backupCreateOptions maps backup spec to the driver create options
+func backupCreateOptions(backup *backupApi.ArangoBackup) driver.BackupCreateOptions {
+	co := driver.BackupCreateOptions{
+		AllowInconsistent: backup.Spec.GetAllowInconsistent(),
+		Label:             backup.Spec.Options.GetLabel(),
+	}
 
-	if opt := ac.backup.Spec.Options; opt != nil {
-		if allowInconsistent := opt.AllowInconsistent; allowInconsistent != nil {
-			co.AllowInconsistent = *allowInconsistent
-		}
+	if opt := backup.Spec.Options; opt != nil {
 		if timeout := opt.Timeout; timeout != nil {
 			co.Timeout = time.Duration(*timeout * float32(time.Second))
 		}
 	}
 
+	return co
+}
+
+func (ac *arangoClientBackupImpl) Create(ctx context.Context) (ArangoBackupCreateResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
+	defer cancel()
+
+	co := backupCreateOptions(ac.backup)
+
 	id, resp, err := ac.driver.Backup().Create(ctx, &co)
 	if err != nil {
 		return ArangoBackupCreateResponse{}, err
 	}
 
 	// Now ask for the version
-	meta, err := ac.Get(id)
+	meta, err := ac.Get(ctx, id)
 	if err != nil {
 		return ArangoBackupCreateResponse{}, err
 	}
@@ -105,8 +135,8 @@ func (ac *arangoClientBackupImpl) Create() (ArangoBackupCreateResponse, error) {
 	}, nil
 }
 
-func (ac *arangoClientBackupImpl) Get(backupID driver.BackupID) (driver.BackupMeta, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultArangoClientTimeout)
+func (ac *arangoClientBackupImpl) Get(ctx context.Context, backupID driver.BackupID) (driver.BackupMeta, error) {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
 	defer cancel()
 
 	// list, err := ac.driver.Backup().List(ctx, &driver.BackupListOptions{ID: backupID})
@@ -142,15 +172,10 @@ func (ac *arangoClientBackupImpl) getCredentialsFromSecret(secretName string) (i
 	return raw, nil
 }
 
-func (ac *arangoClientBackupImpl) Upload(backupID driver.BackupID) (driver.BackupTransferJobID, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultArangoClientTimeout)
+func (ac *arangoClientBackupImpl) Upload(ctx context.Context, backupID driver.BackupID, uploadSpec backupApi.ArangoBackupSpecOperation) (driver.BackupTransferJobID, error) {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
 	defer cancel()
 
-	uploadSpec := ac.backup.Spec.Upload
-	if uploadSpec == nil {
-		return "", fmt.Errorf("upload was called but no upload spec was given")
-	}
-
 	cred, err := ac.getCredentialsFromSecret(uploadSpec.CredentialsSecretName)
 	if err != nil {
 		return "", err
@@ -159,8 +184,8 @@ func (ac *arangoClientBackupImpl) Upload(backupID driver.BackupID) (driver.Backu
 	return ac.driver.Backup().Upload(ctx, backupID, uploadSpec.RepositoryURL, cred)
 }
 
-func (ac *arangoClientBackupImpl) Download(backupID driver.BackupID) (driver.BackupTransferJobID, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultArangoClientTimeout)
+func (ac *arangoClientBackupImpl) Download(ctx context.Context, backupID driver.BackupID) (driver.BackupTransferJobID, error) {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
 	defer cancel()
 
 	downloadSpec := ac.backup.Spec.Download
@@ -176,8 +201,8 @@ func (ac *arangoClientBackupImpl) Download(backupID driver.BackupID) (driver.Bac
 	return ac.driver.Backup().Download(ctx, backupID, downloadSpec.RepositoryURL, cred)
 }
 
-func (ac *arangoClientBackupImpl) Progress(jobID driver.BackupTransferJobID) (ArangoBackupProgress, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultArangoClientTimeout)
+func (ac *arangoClientBackupImpl) Progress(ctx context.Context, jobID driver.BackupTransferJobID) (ArangoBackupProgress, error) {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
 	defer cancel()
 
 	report, err := ac.driver.Backup().Progress(ctx, jobID)
@@ -190,16 +215,22 @@ func (ac *arangoClientBackupImpl) Progress(jobID driver.BackupTransferJobID) (Ar
 	var total int
 	var done int
 
-	for _, status := range report.DBServers {
+	for server, status := range report.DBServers {
 		total += status.Progress.Total
 		done += status.Progress.Done
 
 		switch status.Status {
 		case driver.TransferFailed:
 			ret.Failed = true
-			ret.FailMessage = status.ErrorMessage
+			ret.Errors = append(ret.Errors, backupApi.ArangoBackupJobServerError{
+				Server:  server,
+				Code:    status.Error,
+				Message: status.ErrorMessage,
+			})
 		case driver.TransferCompleted:
 			completedCount++
+		case driver.TransferCancelled:
+			ret.Cancelled = true
 		case driver.TransferAcknowledged:
 		case driver.TransferStarted:
 		case "":
@@ -209,6 +240,13 @@ func (ac *arangoClientBackupImpl) Progress(jobID driver.BackupTransferJobID) (Ar
 		}
 	}
 
+	if ret.Failed {
+		sort.Slice(ret.Errors, func(i, j int) bool {
+			return ret.Errors[i].Server < ret.Errors[j].Server
+		})
+		ret.FailMessage = jobErrorMessage(ret.Errors)
+	}
+
 	// Check if all defined servers are completed and total number of files is greater than 0 (there is at least 1 file per server)
 	ret.Completed = completedCount == len(report.DBServers) && total > 0
 	if total != 0 {
@@ -217,8 +255,8 @@ func (ac *arangoClientBackupImpl) Progress(jobID driver.BackupTransferJobID) (Ar
 	return ret, nil
 }
 
-func (ac *arangoClientBackupImpl) Exists(backupID driver.BackupID) (bool, error) {
-	_, err := ac.Get(backupID)
+func (ac *arangoClientBackupImpl) Exists(ctx context.Context, backupID driver.BackupID) (bool, error) {
+	_, err := ac.Get(ctx, backupID)
 	if err != nil {
 		if driver.IsNotFound(err) {
 			return false, nil
@@ -230,16 +268,94 @@ func (ac *arangoClientBackupImpl) Exists(backupID driver.BackupID) (bool, error)
 	return true, nil
 }
 
-func (ac *arangoClientBackupImpl) Delete(backupID driver.BackupID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultArangoClientTimeout)
+func (ac *arangoClientBackupImpl) Delete(ctx context.Context, backupID driver.BackupID) error {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
 	defer cancel()
 
 	return ac.driver.Backup().Delete(ctx, backupID)
 }
 
-func (ac *arangoClientBackupImpl) Abort(jobID driver.BackupTransferJobID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultArangoClientTimeout)
+func (ac *arangoClientBackupImpl) Abort(ctx context.Context, jobID driver.BackupTransferJobID) error {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
 	defer cancel()
 
 	return ac.driver.Backup().Abort(ctx, jobID)
 }
+
+func (ac *arangoClientBackupImpl) Manifest(ctx context.Context) ([]backupApi.ArangoBackupManifestCollection, error) {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
+	defer cancel()
+
+	databases, err := ac.driver.Databases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var collections []backupApi.ArangoBackupManifestCollection
+
+	for _, db := range databases {
+		cols, err := db.Collections(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, col := range cols {
+			stats, err := col.Statistics(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			collections = append(collections, backupApi.ArangoBackupManifestCollection{
+				Database:    db.Name(),
+				Name:        col.Name(),
+				Count:       stats.Count,
+				SizeInBytes: stats.Figures.Alive.Size,
+			})
+		}
+	}
+
+	return collections, nil
+}
+
+func (ac *arangoClientBackupImpl) Time(ctx context.Context) (time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
+	defer cancel()
+
+	conn := ac.driver.Connection()
+
+	req, err := conn.NewRequest(http.MethodGet, "/_admin/time")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	resp, err := conn.Do(ctx, req)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := resp.CheckStatus(http.StatusOK); err != nil {
+		return time.Time{}, err
+	}
+
+	var result struct {
+		Time float64 `json:"time"`
+	}
+
+	if err := resp.ParseBody("", &result); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, int64(result.Time*float64(time.Second))), nil
+}
+
+func (ac *arangoClientBackupImpl) ServerVersion(ctx context.Context) (driver.Version, error) {
+	ctx, cancel := context.WithTimeout(ctx, ac.timeout)
+	defer cancel()
+
+	info, err := ac.driver.Version(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return info.Version, nil
+}
@@ -23,6 +23,7 @@
 package backup
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -35,7 +36,8 @@ import (
 )
 
 const (
-	mockVersion = "1.0.0"
+	mockVersion       = "1.0.0"
+	mockServerVersion = "3.6.0"
 )
 
 func newMockArangoClientBackupErrorFactory(err error) ArangoClientFactory {
@@ -57,12 +59,13 @@ func newMockArangoClientBackup(errors mockErrorsArangoClientBackup) *mockArangoC
 	return &mockArangoClientBackupState{
 		backups:    map[driver.BackupID]driver.BackupMeta{},
 		progresses: map[driver.BackupTransferJobID]ArangoBackupProgress{},
+		uploads:    map[driver.BackupTransferJobID]string{},
 		errors:     errors,
 	}
 }
 
 type mockErrorsArangoClientBackup struct {
-	createError, listError, getError, uploadError, downloadError, progressError, existsError, deleteError, abortError error
+	createError, listError, getError, uploadError, downloadError, progressError, existsError, deleteError, abortError, manifestError, timeError, serverVersionError error
 }
 
 type mockArangoClientBackupState struct {
@@ -70,6 +73,12 @@ type mockArangoClientBackupState struct {
 
 	backups    map[driver.BackupID]driver.BackupMeta
 	progresses map[driver.BackupTransferJobID]ArangoBackupProgress
+	uploads    map[driver.BackupTransferJobID]string
+	manifest   []backupApi.ArangoBackupManifestCollection
+	clockSkew  time.Duration
+	size       uint64
+	// createDelay simulates time the server needs to create backup
+	createDelay time.Duration
 
 	errors mockErrorsArangoClientBackup
 }
@@ -79,7 +88,7 @@ type mockArangoClientBackup struct {
 	state  *mockArangoClientBackupState
 }
 
-func (m *mockArangoClientBackup) List() (map[driver.BackupID]driver.BackupMeta, error) {
+func (m *mockArangoClientBackup) List(_ context.Context) (map[driver.BackupID]driver.BackupMeta, error) {
 	m.state.lock.Lock()
 	defer m.state.lock.Unlock()
 
@@ -90,7 +99,7 @@ func (m *mockArangoClientBackup) List() (map[driver.BackupID]driver.BackupMeta,
 	return m.state.backups, nil
 }
 
-func (m *mockArangoClientBackup) Abort(d driver.BackupTransferJobID) error {
+func (m *mockArangoClientBackup) Abort(_ context.Context, d driver.BackupTransferJobID) error {
 	m.state.lock.Lock()
 	defer m.state.lock.Unlock()
 
@@ -98,12 +107,16 @@ func (m *mockArangoClientBackup) Abort(d driver.BackupTransferJobID) error {
 		return m.state.errors.abortError
 	}
 
-	delete(m.state.progresses, d)
+	if _, ok := m.state.progresses[d]; ok {
+		m.state.progresses[d] = ArangoBackupProgress{
+			Cancelled: true,
+		}
+	}
 
 	return nil
 }
 
-func (m *mockArangoClientBackup) Exists(id driver.BackupID) (bool, error) {
+func (m *mockArangoClientBackup) Exists(_ context.Context, id driver.BackupID) (bool, error) {
 	m.state.lock.Lock()
 	defer m.state.lock.Unlock()
 
@@ -116,7 +129,7 @@ func (m *mockArangoClientBackup) Exists(id driver.BackupID) (bool, error) {
 	return ok, nil
 }
 
-func (m *mockArangoClientBackup) Delete(id driver.BackupID) error {
+func (m *mockArangoClientBackup) Delete(_ context.Context, id driver.BackupID) error {
 	m.state.lock.Lock()
 	defer m.state.lock.Unlock()
 
@@ -129,7 +142,7 @@ func (m *mockArangoClientBackup) Delete(id driver.BackupID) error {
 	return nil
 }
 
-func (m *mockArangoClientBackup) Download(driver.BackupID) (driver.BackupTransferJobID, error) {
+func (m *mockArangoClientBackup) Download(context.Context, driver.BackupID) (driver.BackupTransferJobID, error) {
 	m.state.lock.Lock()
 	defer m.state.lock.Unlock()
 
@@ -144,7 +157,7 @@ func (m *mockArangoClientBackup) Download(driver.BackupID) (driver.BackupTransfe
 	return id, nil
 }
 
-func (m *mockArangoClientBackup) Progress(id driver.BackupTransferJobID) (ArangoBackupProgress, error) {
+func (m *mockArangoClientBackup) Progress(_ context.Context, id driver.BackupTransferJobID) (ArangoBackupProgress, error) {
 	m.state.lock.Lock()
 	defer m.state.lock.Unlock()
 
@@ -155,7 +168,7 @@ func (m *mockArangoClientBackup) Progress(id driver.BackupTransferJobID) (Arango
 	return m.state.progresses[id], nil
 }
 
-func (m *mockArangoClientBackup) Upload(driver.BackupID) (driver.BackupTransferJobID, error) {
+func (m *mockArangoClientBackup) Upload(_ context.Context, _ driver.BackupID, upload backupApi.ArangoBackupSpecOperation) (driver.BackupTransferJobID, error) {
 	m.state.lock.Lock()
 	defer m.state.lock.Unlock()
 
@@ -166,11 +179,12 @@ func (m *mockArangoClientBackup) Upload(driver.BackupID) (driver.BackupTransferJ
 	id := driver.BackupTransferJobID(uuid.NewUUID())
 
 	m.state.progresses[id] = ArangoBackupProgress{}
+	m.state.uploads[id] = upload.RepositoryURL
 
 	return id, nil
 }
 
-func (m *mockArangoClientBackup) Get(id driver.BackupID) (driver.BackupMeta, error) {
+func (m *mockArangoClientBackup) Get(_ context.Context, id driver.BackupID) (driver.BackupMeta, error) {
 	m.state.lock.Lock()
 	defer m.state.lock.Unlock()
 
@@ -185,7 +199,7 @@ func (m *mockArangoClientBackup) Get(id driver.BackupID) (driver.BackupMeta, err
 	return driver.BackupMeta{}, fmt.Errorf("not found")
 }
 
-func (m *mockArangoClientBackup) Create() (ArangoBackupCreateResponse, error) {
+func (m *mockArangoClientBackup) Create(_ context.Context) (ArangoBackupCreateResponse, error) {
 	m.state.lock.Lock()
 	defer m.state.lock.Unlock()
 
@@ -193,26 +207,34 @@ func (m *mockArangoClientBackup) Create() (ArangoBackupCreateResponse, error) {
 		return ArangoBackupCreateResponse{}, m.state.errors.createError
 	}
 
+	time.Sleep(m.state.createDelay)
+
 	id := driver.BackupID(uuid.NewUUID())
 
 	inconsistent := false
 
 	if m.backup != nil {
-		if m.backup.Spec.Options != nil {
-			if m.backup.Spec.Options.AllowInconsistent != nil {
-				inconsistent = *m.backup.Spec.Options.AllowInconsistent
-			}
+		inconsistent = m.backup.Spec.GetAllowInconsistent()
+
+		// Server appends label to the backup ID
+		if label := m.backup.Spec.Options.GetLabel(); label != "" {
+			id = driver.BackupID(fmt.Sprintf("%s_%s", id, label))
 		}
 	}
 
 	servers := uint(rand.Uint32())
 
+	size := m.state.size
+	if size == 0 {
+		size = rand.Uint64()
+	}
+
 	meta := driver.BackupMeta{
 		ID:                      id,
 		Version:                 mockVersion,
 		NumberOfDBServers:       servers,
 		DateTime:                time.Now(),
-		SizeInBytes:             rand.Uint64(),
+		SizeInBytes:             size,
 		PotentiallyInconsistent: inconsistent,
 		NumberOfFiles:           uint(rand.Uint32()),
 		NumberOfPiecesPresent:   servers,
@@ -227,6 +249,39 @@ func (m *mockArangoClientBackup) Create() (ArangoBackupCreateResponse, error) {
 	}, nil
 }
 
+func (m *mockArangoClientBackup) Manifest(_ context.Context) ([]backupApi.ArangoBackupManifestCollection, error) {
+	m.state.lock.Lock()
+	defer m.state.lock.Unlock()
+
+	if m.state.errors.manifestError != nil {
+		return nil, m.state.errors.manifestError
+	}
+
+	return m.state.manifest, nil
+}
+
+func (m *mockArangoClientBackup) Time(_ context.Context) (time.Time, error) {
+	m.state.lock.Lock()
+	defer m.state.lock.Unlock()
+
+	if m.state.errors.timeError != nil {
+		return time.Time{}, m.state.errors.timeError
+	}
+
+	return time.Now().Add(m.state.clockSkew), nil
+}
+
+func (m *mockArangoClientBackup) ServerVersion(_ context.Context) (driver.Version, error) {
+	m.state.lock.Lock()
+	defer m.state.lock.Unlock()
+
+	if m.state.errors.serverVersionError != nil {
+		return "", m.state.errors.serverVersionError
+	}
+
+	return mockServerVersion, nil
+}
+
 func (m *mockArangoClientBackup) getIDs() []string {
 	ret := make([]string, 0, len(m.state.backups))
 
@@ -0,0 +1,45 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+)
+
+// applyAvailablePolicy ensures that backup is not advertised as available before
+// the phase requested by the AvailableAfter policy is completed.
+func applyAvailablePolicy(backup *backupApi.ArangoBackup, status *backupApi.ArangoBackupStatus) *backupApi.ArangoBackupStatus {
+	if status == nil || !status.Available {
+		return status
+	}
+
+	switch backup.Spec.Policy.GetAvailableAfter() {
+	case backupApi.ArangoBackupAvailableAfterUpload:
+		if status.Backup == nil || !util.BoolOrDefault(status.Backup.Uploaded) {
+			status.Available = false
+		}
+	}
+
+	return status
+}
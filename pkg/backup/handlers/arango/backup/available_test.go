@@ -0,0 +1,155 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arangodb/go-driver"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newAvailableAfter(after backupApi.ArangoBackupAvailableAfter) *backupApi.ArangoBackupSpecPolicy {
+	return &backupApi.ArangoBackupSpecPolicy{
+		AvailableAfter: &after,
+	}
+}
+
+func Test_AvailableAfter_Ready(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
+	obj.Spec.Policy = newAvailableAfter(backupApi.ArangoBackupAvailableAfterReady)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+}
+
+func Test_AvailableAfter_Upload(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
+	obj.Spec.Policy = newAvailableAfter(backupApi.ArangoBackupAvailableAfterUpload)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	t.Run("Created", func(t *testing.T) {
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		// Assert
+		newObj := refreshArangoBackup(t, handler, obj)
+		checkBackup(t, newObj, backupApi.ArangoBackupStateReady, false)
+	})
+
+	t.Run("Upload started", func(t *testing.T) {
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		// Assert
+		newObj := refreshArangoBackup(t, handler, obj)
+		checkBackup(t, newObj, backupApi.ArangoBackupStateUploading, false)
+		require.NotNil(t, newObj.Status.Progress)
+	})
+
+	t.Run("Upload finished", func(t *testing.T) {
+		newObj := refreshArangoBackup(t, handler, obj)
+
+		progress, ok := mock.state.progresses[driver.BackupTransferJobID(newObj.Status.Progress.JobID)]
+		require.True(t, ok)
+		progress.Completed = true
+		mock.state.progresses[driver.BackupTransferJobID(newObj.Status.Progress.JobID)] = progress
+
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		// Assert
+		newObj = refreshArangoBackup(t, handler, obj)
+		checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+		require.NotNil(t, newObj.Status.Backup.Uploaded)
+		require.True(t, *newObj.Status.Backup.Uploaded)
+	})
+}
+
+func Test_AvailableAfter_Validation(t *testing.T) {
+	// Arrange
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateCreate)
+
+	// Assert
+	obj.Spec.Policy = newAvailableAfter(backupApi.ArangoBackupAvailableAfterUpload)
+	require.EqualError(t, obj.Validate(), "available after Upload requires upload to be specified")
+
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{}
+	require.NoError(t, obj.Validate())
+
+	obj.Spec.Policy = newAvailableAfter("Unknown")
+	require.EqualError(t, obj.Spec.Validate(), "available after Unknown is not supported")
+}
+
+func Test_AvailableAfter_UploadRemovedFromReadyBackup(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Spec.Policy = newAvailableAfter(backupApi.ArangoBackupAvailableAfterUpload)
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+	obj.Status.Backup.Uploaded = util.NewBool(true)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, false)
+	require.NotNil(t, newObj.Status.Backup)
+}
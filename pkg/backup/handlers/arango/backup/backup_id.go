@@ -0,0 +1,67 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// setBackupIDLabel sets server backup ID label on the object. Returns true if labels were changed.
+// IDs which are not valid label values are skipped.
+func setBackupIDLabel(backup *backupApi.ArangoBackup, id string) bool {
+	if len(validation.IsValidLabelValue(id)) > 0 {
+		return false
+	}
+
+	if backup.Labels[backupApi.LabelArangoBackupID] == id {
+		return false
+	}
+
+	if backup.Labels == nil {
+		backup.Labels = map[string]string{}
+	}
+
+	backup.Labels[backupApi.LabelArangoBackupID] = id
+
+	return true
+}
+
+// backupWithIDExists returns true if ArangoBackup labeled with server backup ID exists in namespace
+func (h *handler) backupWithIDExists(namespace, id string) (bool, error) {
+	if len(validation.IsValidLabelValue(id)) > 0 {
+		return false, nil
+	}
+
+	backups, err := h.client.BackupV1().ArangoBackups(namespace).List(meta.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", backupApi.LabelArangoBackupID, id),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(backups.Items) > 0, nil
+}
@@ -0,0 +1,120 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_BackupID_Label(t *testing.T) {
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	require.True(t, setBackupIDLabel(obj, "2020-01-01T10.00.00Z_f9bb4a29-50d8-4b4b-a3ea-ed7c2b4d05e5"))
+	require.Equal(t, "2020-01-01T10.00.00Z_f9bb4a29-50d8-4b4b-a3ea-ed7c2b4d05e5", obj.Labels[backupApi.LabelArangoBackupID])
+	require.False(t, setBackupIDLabel(obj, "2020-01-01T10.00.00Z_f9bb4a29-50d8-4b4b-a3ea-ed7c2b4d05e5"))
+
+	// Invalid label value
+	require.False(t, setBackupIDLabel(obj, "invalid/id"))
+}
+
+func Test_BackupID_LabelOnHandle(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	backupMeta, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(driver.BackupMeta{
+		ID: backupMeta.ID,
+	}, nil)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, string(backupMeta.ID), newObj.Labels[backupApi.LabelArangoBackupID])
+}
+
+func Test_BackupID_ImportedLabel(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	mock.state.backups["imported"] = driver.BackupMeta{
+		ID:      "imported",
+		Version: "3.6.0",
+	}
+
+	createArangoDeployment(t, handler, deployment)
+
+	// Act
+	_, err := handler.refreshDeployment(deployment)
+	require.NoError(t, err)
+
+	// Assert
+	backups, err := handler.client.BackupV1().ArangoBackups(deployment.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, backups.Items, 1)
+	require.Equal(t, "imported", backups.Items[0].Labels[backupApi.LabelArangoBackupID])
+}
+
+func Test_BackupID_ImportRace(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	setBackupIDLabel(obj, "imported")
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	backupMeta := driver.BackupMeta{
+		ID:      "imported",
+		Version: "3.6.0",
+	}
+
+	// Act
+	// Backup created in the meantime is not in the list passed to the refresh
+	_, err := handler.refreshDeploymentBackup(deployment, mock, backupMeta, nil, nil)
+	require.NoError(t, err)
+
+	// Assert
+	backups, err := handler.client.BackupV1().ArangoBackups(deployment.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, backups.Items, 1)
+	require.Equal(t, obj.Name, backups.Items[0].Name)
+}
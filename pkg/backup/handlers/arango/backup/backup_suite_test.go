@@ -23,6 +23,7 @@
 package backup
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -39,6 +40,7 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/backup/state"
 	fakeClientSet "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/fake"
 	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
 )
@@ -51,12 +53,22 @@ func newFakeHandler() *handler {
 	f := fakeClientSet.NewSimpleClientset()
 	k := fake.NewSimpleClientset()
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &handler{
 		client:     f,
 		kubeClient: k,
 
 		arangoClientTimeout: defaultArangoClientTimeout,
 		eventRecorder:       newEventInstance(event.NewEventRecorder("mock", k)),
+
+		config:  NewDefaultConfig(),
+		metrics: newPrometheusMetrics(),
+
+		requeueLimiter: newRequeueLimiter(NewDefaultConfig()),
+
+		ctx:    ctx,
+		cancel: cancel,
 	}
 }
 
@@ -158,6 +170,14 @@ func newArangoDeployment(namespace, name string) *database.ArangoDeployment {
 				name),
 			UID: uuid.NewUUID(),
 		},
+		Status: database.DeploymentStatus{
+			Conditions: database.ConditionList{
+				{
+					Type:   database.ConditionTypeReady,
+					Status: core.ConditionTrue,
+				},
+			},
+		},
 	}
 }
 
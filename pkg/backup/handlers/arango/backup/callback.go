@@ -0,0 +1,122 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/utils"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	callbackTimeout    = 10 * time.Second
+	callbackRetryCount = 3
+	callbackRetryDelay = time.Second
+)
+
+// callbackPayload is a JSON body of the callback request
+type callbackPayload struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	BackupID  string `json:"backupID,omitempty"`
+	State     string `json:"state"`
+	Message   string `json:"message,omitempty"`
+}
+
+func newCallbackPayload(backup *backupApi.ArangoBackup) callbackPayload {
+	p := callbackPayload{
+		Namespace: backup.Namespace,
+		Name:      backup.Name,
+		State:     string(backup.Status.State),
+		Message:   backup.Status.Message,
+	}
+
+	if backup.Status.Backup != nil {
+		p.BackupID = backup.Status.Backup.ID
+	}
+
+	return p
+}
+
+// sendCallback notifies configured endpoint about backup which reached Ready or Failed state.
+// Request is send in background and failures are only logged, so slow endpoint does not block the handler.
+func (h *handler) sendCallback(backup *backupApi.ArangoBackup) {
+	if h.config.CallbackURL == "" {
+		return
+	}
+
+	if backup.Status.State != backupApi.ArangoBackupStateReady && backup.Status.State != backupApi.ArangoBackupStateFailed {
+		return
+	}
+
+	body, err := json.Marshal(newCallbackPayload(backup))
+	if err != nil {
+		log.Warn().Err(err).Msgf("Unable to prepare callback of %s/%s", backup.Namespace, backup.Name)
+		return
+	}
+
+	url, authorization := h.config.CallbackURL, h.config.CallbackAuthorization
+	namespace, name := backup.Namespace, backup.Name
+
+	go func() {
+		if err := utils.Retry(callbackRetryCount, callbackRetryDelay, func() error {
+			return postCallback(url, authorization, body)
+		}); err != nil {
+			log.Warn().Err(err).Msgf("Callback of %s/%s failed", namespace, name)
+		}
+	}()
+}
+
+func postCallback(url, authorization string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	client := http.Client{
+		Timeout: callbackTimeout,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
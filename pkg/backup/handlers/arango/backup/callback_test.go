@@ -0,0 +1,129 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+)
+
+type callbackRequest struct {
+	authorization string
+	payload       callbackPayload
+}
+
+func newCallbackServer(t *testing.T) (*httptest.Server, <-chan callbackRequest) {
+	requests := make(chan callbackRequest, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p callbackPayload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+
+		requests <- callbackRequest{
+			authorization: r.Header.Get("Authorization"),
+			payload:       p,
+		}
+	}))
+
+	return server, requests
+}
+
+func Test_Callback_Ready(t *testing.T) {
+	// Arrange
+	server, requests := newCallbackServer(t)
+	defer server.Close()
+
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.CallbackURL = server.URL
+	handler.config.CallbackAuthorization = "bearer token"
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	select {
+	case r := <-requests:
+		require.Equal(t, "bearer token", r.authorization)
+		require.Equal(t, callbackPayload{
+			Namespace: obj.Namespace,
+			Name:      obj.Name,
+			BackupID:  newObj.Status.Backup.ID,
+			State:     string(backupApi.ArangoBackupStateReady),
+		}, r.payload)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "callback not received")
+	}
+}
+
+func Test_Callback_NotTerminalState(t *testing.T) {
+	// Arrange
+	server, requests := newCallbackServer(t)
+	defer server.Close()
+
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.CallbackURL = server.URL
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateScheduled, newObj.Status.State)
+
+	select {
+	case <-requests:
+		require.Fail(t, "callback not expected")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func Test_Callback_Failure(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	// Act & Assert
+	require.EqualError(t, postCallback(server.URL, "", nil), "callback returned unexpected status code 500")
+}
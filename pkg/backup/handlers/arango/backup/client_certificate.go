@@ -0,0 +1,55 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"crypto/tls"
+
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// deploymentClientCertificate returns client certificate from the secret referenced in the deployment TLS spec,
+// nil is returned if deployment is not secure or client certificate is not configured
+func deploymentClientCertificate(secrets k8sutil.SecretInterface, deployment *database.ArangoDeployment) (*tls.Certificate, error) {
+	if !deployment.Spec.IsSecure() {
+		return nil, nil
+	}
+
+	name := deployment.Spec.TLS.GetClientCertificateSecretName()
+	if name == "" {
+		return nil, nil
+	}
+
+	certPEM, keyPEM, err := k8sutil.GetClientCertificateSecret(secrets, name)
+	if err != nil {
+		return nil, newTemporaryError(err)
+	}
+
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, newFatalErrorf("client certificate from secret %s is invalid: %s", name, err.Error())
+	}
+
+	return &cert, nil
+}
@@ -0,0 +1,144 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	certificates "github.com/arangodb-helper/go-certificates"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newClientCertificateSecret(t *testing.T, namespace, name string) *core.Secret {
+	cert, key, err := certificates.CreateCertificate(certificates.CreateCertificateOptions{
+		CommonName:   "backup-operator",
+		ValidFrom:    time.Now(),
+		ValidFor:     time.Hour,
+		IsClientAuth: true,
+		ECDSACurve:   "P256",
+	}, nil)
+	require.NoError(t, err)
+
+	return &core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: core.SecretTypeTLS,
+		Data: map[string][]byte{
+			core.TLSCertKey:       []byte(cert),
+			core.TLSPrivateKeyKey: []byte(key),
+		},
+	}
+}
+
+func Test_ClientCertificate_Loaded(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+	deployment.Spec.TLS.ClientCertificateSecretName = util.NewString("client-cert")
+
+	secrets := handler.kubeClient.CoreV1().Secrets(deployment.Namespace)
+	_, err := secrets.Create(newClientCertificateSecret(t, deployment.Namespace, "client-cert"))
+	require.NoError(t, err)
+
+	// Act
+	cert, err := deploymentClientCertificate(secrets, deployment)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, cert)
+	require.Len(t, cert.Certificate, 1)
+}
+
+func Test_ClientCertificate_NotConfigured(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+	secrets := handler.kubeClient.CoreV1().Secrets(deployment.Namespace)
+
+	// Act
+	cert, err := deploymentClientCertificate(secrets, deployment)
+
+	// Assert
+	require.NoError(t, err)
+	require.Nil(t, cert)
+
+	// Not secure deployment does not use client certificate
+	deployment.Spec.TLS.CASecretName = util.NewString("None")
+	deployment.Spec.TLS.ClientCertificateSecretName = util.NewString("client-cert")
+
+	// Act
+	cert, err = deploymentClientCertificate(secrets, deployment)
+
+	// Assert
+	require.NoError(t, err)
+	require.Nil(t, cert)
+}
+
+func Test_ClientCertificate_Missing(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+	deployment.Spec.TLS.ClientCertificateSecretName = util.NewString("client-cert")
+
+	secrets := handler.kubeClient.CoreV1().Secrets(deployment.Namespace)
+
+	// Act
+	_, err := deploymentClientCertificate(secrets, deployment)
+
+	// Assert
+	require.Error(t, err)
+	require.True(t, isTemporaryError(err))
+}
+
+func Test_ClientCertificate_Invalid(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+	deployment.Spec.TLS.ClientCertificateSecretName = util.NewString("client-cert")
+
+	secret := newClientCertificateSecret(t, deployment.Namespace, "client-cert")
+	secret.Data[core.TLSPrivateKeyKey] = []byte("invalid")
+
+	secrets := handler.kubeClient.CoreV1().Secrets(deployment.Namespace)
+	_, err := secrets.Create(secret)
+	require.NoError(t, err)
+
+	// Act
+	_, err = deploymentClientCertificate(secrets, deployment)
+
+	// Assert
+	require.Error(t, err)
+	require.False(t, isTemporaryError(err))
+	require.Contains(t, err.Error(), "client certificate from secret client-cert is invalid")
+}
@@ -0,0 +1,53 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/rs/zerolog/log"
+)
+
+// deploymentClientTimeout returns timeout of the requests send to the deployment,
+// annotation of the deployment takes precedence over the operator timeout
+func (h *handler) deploymentClientTimeout(deployment *database.ArangoDeployment) time.Duration {
+	timeout := h.arangoClientTimeout
+	if timeout <= 0 {
+		timeout = defaultArangoClientTimeout
+	}
+
+	value, ok := deployment.Annotations[backupApi.AnnotationArangoDeploymentClientTimeout]
+	if !ok {
+		return timeout
+	}
+
+	override, err := time.ParseDuration(value)
+	if err != nil || override <= 0 {
+		log.Warn().Err(err).Msgf("Invalid client timeout %s of %s/%s, using %s", value, deployment.Namespace, deployment.Name, timeout)
+		return timeout
+	}
+
+	return override
+}
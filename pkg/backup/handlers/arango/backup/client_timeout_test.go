@@ -0,0 +1,141 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/stretchr/testify/require"
+)
+
+// slowBackupDriver returns backup list after the delay or context timeout
+type slowBackupDriver struct {
+	driver.Client
+	driver.ClientBackup
+
+	delay time.Duration
+}
+
+func (s *slowBackupDriver) Backup() driver.ClientBackup {
+	return s
+}
+
+func (s *slowBackupDriver) List(ctx context.Context, _ *driver.BackupListOptions) (map[driver.BackupID]driver.BackupMeta, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(s.delay):
+		return map[driver.BackupID]driver.BackupMeta{}, nil
+	}
+}
+
+func Test_ClientTimeout_Default(t *testing.T) {
+	handler := newFakeHandler()
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	require.Equal(t, defaultArangoClientTimeout, handler.deploymentClientTimeout(deployment))
+
+	handler.arangoClientTimeout = time.Minute
+	require.Equal(t, time.Minute, handler.deploymentClientTimeout(deployment))
+}
+
+func Test_ClientTimeout_Annotation(t *testing.T) {
+	handler := newFakeHandler()
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	deployment.Annotations = map[string]string{
+		backupApi.AnnotationArangoDeploymentClientTimeout: "5m",
+	}
+	require.Equal(t, 5*time.Minute, handler.deploymentClientTimeout(deployment))
+
+	deployment.Annotations[backupApi.AnnotationArangoDeploymentClientTimeout] = "invalid"
+	require.Equal(t, defaultArangoClientTimeout, handler.deploymentClientTimeout(deployment))
+
+	deployment.Annotations[backupApi.AnnotationArangoDeploymentClientTimeout] = "-1s"
+	require.Equal(t, defaultArangoClientTimeout, handler.deploymentClientTimeout(deployment))
+}
+
+func Test_ClientTimeout_SlowList(t *testing.T) {
+	handler := newFakeHandler()
+	handler.arangoClientTimeout = 10 * time.Millisecond
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	slow := &slowBackupDriver{
+		delay: 200 * time.Millisecond,
+	}
+
+	newClient := func() *arangoClientBackupImpl {
+		return &arangoClientBackupImpl{
+			deployment: deployment,
+			driver:     slow,
+			kubecli:    handler.kubeClient,
+			timeout:    handler.deploymentClientTimeout(deployment),
+		}
+	}
+
+	t.Run("Global timeout", func(t *testing.T) {
+		_, err := newClient().List(context.Background())
+		require.EqualError(t, err, context.DeadlineExceeded.Error())
+	})
+
+	t.Run("Deployment timeout", func(t *testing.T) {
+		deployment.Annotations = map[string]string{
+			backupApi.AnnotationArangoDeploymentClientTimeout: "5s",
+		}
+
+		_, err := newClient().List(context.Background())
+		require.NoError(t, err)
+	})
+}
+
+func Test_ClientContext_OperatorStop(t *testing.T) {
+	handler := newFakeHandler()
+	handler.config.RefreshInterval = 0
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	client := &arangoClientBackupImpl{
+		deployment: deployment,
+		driver: &slowBackupDriver{
+			delay: time.Minute,
+		},
+		kubecli: handler.kubeClient,
+		timeout: handler.deploymentClientTimeout(deployment),
+	}
+
+	stopCh := make(chan struct{})
+	handler.Start(stopCh)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(stopCh)
+	}()
+
+	_, err := client.List(handler.ctx)
+	require.EqualError(t, err, context.Canceled.Error())
+}
@@ -0,0 +1,96 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/rs/zerolog/log"
+)
+
+// clock returns current time from the perspective of the deployment
+type clock func(deployment *database.ArangoDeployment) time.Time
+
+func clockSkewKey(deployment *database.ArangoDeployment) string {
+	return fmt.Sprintf("%s/%s", deployment.Namespace, deployment.Name)
+}
+
+// checkClockSkew compares operator time with the time reported by the database server.
+// Skew is positive when operator clock is ahead of the server clock.
+func (h *handler) checkClockSkew(deployment *database.ArangoDeployment, client ArangoBackupClient) (time.Duration, bool, error) {
+	before := time.Now()
+
+	serverTime, err := client.Time(h.ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Assume that server time was taken in the middle of the request
+	local := before.Add(time.Since(before) / 2)
+
+	skew := local.Sub(serverTime)
+
+	h.setClockSkew(deployment, skew)
+
+	h.metrics.clockSkew.WithLabelValues(deployment.Namespace, deployment.Name).Set(skew.Seconds())
+
+	exceeded := skew > h.config.ClockSkewThreshold || skew < -h.config.ClockSkewThreshold
+
+	if exceeded {
+		log.Warn().Msgf("Clock skew of %s between operator and deployment %s/%s exceeds threshold of %s",
+			skew, deployment.Namespace, deployment.Name, h.config.ClockSkewThreshold)
+	}
+
+	return skew, exceeded, nil
+}
+
+func (h *handler) setClockSkew(deployment *database.ArangoDeployment, skew time.Duration) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.clockSkew == nil {
+		h.clockSkew = map[string]time.Duration{}
+	}
+
+	h.clockSkew[clockSkewKey(deployment)] = skew
+}
+
+func (h *handler) getClockSkew(deployment *database.ArangoDeployment) time.Duration {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.clockSkew[clockSkewKey(deployment)]
+}
+
+// now returns current time, corrected with detected clock skew of the deployment if server time is enabled
+func (h *handler) now(deployment *database.ArangoDeployment) time.Time {
+	now := time.Now()
+
+	if !h.config.UseServerTime {
+		return now
+	}
+
+	return now.Add(-h.getClockSkew(deployment))
+}
@@ -0,0 +1,107 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClockSkew_Detected(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	mock.state.clockSkew = 10 * time.Minute
+
+	createArangoDeployment(t, handler, deployment)
+
+	// Act
+	skew, exceeded, err := handler.checkClockSkew(deployment, mock)
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, exceeded)
+	require.InDelta(t, (-10 * time.Minute).Seconds(), skew.Seconds(), 1)
+	require.InDelta(t, (-10 * time.Minute).Seconds(), testutil.ToFloat64(handler.metrics.clockSkew.WithLabelValues(deployment.Namespace, deployment.Name)), 1)
+}
+
+func Test_ClockSkew_BelowThreshold(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	mock.state.clockSkew = 10 * time.Second
+
+	// Act
+	_, exceeded, err := handler.checkClockSkew(deployment, mock)
+
+	// Assert
+	require.NoError(t, err)
+	require.False(t, exceeded)
+}
+
+func Test_ClockSkew_Error(t *testing.T) {
+	// Arrange
+	error := fmt.Errorf("time error")
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{
+		timeError: error,
+	})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	// Act
+	_, _, err := handler.checkClockSkew(deployment, mock)
+
+	// Assert
+	require.EqualError(t, err, error.Error())
+	require.Zero(t, handler.getClockSkew(deployment))
+}
+
+func Test_ClockSkew_ServerTime(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	mock.state.clockSkew = time.Hour
+
+	createArangoDeployment(t, handler, deployment)
+
+	// Act
+	_, err := handler.refreshDeployment(deployment)
+	require.NoError(t, err)
+
+	// Assert
+	require.WithinDuration(t, time.Now(), handler.now(deployment), time.Second)
+
+	handler.config.UseServerTime = true
+	require.WithinDuration(t, time.Now().Add(time.Hour), handler.now(deployment), time.Second)
+}
@@ -0,0 +1,308 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	defaultHealthFreshness    = 24 * time.Hour
+	defaultClockSkewThreshold = time.Minute
+	defaultCredentialsTimeout = 10 * time.Minute
+
+	defaultDeploymentConcurrency = 1
+	defaultLockWarningThreshold  = 30 * time.Minute
+
+	defaultRefreshInterval = 2 * time.Minute
+	minRefreshInterval     = 10 * time.Second
+	defaultRefreshJitter   = 0.2
+
+	defaultRequeueBaseDelay = 100 * time.Millisecond
+	defaultRequeueMaxDelay  = time.Minute
+	defaultJobPollInterval  = 10 * time.Second
+	defaultJobPollMaxDelay  = 5 * time.Minute
+
+	defaultDrainTimeout = 30 * time.Second
+)
+
+// Config holds the operator level configuration of the ArangoBackup handler
+type Config struct {
+	// HealthFreshness defines maximum age of the Ready backup to consider deployment backup as fresh
+	HealthFreshness time.Duration
+
+	// ImportedBackupsEditable allows changes of the spec of the imported backups
+	ImportedBackupsEditable bool
+
+	// OwnerReferenceController sets Controller flag on the deployment owner reference of the backup
+	OwnerReferenceController bool
+
+	// OwnerReferenceBlockOwnerDeletion sets BlockOwnerDeletion flag on the deployment owner reference of the backup
+	OwnerReferenceBlockOwnerDeletion bool
+
+	// OwnerReferenceDisabled skips the deployment owner reference, so backups are kept when the deployment is removed
+	OwnerReferenceDisabled bool
+
+	// ClockSkewThreshold defines maximum accepted clock skew between operator and database server
+	ClockSkewThreshold time.Duration
+
+	// UseServerTime enables correction of the operator time with detected clock skew in backup age calculations
+	UseServerTime bool
+
+	// CredentialsTimeout defines how long backup waits for the missing authentication secret of the deployment before it fails
+	CredentialsTimeout time.Duration
+
+	// Defaults holds operator level defaults of the backup spec
+	Defaults SpecDefaults
+
+	// Import defines names and labels of the backups imported from the deployments
+	Import ImportTemplate
+
+	// AutoDeleteFailedAfter defines how long backups stay in Failed state before they are deleted during refresh,
+	// 0 disables deletion. Failed backups which are still available on the deployment are kept
+	AutoDeleteFailedAfter time.Duration
+
+	// RetentionExcludeImported excludes imported backups from the retention, they are neither counted nor deleted
+	RetentionExcludeImported bool
+
+	// DeploymentConcurrency defines how many backup operations can run in parallel on one deployment,
+	// operations above the limit are queued until one of the running operations finishes
+	DeploymentConcurrency int
+
+	// GlobalConcurrency defines how many backups can be handled in parallel by the operator across all deployments,
+	// handling above the limit waits for the free slot, 0 disables the limit
+	GlobalConcurrency int
+
+	// DrainTimeout defines how long in-flight backup operations are awaited on operator shutdown
+	// before remaining ArangoDB client calls are aborted, 0 aborts them immediately
+	DrainTimeout time.Duration
+
+	// LockWarningThreshold defines how long the deployment lock can be held without any operation finishing
+	// before it is reported as stuck, 0 disables the detection
+	LockWarningThreshold time.Duration
+
+	// TrimFinalStatus drops operation progress and state history from the status when backup reaches final state
+	// to reduce size of the stored objects, backup details and state are kept
+	TrimFinalStatus bool
+
+	// StateHistoryLimit defines how many recent state transitions are kept in the backup status, 0 disables the history
+	StateHistoryLimit int
+
+	// Leadership defines gate consulted before backups are handled and refreshed, nil means that operator is always the leader
+	Leadership LeadershipGate
+
+	// AllNamespaces enables handling of the deployments and backups in all namespaces instead of the operator namespace only.
+	// Operator needs cluster wide RBAC permissions to list and watch ArangoDeployments and ArangoBackups,
+	// and to update ArangoBackups and their status in every namespace
+	AllNamespaces bool
+
+	// DeploymentSelector is a label selector of the ArangoDeployments handled during refresh, empty selector matches all deployments
+	DeploymentSelector string
+
+	// CallbackURL defines endpoint notified with POST request when backup reaches Ready or Failed state, empty URL disables notifications
+	CallbackURL string
+
+	// CallbackAuthorization defines optional value of the Authorization header send with the callback request
+	CallbackAuthorization string
+
+	// RefreshInterval defines how often backups of the deployments are imported and checked.
+	// It needs to be at least 10s, 0 disables periodic refresh
+	RefreshInterval time.Duration
+
+	// RefreshJitter defines fraction of the RefreshInterval by which every refresh is randomly moved earlier or later,
+	// e.g. 0.2 spreads refreshes between 80% and 120% of the interval
+	RefreshJitter float64
+
+	// RequeueBaseDelay defines initial delay of the backup handling when backup is handled without state transition,
+	// delay grows exponentially up to RequeueMaxDelay and is reset after state transition
+	RequeueBaseDelay time.Duration
+
+	// RequeueMaxDelay defines maximum delay of the backup handling when backup is handled without state transition
+	RequeueMaxDelay time.Duration
+
+	// JobPollInterval defines initial delay of the backup handling while upload or download job is running on the server,
+	// delay doubles while job keeps running up to JobPollMaxDelay
+	JobPollInterval time.Duration
+
+	// JobPollMaxDelay defines maximum delay of the backup handling while upload or download job is running on the server
+	JobPollMaxDelay time.Duration
+}
+
+// SpecDefaults holds values used when they are not specified in the ArangoBackup spec
+type SpecDefaults struct {
+	// Timeout defines default timeout of the backup creation, 0 means not set
+	Timeout time.Duration
+
+	// AllowInconsistent allows creation of inconsistent backups by default
+	AllowInconsistent bool
+
+	// UploadRepositoryURL defines default repository of the backups with upload requested
+	UploadRepositoryURL string
+
+	// UploadCredentialsSecretName defines default secret with repository credentials of the backups with upload requested
+	UploadCredentialsSecretName string
+
+	// StateTimeout defines default time the backup can stay in one of the operation states, 0 means not set
+	StateTimeout time.Duration
+}
+
+// Validate validates the defaults
+func (s SpecDefaults) Validate() error {
+	if s.Timeout < 0 {
+		return fmt.Errorf("default timeout can not be negative")
+	}
+
+	if s.StateTimeout < 0 {
+		return fmt.Errorf("default state timeout can not be negative")
+	}
+
+	if s.StateTimeout > 0 && s.StateTimeout < time.Second {
+		return fmt.Errorf("default state timeout needs to be at least 1s")
+	}
+
+	if s.UploadCredentialsSecretName != "" {
+		if err := k8sutil.ValidateResourceName(s.UploadCredentialsSecretName); err != nil {
+			return fmt.Errorf("default upload credentials secret name is invalid: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// NewDefaultConfig returns configuration with default values
+func NewDefaultConfig() Config {
+	return Config{
+		HealthFreshness:          defaultHealthFreshness,
+		OwnerReferenceController: true,
+		ClockSkewThreshold:       defaultClockSkewThreshold,
+		CredentialsTimeout:       defaultCredentialsTimeout,
+		DeploymentConcurrency:    defaultDeploymentConcurrency,
+		LockWarningThreshold:     defaultLockWarningThreshold,
+		RefreshInterval:          defaultRefreshInterval,
+		RefreshJitter:            defaultRefreshJitter,
+		RequeueBaseDelay:         defaultRequeueBaseDelay,
+		RequeueMaxDelay:          defaultRequeueMaxDelay,
+		JobPollInterval:          defaultJobPollInterval,
+		JobPollMaxDelay:          defaultJobPollMaxDelay,
+		DrainTimeout:             defaultDrainTimeout,
+		Import: ImportTemplate{
+			NamePrefix: defaultImportNamePrefix,
+		},
+	}
+}
+
+// Validate validates the configuration
+func (c Config) Validate() error {
+	if c.HealthFreshness <= 0 {
+		return fmt.Errorf("health freshness window needs to be greater than 0")
+	}
+
+	if c.ClockSkewThreshold <= 0 {
+		return fmt.Errorf("clock skew threshold needs to be greater than 0")
+	}
+
+	if c.CredentialsTimeout <= 0 {
+		return fmt.Errorf("credentials timeout needs to be greater than 0")
+	}
+
+	if c.DeploymentConcurrency < 1 {
+		return fmt.Errorf("deployment concurrency needs to be greater than 0")
+	}
+
+	if c.GlobalConcurrency < 0 {
+		return fmt.Errorf("global concurrency can not be negative")
+	}
+
+	if c.DrainTimeout < 0 {
+		return fmt.Errorf("drain timeout can not be negative")
+	}
+
+	if c.AutoDeleteFailedAfter < 0 {
+		return fmt.Errorf("auto delete failed after can not be negative")
+	}
+
+	if c.StateHistoryLimit < 0 {
+		return fmt.Errorf("state history limit can not be negative")
+	}
+
+	if c.LockWarningThreshold < 0 {
+		return fmt.Errorf("lock warning threshold can not be negative")
+	}
+
+	if c.RefreshInterval != 0 && c.RefreshInterval < minRefreshInterval {
+		return fmt.Errorf("refresh interval needs to be 0 or at least %s", minRefreshInterval)
+	}
+
+	if c.RefreshJitter < 0 || c.RefreshJitter >= 1 {
+		return fmt.Errorf("refresh jitter needs to be in range [0, 1)")
+	}
+
+	if c.RequeueBaseDelay <= 0 {
+		return fmt.Errorf("requeue base delay needs to be greater than 0")
+	}
+
+	if c.RequeueMaxDelay < c.RequeueBaseDelay {
+		return fmt.Errorf("requeue max delay can not be lower than requeue base delay")
+	}
+
+	if c.JobPollInterval <= 0 {
+		return fmt.Errorf("job poll interval needs to be greater than 0")
+	}
+
+	if c.JobPollMaxDelay < c.JobPollInterval {
+		return fmt.Errorf("job poll max delay can not be lower than job poll interval")
+	}
+
+	if _, err := labels.Parse(c.DeploymentSelector); err != nil {
+		return fmt.Errorf("deployment selector is invalid: %s", err.Error())
+	}
+
+	if c.CallbackURL != "" {
+		if u, err := url.Parse(c.CallbackURL); err != nil {
+			return fmt.Errorf("callback url is invalid: %s", err.Error())
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("callback url scheme %s is not supported", u.Scheme)
+		}
+	} else if c.CallbackAuthorization != "" {
+		return fmt.Errorf("callback authorization requires callback url to be specified")
+	}
+
+	if c.OwnerReferenceDisabled && c.OwnerReferenceBlockOwnerDeletion {
+		return fmt.Errorf("owner reference BlockOwnerDeletion flag can not be set when owner references are disabled")
+	}
+
+	if err := c.Defaults.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Import.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Config_Default(t *testing.T) {
+	require.NoError(t, NewDefaultConfig().Validate())
+}
+
+func Test_Config_OwnerReference(t *testing.T) {
+	c := NewDefaultConfig()
+
+	c.OwnerReferenceController = false
+	require.NoError(t, c.Validate())
+
+	c.OwnerReferenceBlockOwnerDeletion = true
+	require.NoError(t, c.Validate())
+
+	c.OwnerReferenceDisabled = true
+	require.EqualError(t, c.Validate(), "owner reference BlockOwnerDeletion flag can not be set when owner references are disabled")
+
+	c.OwnerReferenceBlockOwnerDeletion = false
+	require.NoError(t, c.Validate())
+}
+
+func Test_Config_ClockSkewThreshold(t *testing.T) {
+	c := NewDefaultConfig()
+
+	c.ClockSkewThreshold = 0
+	require.EqualError(t, c.Validate(), "clock skew threshold needs to be greater than 0")
+}
+
+func Test_Config_CredentialsTimeout(t *testing.T) {
+	c := NewDefaultConfig()
+
+	c.CredentialsTimeout = 0
+	require.EqualError(t, c.Validate(), "credentials timeout needs to be greater than 0")
+}
+
+func Test_Config_Defaults(t *testing.T) {
+	c := NewDefaultConfig()
+
+	c.Defaults.Timeout = -time.Second
+	require.EqualError(t, c.Validate(), "default timeout can not be negative")
+
+	c.Defaults.Timeout = time.Minute
+	c.Defaults.StateTimeout = -time.Second
+	require.EqualError(t, c.Validate(), "default state timeout can not be negative")
+
+	c.Defaults.StateTimeout = 500 * time.Millisecond
+	require.EqualError(t, c.Validate(), "default state timeout needs to be at least 1s")
+
+	c.Defaults.StateTimeout = time.Hour
+	c.Defaults.UploadCredentialsSecretName = "Invalid_Name"
+	require.Error(t, c.Validate())
+
+	c.Defaults.UploadCredentialsSecretName = "credentials"
+	require.NoError(t, c.Validate())
+}
+
+func Test_Config_DeploymentConcurrency(t *testing.T) {
+	c := NewDefaultConfig()
+	require.Equal(t, 1, c.DeploymentConcurrency)
+
+	c.DeploymentConcurrency = 0
+	require.EqualError(t, c.Validate(), "deployment concurrency needs to be greater than 0")
+
+	c.DeploymentConcurrency = 4
+	require.NoError(t, c.Validate())
+}
+
+func Test_Config_GlobalConcurrency(t *testing.T) {
+	c := NewDefaultConfig()
+	require.Equal(t, 0, c.GlobalConcurrency)
+
+	c.GlobalConcurrency = -1
+	require.EqualError(t, c.Validate(), "global concurrency can not be negative")
+
+	c.GlobalConcurrency = 4
+	require.NoError(t, c.Validate())
+}
+
+func Test_Config_DrainTimeout(t *testing.T) {
+	c := NewDefaultConfig()
+	require.Equal(t, defaultDrainTimeout, c.DrainTimeout)
+
+	c.DrainTimeout = -time.Second
+	require.EqualError(t, c.Validate(), "drain timeout can not be negative")
+
+	c.DrainTimeout = 0
+	require.NoError(t, c.Validate())
+}
+
+func Test_Config_AutoDeleteFailedAfter(t *testing.T) {
+	c := NewDefaultConfig()
+	require.Equal(t, time.Duration(0), c.AutoDeleteFailedAfter)
+
+	c.AutoDeleteFailedAfter = -1
+	require.EqualError(t, c.Validate(), "auto delete failed after can not be negative")
+
+	c.AutoDeleteFailedAfter = time.Hour
+	require.NoError(t, c.Validate())
+}
+
+func Test_Config_StateHistoryLimit(t *testing.T) {
+	c := NewDefaultConfig()
+	require.Equal(t, 0, c.StateHistoryLimit)
+
+	c.StateHistoryLimit = -1
+	require.EqualError(t, c.Validate(), "state history limit can not be negative")
+
+	c.StateHistoryLimit = 5
+	require.NoError(t, c.Validate())
+}
+
+func Test_Config_LockWarningThreshold(t *testing.T) {
+	c := NewDefaultConfig()
+	require.Equal(t, 30*time.Minute, c.LockWarningThreshold)
+
+	c.LockWarningThreshold = -1
+	require.EqualError(t, c.Validate(), "lock warning threshold can not be negative")
+
+	c.LockWarningThreshold = 0
+	require.NoError(t, c.Validate())
+}
+
+func Test_Config_DeploymentSelector(t *testing.T) {
+	c := NewDefaultConfig()
+	require.Equal(t, "", c.DeploymentSelector)
+	require.NoError(t, c.Validate())
+
+	c.DeploymentSelector = "team in (a,b"
+	require.Error(t, c.Validate())
+
+	c.DeploymentSelector = "team=a"
+	require.NoError(t, c.Validate())
+}
+
+func Test_Config_Callback(t *testing.T) {
+	c := NewDefaultConfig()
+
+	c.CallbackAuthorization = "bearer token"
+	require.EqualError(t, c.Validate(), "callback authorization requires callback url to be specified")
+
+	c.CallbackURL = "ftp://example.com"
+	require.EqualError(t, c.Validate(), "callback url scheme ftp is not supported")
+
+	c.CallbackURL = "https://example.com/backups"
+	require.NoError(t, c.Validate())
+}
+
+func Test_Config_Requeue(t *testing.T) {
+	c := NewDefaultConfig()
+	require.Equal(t, defaultRequeueBaseDelay, c.RequeueBaseDelay)
+	require.Equal(t, defaultRequeueMaxDelay, c.RequeueMaxDelay)
+
+	c.RequeueBaseDelay = 0
+	require.EqualError(t, c.Validate(), "requeue base delay needs to be greater than 0")
+
+	c.RequeueBaseDelay = 2 * time.Minute
+	require.EqualError(t, c.Validate(), "requeue max delay can not be lower than requeue base delay")
+}
+
+func Test_Config_JobPollInterval(t *testing.T) {
+	c := NewDefaultConfig()
+	require.Equal(t, defaultJobPollInterval, c.JobPollInterval)
+
+	require.Equal(t, defaultJobPollMaxDelay, c.JobPollMaxDelay)
+
+	c.JobPollInterval = 0
+	require.EqualError(t, c.Validate(), "job poll interval needs to be greater than 0")
+
+	c.JobPollInterval = 10 * time.Minute
+	require.EqualError(t, c.Validate(), "job poll max delay can not be lower than job poll interval")
+}
+
+func Test_Config_Refresh(t *testing.T) {
+	c := NewDefaultConfig()
+	require.Equal(t, 2*time.Minute, c.RefreshInterval)
+	require.Equal(t, 0.2, c.RefreshJitter)
+	require.NoError(t, c.Validate())
+
+	c.RefreshJitter = 1
+	require.EqualError(t, c.Validate(), "refresh jitter needs to be in range [0, 1)")
+
+	c.RefreshJitter = -0.1
+	require.EqualError(t, c.Validate(), "refresh jitter needs to be in range [0, 1)")
+
+	c.RefreshJitter = 0
+	require.NoError(t, c.Validate())
+
+	c.RefreshInterval = 5 * time.Second
+	require.EqualError(t, c.Validate(), "refresh interval needs to be 0 or at least 10s")
+
+	c.RefreshInterval = -time.Minute
+	require.EqualError(t, c.Validate(), "refresh interval needs to be 0 or at least 10s")
+
+	c.RefreshInterval = 10 * time.Second
+	require.NoError(t, c.Validate())
+
+	// Periodic refresh disabled
+	c.RefreshInterval = 0
+	require.NoError(t, c.Validate())
+}
@@ -0,0 +1,181 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newConsistencyLevel(level backupApi.ArangoBackupConsistencyLevel) *backupApi.ArangoBackupConsistencyLevel {
+	return &level
+}
+
+func Test_ConsistencyLevel_CreateOptions(t *testing.T) {
+	timeout := float32(5)
+
+	cases := map[string]struct {
+		level             *backupApi.ArangoBackupConsistencyLevel
+		options           *backupApi.ArangoBackupSpecOptions
+		allowInconsistent bool
+		timeout           time.Duration
+	}{
+		"default": {},
+		"strict": {
+			level: newConsistencyLevel(backupApi.ArangoBackupConsistencyLevelStrict),
+		},
+		"eventual": {
+			level:             newConsistencyLevel(backupApi.ArangoBackupConsistencyLevelEventual),
+			allowInconsistent: true,
+		},
+		"allow inconsistent option": {
+			options: &backupApi.ArangoBackupSpecOptions{
+				AllowInconsistent: util.NewBool(true),
+			},
+			allowInconsistent: true,
+		},
+		"eventual with timeout": {
+			level: newConsistencyLevel(backupApi.ArangoBackupConsistencyLevelEventual),
+			options: &backupApi.ArangoBackupSpecOptions{
+				Timeout: &timeout,
+			},
+			allowInconsistent: true,
+			timeout:           5 * time.Second,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			obj, _ := newObjectSet(backupApi.ArangoBackupStateCreate)
+			obj.Spec.ConsistencyLevel = c.level
+			obj.Spec.Options = c.options
+
+			// Act
+			require.NoError(t, obj.Spec.Validate())
+			co := backupCreateOptions(obj)
+
+			// Assert
+			require.Equal(t, driver.BackupCreateOptions{
+				AllowInconsistent: c.allowInconsistent,
+				Timeout:           c.timeout,
+			}, co)
+		})
+	}
+}
+
+func Test_ConsistencyLevel_Validation(t *testing.T) {
+	// Arrange
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateCreate)
+
+	// Assert
+	obj.Spec.ConsistencyLevel = newConsistencyLevel(backupApi.ArangoBackupConsistencyLevelSnapshot)
+	require.EqualError(t, obj.Spec.Validate(), "consistency level snapshot is not supported by the server")
+
+	obj.Spec.ConsistencyLevel = newConsistencyLevel("unknown")
+	require.EqualError(t, obj.Spec.Validate(), "consistency level unknown is not supported")
+
+	obj.Spec.ConsistencyLevel = newConsistencyLevel(backupApi.ArangoBackupConsistencyLevelStrict)
+	obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+		AllowInconsistent: util.NewBool(true),
+	}
+	require.EqualError(t, obj.Spec.Validate(), "consistency level strict conflicts with allowInconsistent option")
+}
+
+func Test_ConsistencyLevel_Achieved(t *testing.T) {
+	for _, level := range []backupApi.ArangoBackupConsistencyLevel{
+		backupApi.ArangoBackupConsistencyLevelStrict,
+		backupApi.ArangoBackupConsistencyLevelEventual,
+	} {
+		t.Run(string(level), func(t *testing.T) {
+			// Arrange
+			handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+			obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+			obj.Spec.ConsistencyLevel = newConsistencyLevel(level)
+
+			// Act
+			createArangoDeployment(t, handler, deployment)
+			createArangoBackup(t, handler, obj)
+
+			require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+			// Assert
+			newObj := refreshArangoBackup(t, handler, obj)
+			checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+			require.Equal(t, level, newObj.Status.Backup.ConsistencyLevel)
+		})
+	}
+}
+
+func Test_ConsistencyLevel_InconsistentMessage(t *testing.T) {
+	run := func(t *testing.T, allowInconsistent bool) (*backupApi.ArangoBackup, []core.Event) {
+		// Arrange
+		handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+		obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+		obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+			AllowInconsistent: util.NewBool(allowInconsistent),
+		}
+
+		// Act
+		createArangoDeployment(t, handler, deployment)
+		createArangoBackup(t, handler, obj)
+
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		// Assert
+		newObj := refreshArangoBackup(t, handler, obj)
+		checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+		events, err := handler.kubeClient.CoreV1().Events(obj.Namespace).List(meta.ListOptions{})
+		require.NoError(t, err)
+
+		return newObj, events.Items
+	}
+
+	t.Run("Consistent", func(t *testing.T) {
+		obj, events := run(t, false)
+		require.False(t, *obj.Status.Backup.PotentiallyInconsistent)
+		require.Equal(t, "", obj.Status.Message)
+		require.Len(t, events, 1)
+		require.Equal(t, "Transiting from Create to Ready", events[0].Message)
+	})
+
+	t.Run("Potentially inconsistent", func(t *testing.T) {
+		obj, events := run(t, true)
+		require.True(t, *obj.Status.Backup.PotentiallyInconsistent)
+		require.Equal(t, "backup is potentially inconsistent", obj.Status.Message)
+		require.Len(t, events, 1)
+		require.Equal(t, StateChange, events[0].Reason)
+		require.Equal(t, "Transiting from Create to Ready with message: backup is potentially inconsistent", events[0].Message)
+	})
+}
@@ -0,0 +1,48 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+)
+
+// waitingForDeploymentMessage prefixes status message of the backup waiting for the deployment to be Ready
+const waitingForDeploymentMessage = "WaitingForDeployment"
+
+// waitForDeploymentReady returns status with unchanged state and waiting message if deployment does not report Ready condition,
+// e.g. during rolling upgrade. Message contains reason of the Ready condition if it is reported.
+// Backup is handled again after requeue delay. Returns nil if deployment is Ready.
+func waitForDeploymentReady(backup *backupApi.ArangoBackup, deployment *database.ArangoDeployment) (*backupApi.ArangoBackupStatus, error) {
+	if deployment.Status.Conditions.IsTrue(database.ConditionTypeReady) {
+		return nil, nil
+	}
+
+	if condition, ok := deployment.Status.Conditions.Get(database.ConditionTypeReady); ok && condition.Reason != "" {
+		return wrapUpdateStatus(backup,
+			updateStatusState(backup.Status.State, "%s: deployment %s is not Ready: %s", waitingForDeploymentMessage, deployment.Name, condition.Reason))
+	}
+
+	return wrapUpdateStatus(backup,
+		updateStatusState(backup.Status.State, "%s: deployment %s is not Ready", waitingForDeploymentMessage, deployment.Name))
+}
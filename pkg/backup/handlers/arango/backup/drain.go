@@ -0,0 +1,91 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// beginOperation registers in-flight Handle call, returns false when operator is shutting down and new work is not accepted
+func (h *handler) beginOperation() bool {
+	h.drainLock.Lock()
+	defer h.drainLock.Unlock()
+
+	if h.draining {
+		return false
+	}
+
+	h.inFlightCount++
+	h.inFlight.Add(1)
+
+	return true
+}
+
+// endOperation marks in-flight Handle call as finished
+func (h *handler) endOperation() {
+	h.drainLock.Lock()
+	h.inFlightCount--
+	h.drainLock.Unlock()
+
+	h.inFlight.Done()
+}
+
+// drain stops accepting new work and waits up to timeout for in-flight Handle calls to finish.
+// Returns number of operations which were in-flight when drain started.
+func (h *handler) drain(timeout time.Duration) int {
+	h.drainLock.Lock()
+	h.draining = true
+	count := h.inFlightCount
+	h.drainLock.Unlock()
+
+	if count == 0 {
+		log.Info().Msgf("No in-flight backup operations to drain")
+		return 0
+	}
+
+	log.Info().Msgf("Draining %d in-flight backup operations", count)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.inFlight.Wait()
+	}()
+
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+
+	select {
+	case <-done:
+		log.Info().Msgf("Drained %d in-flight backup operations", count)
+	case <-t.C:
+		h.drainLock.Lock()
+		left := h.inFlightCount
+		h.drainLock.Unlock()
+
+		log.Warn().Msgf("Drain timeout %s reached, %d of %d in-flight backup operations are still running", timeout, left, count)
+	}
+
+	return count
+}
@@ -0,0 +1,107 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Drain_WaitsForInFlight(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	require.True(t, handler.beginOperation())
+	require.True(t, handler.beginOperation())
+
+	go func() {
+		time.Sleep(semaphoreWait)
+		handler.endOperation()
+		handler.endOperation()
+	}()
+
+	// Act
+	var count int
+	drained := acquireAsync(func() {
+		count = handler.drain(5 * time.Second)
+	})
+
+	// Assert
+	requireBlocked(t, drained)
+	requireAcquired(t, drained)
+	require.Equal(t, 2, count)
+	require.Equal(t, 0, handler.inFlightCount)
+
+	// New work is not accepted
+	require.False(t, handler.beginOperation())
+}
+
+func Test_Drain_Timeout(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	require.True(t, handler.beginOperation())
+	defer handler.endOperation()
+
+	// Act
+	start := time.Now()
+	require.Equal(t, 1, handler.drain(semaphoreWait))
+
+	// Assert
+	require.True(t, time.Since(start) >= semaphoreWait)
+	require.Equal(t, 1, handler.inFlightCount)
+}
+
+func Test_Drain_Empty(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	// Act
+	require.Equal(t, 0, handler.drain(time.Minute))
+
+	// Assert
+	require.False(t, handler.beginOperation())
+}
+
+func Test_Drain_HandleSkipped(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	handler.drain(0)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateNone, newObj.Status.State)
+}
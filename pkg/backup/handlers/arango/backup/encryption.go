@@ -0,0 +1,84 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/rs/zerolog/log"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// encryptionSecretVersion returns resource version of the deployment encryption key secret.
+// Empty string is returned if deployment is not encrypted or secret can not be fetched.
+func (h *handler) encryptionSecretVersion(deployment *database.ArangoDeployment) string {
+	if !deployment.Spec.RocksDB.IsEncrypted() {
+		return ""
+	}
+
+	name := deployment.Spec.RocksDB.Encryption.GetKeySecretName()
+
+	secret, err := h.kubeClient.CoreV1().Secrets(deployment.Namespace).Get(name, meta.GetOptions{})
+	if err != nil {
+		log.Debug().Err(err).Msgf("Unable to get encryption key secret %s/%s", deployment.Namespace, name)
+		return ""
+	}
+
+	return secret.ResourceVersion
+}
+
+// checkEncryptionSecretVersion warns about backups created with encryption key secret which changed since then.
+// Check is best effort, backups without recorded version are skipped.
+// Each backup is warned once per secret version, the warned version is kept in the backup status.
+func (h *handler) checkEncryptionSecretVersion(deployment *database.ArangoDeployment, backups []backupApi.ArangoBackup) {
+	version := h.encryptionSecretVersion(deployment)
+	if version == "" {
+		return
+	}
+
+	for id := range backups {
+		backup := &backups[id]
+
+		if backup.Spec.Deployment.Name != deployment.Name {
+			continue
+		}
+
+		if backup.Status.EncryptionSecretVersion == "" || backup.Status.EncryptionSecretVersion == version {
+			continue
+		}
+
+		if backup.Status.EncryptionSecretWarnedVersion == version {
+			continue
+		}
+
+		h.eventRecorder.Warning(backup, EncryptionKeyChanged, "Encryption key secret %s changed after backup creation, restore of the backup may fail",
+			deployment.Spec.RocksDB.Encryption.GetKeySecretName())
+
+		b := backup.DeepCopy()
+		b.Status.EncryptionSecretWarnedVersion = version
+
+		if _, err := h.client.BackupV1().ArangoBackups(b.Namespace).UpdateStatus(b); err != nil {
+			log.Warn().Err(err).Msgf("Unable to record encryption key secret warning of %s/%s", b.Namespace, b.Name)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newEncryptionSecret(t *testing.T, handler *handler, deployment *database.ArangoDeployment, version string) {
+	deployment.Spec.RocksDB.Encryption.KeySecretName = util.NewString("encryption-key")
+
+	secret := &core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Name:            "encryption-key",
+			Namespace:       deployment.Namespace,
+			ResourceVersion: version,
+		},
+	}
+
+	_, err := handler.kubeClient.CoreV1().Secrets(deployment.Namespace).Create(secret)
+	require.NoError(t, err)
+}
+
+func Test_Encryption_RecordVersionOnCreate(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	newEncryptionSecret(t, handler, deployment, "1")
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+	require.Equal(t, "1", newObj.Status.EncryptionSecretVersion)
+}
+
+func Test_Encryption_NotEncrypted(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+	require.Equal(t, "", newObj.Status.EncryptionSecretVersion)
+}
+
+func Test_Encryption_SecretChanged(t *testing.T) {
+	run := func(t *testing.T, created, current string) []core.Event {
+		// Arrange
+		handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+		obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+		obj.Status.EncryptionSecretVersion = created
+		newEncryptionSecret(t, handler, deployment, current)
+
+		createArangoDeployment(t, handler, deployment)
+		createArangoBackup(t, handler, obj)
+
+		// Act
+		_, err := handler.refreshDeployment(deployment)
+		require.NoError(t, err)
+
+		// Assert
+		events, err := handler.kubeClient.CoreV1().Events(deployment.Namespace).List(meta.ListOptions{})
+		require.NoError(t, err)
+
+		return events.Items
+	}
+
+	t.Run("Same version", func(t *testing.T) {
+		require.Len(t, run(t, "1", "1"), 0)
+	})
+
+	t.Run("Version not recorded", func(t *testing.T) {
+		require.Len(t, run(t, "", "2"), 0)
+	})
+
+	t.Run("Changed version", func(t *testing.T) {
+		events := run(t, "1", "2")
+		require.Len(t, events, 1)
+		require.Equal(t, EncryptionKeyChanged, events[0].Reason)
+		require.Equal(t, core.EventTypeWarning, events[0].Type)
+		require.Equal(t, "Encryption key secret encryption-key changed after backup creation, restore of the backup may fail", events[0].Message)
+	})
+}
+
+func Test_Encryption_SecretChanged_WarnedOnce(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Status.EncryptionSecretVersion = "1"
+	newEncryptionSecret(t, handler, deployment, "2")
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	// Act
+	for i := 0; i < 3; i++ {
+		_, err := handler.refreshDeployment(deployment)
+		require.NoError(t, err)
+	}
+
+	// Assert
+	events, err := handler.kubeClient.CoreV1().Events(deployment.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 1)
+
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, "2", newObj.Status.EncryptionSecretWarnedVersion)
+
+	// Secret changes again
+	secret, err := handler.kubeClient.CoreV1().Secrets(deployment.Namespace).Get("encryption-key", meta.GetOptions{})
+	require.NoError(t, err)
+	secret.ResourceVersion = "3"
+	_, err = handler.kubeClient.CoreV1().Secrets(deployment.Namespace).Update(secret)
+	require.NoError(t, err)
+
+	_, err = handler.refreshDeployment(deployment)
+	require.NoError(t, err)
+
+	events, err = handler.kubeClient.CoreV1().Events(deployment.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 2)
+}
@@ -23,13 +23,37 @@
 package backup
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/arangodb/go-driver"
+	"github.com/arangodb/kube-arangodb/pkg/backup/state"
 	"github.com/arangodb/kube-arangodb/pkg/backup/utils"
 )
 
+// ErrUnsupportedBackupState matches with errors.Is every error returned for the backup in state without handler
+var ErrUnsupportedBackupState = UnsupportedBackupStateError{}
+
+// UnsupportedBackupStateError is returned when there is no handler for the state of the backup
+type UnsupportedBackupStateError struct {
+	State state.State
+}
+
+func (u UnsupportedBackupStateError) Error() string {
+	return fmt.Sprintf("state %s is not supported", u.State)
+}
+
+// Is returns true for every UnsupportedBackupStateError, regardless of the state
+func (u UnsupportedBackupStateError) Is(target error) bool {
+	_, ok := target.(UnsupportedBackupStateError)
+	return ok
+}
+
+func isUnsupportedBackupStateError(err error) bool {
+	return errors.Is(err, ErrUnsupportedBackupState)
+}
+
 func newTemporaryError(err error) error {
 	return temporaryError{
 		Causer: err,
@@ -0,0 +1,90 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/rs/zerolog/log"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// FailedBackupExpired name of the event send when failed backup was deleted after AutoDeleteFailedAfter
+	FailedBackupExpired = "FailedBackupExpired"
+)
+
+// deleteExpiredFailedBackups deletes backups of the deployment which are failed for longer than AutoDeleteFailedAfter.
+// Deletion goes through the finalizer. Deployment mutex needs to be acquired by the caller.
+func (h *handler) deleteExpiredFailedBackups(deployment *database.ArangoDeployment, backups []backupApi.ArangoBackup) (int, error) {
+	if h.config.AutoDeleteFailedAfter == 0 {
+		return 0, nil
+	}
+
+	deleted := 0
+
+	for _, backup := range expiredFailedBackups(deployment.Name, backups, time.Now(), h.config.AutoDeleteFailedAfter) {
+		log.Info().Msgf("Deleting backup %s/%s, failed for longer than %s: %s", backup.Namespace, backup.Name, h.config.AutoDeleteFailedAfter, backup.Status.Message)
+
+		h.eventRecorder.Warning(&backup, FailedBackupExpired, "Deleting backup failed for longer than %s: %s", h.config.AutoDeleteFailedAfter, backup.Status.Message)
+
+		if err := h.client.BackupV1().ArangoBackups(backup.Namespace).Delete(backup.Name, &meta.DeleteOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+
+			return deleted, err
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// expiredFailedBackups returns failed backups of the deployment which entered the Failed state before now - after.
+// Backups which are still available on the deployment are skipped, their failure does not make the data unusable.
+func expiredFailedBackups(deployment string, backups []backupApi.ArangoBackup, now time.Time, after time.Duration) []backupApi.ArangoBackup {
+	var expired []backupApi.ArangoBackup
+
+	for _, backup := range backups {
+		if backup.Spec.Deployment.Name != deployment || backup.DeletionTimestamp != nil {
+			continue
+		}
+
+		if backup.Status.State != backupApi.ArangoBackupStateFailed || backup.Status.Available {
+			continue
+		}
+
+		if now.Sub(backup.Status.Time.Time) <= after {
+			continue
+		}
+
+		expired = append(expired, backup)
+	}
+
+	return expired
+}
@@ -0,0 +1,109 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ExpiredFailedBackups(t *testing.T) {
+	now := time.Now()
+	deletion := meta.Now()
+
+	newFailed := func(name string, age time.Duration) backupApi.ArangoBackup {
+		b, _ := newObjectSet(backupApi.ArangoBackupStateFailed)
+		b.Name = name
+		b.Spec.Deployment.Name = "deployment"
+		b.Status.Time = meta.NewTime(now.Add(-age))
+		return *b
+	}
+
+	expired := newFailed("expired", 2*time.Hour)
+
+	fresh := newFailed("fresh", 30*time.Minute)
+
+	available := newFailed("available", 2*time.Hour)
+	available.Status.Available = true
+
+	deleting := newFailed("deleting", 2*time.Hour)
+	deleting.DeletionTimestamp = &deletion
+
+	other := newFailed("other", 2*time.Hour)
+	other.Spec.Deployment.Name = "other"
+
+	ready := newFailed("ready", 2*time.Hour)
+	ready.Status.State = backupApi.ArangoBackupStateReady
+
+	result := expiredFailedBackups("deployment", []backupApi.ArangoBackup{expired, fresh, available, deleting, other, ready}, now, time.Hour)
+
+	require.Len(t, result, 1)
+	require.Equal(t, "expired", result[0].Name)
+}
+
+func Test_Refresh_DeleteExpiredFailedBackups(t *testing.T) {
+	run := func(t *testing.T, after time.Duration) bool {
+		// Arrange
+		handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+		handler.config.AutoDeleteFailedAfter = after
+
+		obj, deployment := newObjectSet(backupApi.ArangoBackupStateFailed)
+		obj.Status.Time = meta.NewTime(time.Now().Add(-2 * time.Hour))
+		obj.Status.Message = "creation failed"
+
+		createArangoDeployment(t, handler, deployment)
+		createArangoBackup(t, handler, obj)
+
+		// Act
+		result, err := handler.refreshDeployment(deployment)
+		require.NoError(t, err)
+
+		// Assert
+		_, err = handler.client.BackupV1().ArangoBackups(obj.Namespace).Get(obj.Name, meta.GetOptions{})
+		if errors.IsNotFound(err) {
+			require.Equal(t, 1, result.Pruned)
+			return true
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, 0, result.Pruned)
+		return false
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		require.False(t, run(t, 0))
+	})
+
+	t.Run("Not expired", func(t *testing.T) {
+		require.False(t, run(t, 3*time.Hour))
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		require.True(t, run(t, time.Hour))
+	})
+}
@@ -23,6 +23,8 @@
 package backup
 
 import (
+	"fmt"
+
 	"github.com/arangodb/go-driver"
 	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
 	"github.com/arangodb/kube-arangodb/pkg/backup/utils"
@@ -42,23 +44,48 @@ func (h *handler) finalize(backup *backupApi.ArangoBackup) error {
 	for _, finalizer := range finalizers {
 		switch finalizer {
 		case backupApi.FinalizerArangoBackup:
-			if err := h.finalizeBackup(backup); err != nil {
+			if err := h.markBackupDeleting(backup); err != nil {
 				return err
 			}
-			finalizersToRemove = append(finalizersToRemove, backupApi.FinalizerArangoBackup)
 
-			h.eventRecorder.Normal(backup, FinalizerChange, "Removed Finalizer: %s", backupApi.FinalizerArangoBackup)
+			if err := h.finalizeBackup(backup); err != nil {
+				if !isFinalizeRetriesExhausted(backup) {
+					if sErr := h.recordFinalizeRetry(backup); sErr != nil {
+						log.Warn().Err(sErr).
+							Str("namespace", backup.Namespace).
+							Str("name", backup.Name).
+							Msgf("Unable to record finalize retry for %s %s/%s",
+								backup.GroupVersionKind().String(),
+								backup.Namespace,
+								backup.Name)
+					}
+
+					return err
+				}
+
+				h.eventRecorder.Warning(backup, FinalizerChange, "Force removed Finalizer %s after %d failed attempts: %s",
+					backupApi.FinalizerArangoBackup,
+					backup.Status.FinalizeRetries+1,
+					err.Error())
+			} else {
+				h.eventRecorder.Normal(backup, FinalizerChange, "Removed Finalizer: %s", backupApi.FinalizerArangoBackup)
+			}
+
+			finalizersToRemove = append(finalizersToRemove, backupApi.FinalizerArangoBackup)
 		}
 	}
 
 	backup.Finalizers = finalizers.Remove(finalizersToRemove...)
 
 	if i := len(backup.Finalizers); i > 0 {
-		log.Warn().Msgf("After finalizing on object %s %s/%s finalizers left: %d",
-			backup.GroupVersionKind().String(),
-			backup.Namespace,
-			backup.Name,
-			i)
+		log.Warn().
+			Str("namespace", backup.Namespace).
+			Str("name", backup.Name).
+			Msgf("After finalizing on object %s %s/%s finalizers left: %d",
+				backup.GroupVersionKind().String(),
+				backup.Namespace,
+				backup.Name,
+				i)
 	}
 
 	if _, err := h.client.BackupV1().ArangoBackups(backup.Namespace).Update(backup); err != nil {
@@ -69,11 +96,11 @@ func (h *handler) finalize(backup *backupApi.ArangoBackup) error {
 }
 
 func (h *handler) finalizeBackup(backup *backupApi.ArangoBackup) error {
-	lock := h.getDeploymentMutex(backup.Namespace, backup.Spec.Deployment.Name)
-	lock.Lock()
-	defer lock.Unlock()
+	s := h.getDeploymentSemaphore(backup.Namespace, backup.Spec.Deployment.Name)
+	s.Acquire()
+	defer s.Release()
 
-	if backup.Status.Backup == nil {
+	if backup.Status.Backup == nil && backup.Status.Progress == nil {
 		// No details passed, object can be removed
 		return nil
 	}
@@ -94,6 +121,20 @@ func (h *handler) finalizeBackup(backup *backupApi.ArangoBackup) error {
 		return err
 	}
 
+	client, err := h.arangoClientFactory(deployment, backup)
+	if err != nil {
+		return err
+	}
+
+	// Running upload or download job needs to be cancelled before backup is removed
+	if err = h.finalizeBackupAction(backup, client); err != nil {
+		return err
+	}
+
+	if backup.Status.Backup == nil {
+		return nil
+	}
+
 	backups, err := h.client.BackupV1().ArangoBackups(backup.Namespace).List(meta.ListOptions{})
 	if err != nil {
 		return err
@@ -114,55 +155,140 @@ func (h *handler) finalizeBackup(backup *backupApi.ArangoBackup) error {
 		}
 	}
 
-	client, err := h.arangoClientFactory(deployment, backup)
+	err = utils.Retry(finalizeRetryCount, finalizeRetryDelay, func() error {
+		exists, err := client.Exists(h.ctx, driver.BackupID(backup.Status.Backup.ID))
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return nil
+		}
+
+		if err := client.Delete(h.ctx, driver.BackupID(backup.Status.Backup.ID)); err != nil {
+			return err
+		}
+
+		// Finalizer is removed only when backup is gone from the server
+		if exists, err = client.Exists(h.ctx, driver.BackupID(backup.Status.Backup.ID)); err != nil {
+			return err
+		} else if exists {
+			return fmt.Errorf("backup %s is not yet removed", backup.Status.Backup.ID)
+		}
+
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if err = h.finalizeBackupAction(backup, client); err != nil {
-		log.Warn().Err(err).Msgf("Operation abort failed for %s %s/%s",
-			backup.GroupVersionKind().String(),
-			backup.Namespace,
-			backup.Name)
-	}
+	h.eventRecorder.Normal(backup, FinalizerChange, "Removed backup %s from deployment %s",
+		backup.Status.Backup.ID,
+		deployment.Name)
 
-	exists, err := client.Exists(driver.BackupID(backup.Status.Backup.ID))
-	if err != nil {
-		return err
+	return nil
+}
+
+// markBackupDeleting moves backup into Deleting state while it is removed from the server.
+// Backups without server side details or in states which can not transit into Deleting are left untouched.
+func (h *handler) markBackupDeleting(backup *backupApi.ArangoBackup) error {
+	if backup.Status.Backup == nil || backup.Status.State == backupApi.ArangoBackupStateDeleting {
+		return nil
 	}
 
-	if !exists {
+	if err := backupApi.ArangoBackupStateMap.Transit(backup.Status.State, backupApi.ArangoBackupStateDeleting); err != nil {
 		return nil
 	}
 
-	err = client.Delete(driver.BackupID(backup.Status.Backup.ID))
+	h.eventRecorder.Normal(backup, StateChange, "Transiting from %s to %s",
+		backup.Status.State,
+		backupApi.ArangoBackupStateDeleting)
+
+	b := backup.DeepCopy()
+	b.Status = *updateStatus(b, updateStatusState(backupApi.ArangoBackupStateDeleting, ""))
+
+	if err := h.updateBackupStatus(b); err != nil {
+		return err
+	}
+
+	// Refresh object to get current resource version
+	updated, err := h.client.BackupV1().ArangoBackups(backup.Namespace).Get(backup.Name, meta.GetOptions{})
 	if err != nil {
 		return err
 	}
 
+	*backup = *updated
+
 	return nil
 }
 
+// isFinalizeRetriesExhausted returns true if backup reached limit of the failed finalize attempts
+func isFinalizeRetriesExhausted(backup *backupApi.ArangoBackup) bool {
+	max, ok := backup.Spec.Policy.GetMaxFinalizeRetries()
+	if !ok {
+		return false
+	}
+
+	return backup.Status.FinalizeRetries >= max
+}
+
+func (h *handler) recordFinalizeRetry(backup *backupApi.ArangoBackup) error {
+	b := backup.DeepCopy()
+	b.Status.FinalizeRetries++
+
+	return h.updateBackupStatus(b)
+}
+
+// finalizeBackupAction aborts running upload or download job and waits until cancellation is confirmed by the server
 func (h *handler) finalizeBackupAction(backup *backupApi.ArangoBackup, client ArangoBackupClient) error {
 	if backup.Status.Progress == nil {
 		return nil
 	}
-	status, err := client.Progress(driver.BackupTransferJobID(backup.Status.Progress.JobID))
-	if err != nil {
+
+	jobID := driver.BackupTransferJobID(backup.Status.Progress.JobID)
+
+	if done, err := h.isJobDone(client, jobID); err != nil {
 		return err
+	} else if done {
+		return nil
 	}
 
-	if status.Failed || status.Completed {
-		return nil
+	if err := client.Abort(h.ctx, jobID); err != nil {
+		return err
 	}
 
-	if err = client.Abort(driver.BackupTransferJobID(backup.Status.Progress.JobID)); err != nil {
+	err := utils.Retry(finalizeRetryCount, finalizeRetryDelay, func() error {
+		if done, err := h.isJobDone(client, jobID); err != nil {
+			return err
+		} else if !done {
+			return fmt.Errorf("job %s is not yet cancelled", jobID)
+		}
+
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
+	h.eventRecorder.Normal(backup, FinalizerChange, "Aborted job %s in state %s", jobID, backup.Status.State)
+
 	return nil
 }
 
+// isJobDone returns true if transfer job is not running anymore
+func (h *handler) isJobDone(client ArangoBackupClient, jobID driver.BackupTransferJobID) (bool, error) {
+	status, err := client.Progress(h.ctx, jobID)
+	if err != nil {
+		if driver.IsNotFound(err) {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	return status.Failed || status.Completed || status.Cancelled, nil
+}
+
 func hasFinalizers(backup *backupApi.ArangoBackup) bool {
 	if backup.Finalizers == nil {
 		return false
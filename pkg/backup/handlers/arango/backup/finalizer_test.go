@@ -23,11 +23,15 @@
 package backup
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	deploymentType "github.com/arangodb/kube-arangodb/pkg/apis/deployment"
 	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
 	"github.com/stretchr/testify/require"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -66,7 +70,7 @@ func Test_Finalizer_RemoveObject(t *testing.T) {
 	time := meta.Now()
 	obj.DeletionTimestamp = &time
 
-	backupMeta, err := mock.Create()
+	backupMeta, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = &backupApi.ArangoBackupDetails{
@@ -84,12 +88,13 @@ func Test_Finalizer_RemoveObject(t *testing.T) {
 
 	// Assert
 	newObj := refreshArangoBackup(t, handler, obj)
-	require.Equal(t, newObj.Status, obj.Status)
+	require.Equal(t, backupApi.ArangoBackupStateDeleting, newObj.Status.State)
+	require.Equal(t, newObj.Status.Backup, obj.Status.Backup)
 	require.Equal(t, newObj.Spec, obj.Spec)
 
 	require.Len(t, newObj.Finalizers, 0)
 
-	exists, err := mock.Exists(backupMeta.ID)
+	exists, err := mock.Exists(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 	require.False(t, exists)
 }
@@ -103,7 +108,7 @@ func Test_Finalizer_RemoveObject_WithoutFinalizer(t *testing.T) {
 	time := meta.Now()
 	obj.DeletionTimestamp = &time
 
-	backupMeta, err := mock.Create()
+	backupMeta, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = &backupApi.ArangoBackupDetails{
@@ -127,7 +132,7 @@ func Test_Finalizer_RemoveObject_WithoutFinalizer(t *testing.T) {
 
 	require.Len(t, newObj.Finalizers, 0)
 
-	exists, err := mock.Exists(backupMeta.ID)
+	exists, err := mock.Exists(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 	require.True(t, exists)
 }
@@ -144,7 +149,7 @@ func Test_Finalizer_RemoveObject_UnknownFinalizer(t *testing.T) {
 	time := meta.Now()
 	obj.DeletionTimestamp = &time
 
-	backupMeta, err := mock.Create()
+	backupMeta, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = &backupApi.ArangoBackupDetails{
@@ -167,7 +172,7 @@ func Test_Finalizer_RemoveObject_UnknownFinalizer(t *testing.T) {
 
 	require.Len(t, newObj.Finalizers, 1)
 
-	exists, err := mock.Exists(backupMeta.ID)
+	exists, err := mock.Exists(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 	require.True(t, exists)
 }
@@ -185,7 +190,7 @@ func Test_Finalizer_RemoveObject_MixedFinalizers(t *testing.T) {
 	time := meta.Now()
 	obj.DeletionTimestamp = &time
 
-	backupMeta, err := mock.Create()
+	backupMeta, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = &backupApi.ArangoBackupDetails{
@@ -203,12 +208,13 @@ func Test_Finalizer_RemoveObject_MixedFinalizers(t *testing.T) {
 
 	// Assert
 	newObj := refreshArangoBackup(t, handler, obj)
-	require.Equal(t, newObj.Status, obj.Status)
+	require.Equal(t, backupApi.ArangoBackupStateDeleting, newObj.Status.State)
+	require.Equal(t, newObj.Status.Backup, obj.Status.Backup)
 	require.Equal(t, newObj.Spec, obj.Spec)
 
 	require.Len(t, newObj.Finalizers, 1)
 
-	exists, err := mock.Exists(backupMeta.ID)
+	exists, err := mock.Exists(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 	require.False(t, exists)
 }
@@ -255,3 +261,299 @@ func Test_Finalizer_AppendDefault(t *testing.T) {
 	require.NotNil(t, newObj.Finalizers)
 	require.True(t, hasFinalizers(newObj))
 }
+
+func Test_Finalizer_MissingDeployment(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	obj.Finalizers = nil
+
+	// Act
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, false)
+	require.Equal(t, createStateMessage(backupApi.ArangoBackupStateNone, backupApi.ArangoBackupStateFailed,
+		fmt.Sprintf("%s \"%s\" not found", deploymentType.ArangoDeploymentCRDName, obj.Spec.Deployment.Name)), newObj.Status.Message)
+	require.Len(t, newObj.Finalizers, 0)
+	require.Len(t, newObj.OwnerReferences, 0)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, false)
+	require.Len(t, newObj.Finalizers, 0)
+}
+
+func Test_Finalizer_RetriesExhausted(t *testing.T) {
+	// Arrange
+	error := fmt.Errorf("delete error")
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{
+		deleteError: error,
+	})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Finalizers = []string{
+		backupApi.FinalizerArangoBackup,
+	}
+	obj.Spec.Policy = &backupApi.ArangoBackupSpecPolicy{
+		MaxFinalizeRetries: util.NewInt(1),
+	}
+
+	time := meta.Now()
+	obj.DeletionTimestamp = &time
+
+	backupMeta, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{
+		ID:                      string(backupMeta.ID),
+		PotentiallyInconsistent: &backupMeta.PotentiallyInconsistent,
+		Version:                 backupMeta.Version,
+		CreationTimestamp:       meta.Now(),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.EqualError(t, handler.Handle(newItemFromBackup(operation.Delete, obj)), error.Error())
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, 1, newObj.Status.FinalizeRetries)
+	require.Len(t, newObj.Finalizers, 1)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Delete, obj)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	require.Len(t, newObj.Finalizers, 0)
+
+	exists, err := mock.Exists(context.Background(), backupMeta.ID)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func Test_Finalizer_RetriesUnlimited(t *testing.T) {
+	// Arrange
+	error := fmt.Errorf("delete error")
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{
+		deleteError: error,
+	})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Finalizers = []string{
+		backupApi.FinalizerArangoBackup,
+	}
+
+	time := meta.Now()
+	obj.DeletionTimestamp = &time
+
+	backupMeta, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{
+		ID:                      string(backupMeta.ID),
+		PotentiallyInconsistent: &backupMeta.PotentiallyInconsistent,
+		Version:                 backupMeta.Version,
+		CreationTimestamp:       meta.Now(),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.EqualError(t, handler.Handle(newItemFromBackup(operation.Delete, obj)), error.Error())
+	require.EqualError(t, handler.Handle(newItemFromBackup(operation.Delete, obj)), error.Error())
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, 2, newObj.Status.FinalizeRetries)
+	require.Len(t, newObj.Finalizers, 1)
+}
+
+func Test_Finalizer_Deleting_KeepsStateUntilRemoved(t *testing.T) {
+	// Arrange
+	error := fmt.Errorf("delete error")
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{
+		deleteError: error,
+	})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateFailed)
+	obj.Finalizers = []string{
+		backupApi.FinalizerArangoBackup,
+	}
+
+	time := meta.Now()
+	obj.DeletionTimestamp = &time
+
+	backupMeta, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{
+		ID:                      string(backupMeta.ID),
+		PotentiallyInconsistent: &backupMeta.PotentiallyInconsistent,
+		Version:                 backupMeta.Version,
+		CreationTimestamp:       meta.Now(),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.EqualError(t, handler.Handle(newItemFromBackup(operation.Delete, obj)), error.Error())
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateDeleting, newObj.Status.State)
+	require.Len(t, newObj.Finalizers, 1)
+
+	exists, err := mock.Exists(context.Background(), backupMeta.ID)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func Test_Finalizer_Deleting_NotPossibleFromState(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUnavailable)
+	obj.Finalizers = []string{
+		backupApi.FinalizerArangoBackup,
+	}
+
+	time := meta.Now()
+	obj.DeletionTimestamp = &time
+
+	backupMeta, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{
+		ID:                      string(backupMeta.ID),
+		PotentiallyInconsistent: &backupMeta.PotentiallyInconsistent,
+		Version:                 backupMeta.Version,
+		CreationTimestamp:       meta.Now(),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Delete, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateUnavailable, newObj.Status.State)
+	require.Len(t, newObj.Finalizers, 0)
+
+	exists, err := mock.Exists(context.Background(), backupMeta.ID)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func Test_Finalizer_RemoveObject_RunningUpload(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUploading)
+	obj.Finalizers = []string{
+		backupApi.FinalizerArangoBackup,
+	}
+
+	time := meta.Now()
+	obj.DeletionTimestamp = &time
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	progress, err := mock.Upload(context.Background(), createResponse.ID, backupApi.ArangoBackupSpecOperation{})
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(createResponse.BackupMeta, nil)
+	obj.Status.Progress = &backupApi.ArangoBackupProgress{
+		JobID: string(progress),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Delete, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Len(t, newObj.Finalizers, 0)
+
+	jobProgress, err := mock.Progress(context.Background(), progress)
+	require.NoError(t, err)
+	require.True(t, jobProgress.Cancelled)
+
+	exists, err := mock.Exists(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	events, err := handler.kubeClient.CoreV1().Events(obj.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+
+	var messages []string
+	for _, event := range events.Items {
+		if event.Reason == FinalizerChange {
+			messages = append(messages, event.Message)
+		}
+	}
+
+	require.Equal(t, []string{
+		fmt.Sprintf("Aborted job %s in state %s", progress, backupApi.ArangoBackupStateUploading),
+		fmt.Sprintf("Removed backup %s from deployment %s", createResponse.ID, deployment.Name),
+		fmt.Sprintf("Removed Finalizer: %s", backupApi.FinalizerArangoBackup),
+	}, messages)
+}
+
+func Test_Finalizer_RemoveObject_RunningUpload_AbortFailed(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{
+		abortError: fmt.Errorf("abort error"),
+	})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUploading)
+	obj.Finalizers = []string{
+		backupApi.FinalizerArangoBackup,
+	}
+
+	time := meta.Now()
+	obj.DeletionTimestamp = &time
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	progress, err := mock.Upload(context.Background(), createResponse.ID, backupApi.ArangoBackupSpecOperation{})
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(createResponse.BackupMeta, nil)
+	obj.Status.Progress = &backupApi.ArangoBackupProgress{
+		JobID: string(progress),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.EqualError(t, handler.Handle(newItemFromBackup(operation.Delete, obj)), "abort error")
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Len(t, newObj.Finalizers, 1)
+
+	exists, err := mock.Exists(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
@@ -23,7 +23,9 @@
 package backup
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -32,7 +34,6 @@ import (
 
 	"github.com/arangodb/go-driver"
 	"github.com/arangodb/kube-arangodb/pkg/backup/utils"
-	"k8s.io/apimachinery/pkg/util/uuid"
 
 	"github.com/arangodb/kube-arangodb/pkg/backup/operator"
 
@@ -40,12 +41,14 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
 
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/workqueue"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/rs/zerolog/log"
 
 	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	deploymentType "github.com/arangodb/kube-arangodb/pkg/apis/deployment"
 	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	arangoClientSet "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -53,19 +56,40 @@ import (
 
 const (
 	defaultArangoClientTimeout = 30 * time.Second
-	retryCount                 = 25
-	retryDelay                 = time.Second
+	retryInitialDelay          = 100 * time.Millisecond
+	retryMaxDelay              = 5 * time.Second
+	retryTimeout               = 25 * time.Second
+	finalizeRetryCount         = 3
+	finalizeRetryDelay         = 100 * time.Millisecond
 
 	// StateChange name of the event send when state changed
 	StateChange = "StateChange"
 
 	// FinalizerChange name of the event send when finalizer removed entry
 	FinalizerChange = "FinalizerChange"
+
+	// SpecChangeRejected name of the event send when change of the spec was rejected
+	SpecChangeRejected = "SpecChangeRejected"
+
+	// ImportedBackup name of the event send when backup found on the server was imported
+	ImportedBackup = "ImportedBackup"
+
+	// AdoptedBackup name of the event send when existing ArangoBackup was matched with backup found on the server
+	AdoptedBackup = "AdoptedBackup"
+
+	// EncryptionKeyChanged name of the event send when encryption key secret changed after backup creation
+	EncryptionKeyChanged = "EncryptionKeyChanged"
+
+	// BackupSizeExceeded name of the event send when backup exceeded size limit defined in the spec
+	BackupSizeExceeded = "BackupSizeExceeded"
 )
 
 type handler struct {
-	lock  sync.Mutex
-	locks map[string]*sync.Mutex
+	lock       sync.Mutex
+	semaphores map[string]*deploymentSemaphore
+
+	// globalSlots limits number of the backups handled in parallel by the operator
+	globalSlots chan struct{}
 
 	client     arangoClientSet.Interface
 	kubeClient kubernetes.Interface
@@ -75,15 +99,52 @@ type handler struct {
 	arangoClientFactory ArangoClientFactory
 	arangoClientTimeout time.Duration
 
-	operator operator.Operator
+	config  Config
+	metrics *prometheusMetrics
+
+	clockSkew map[string]time.Duration
+
+	refreshLock    sync.Mutex
+	refreshStarted time.Time
+	lastRefresh    time.Time
+
+	// refreshRunLock serializes periodic and manually triggered refreshes
+	refreshRunLock sync.Mutex
+
+	operator       operator.Operator
+	requeueLimiter workqueue.RateLimiter
+
+	// ctx is cancelled when operator stops and in-flight operations are drained, remaining ArangoDB client calls are aborted
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// drainLock protects draining flag and number of in-flight Handle calls
+	drainLock     sync.Mutex
+	draining      bool
+	inFlightCount int
+	inFlight      sync.WaitGroup
 }
 
 func (h *handler) Start(stopCh <-chan struct{}) {
+	go func() {
+		<-stopCh
+		h.drain(h.config.DrainTimeout)
+		h.cancel()
+	}()
+
 	go h.start(stopCh)
+	go h.watchLocks(stopCh)
 }
 
 func (h *handler) start(stopCh <-chan struct{}) {
-	t := time.NewTicker(2 * time.Minute)
+	if h.config.RefreshInterval == 0 {
+		log.Info().Msgf("Periodic refresh of database objects is disabled")
+		return
+	}
+
+	h.markRefreshStarted(time.Now())
+
+	t := time.NewTimer(refreshDelay(h.config.RefreshInterval, h.config.RefreshJitter))
 	defer t.Stop()
 
 	for {
@@ -91,81 +152,167 @@ func (h *handler) start(stopCh <-chan struct{}) {
 		case <-stopCh:
 			return
 		case <-t.C:
+			if !h.isLeader() {
+				log.Debug().Msgf("Operator is not the leader, skipping refresh of database objects")
+				t.Reset(refreshDelay(h.config.RefreshInterval, h.config.RefreshJitter))
+				continue
+			}
+
 			log.Debug().Msgf("Refreshing database objects")
-			if err := h.refresh(); err != nil {
+			if _, err := h.refresh(); err != nil {
 				log.Error().Err(err).Msgf("Unable to refresh database objects")
+			} else {
+				h.markRefreshed(time.Now())
 			}
 			log.Debug().Msgf("Database objects refreshed")
+
+			t.Reset(refreshDelay(h.config.RefreshInterval, h.config.RefreshJitter))
 		}
 	}
 }
 
-func (h *handler) refresh() error {
-	deployments, err := h.client.DatabaseV1().ArangoDeployments(h.operator.Namespace()).List(meta.ListOptions{})
-	if err != nil {
-		return err
+// refreshRand is seeded separately in every operator, so replicas get different delays. Used only by the refresh loop.
+var refreshRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+// refreshDelay returns interval randomized by +/- jitter fraction, so refreshes of many operators do not align
+func refreshDelay(interval time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return interval
 	}
 
-	for _, deployment := range deployments.Items {
-		if err = h.refreshDeployment(&deployment); err != nil {
+	return interval + time.Duration((refreshRand.Float64()*2-1)*jitter*float64(interval))
+}
+
+func (h *handler) refresh() (RefreshResult, error) {
+	h.refreshRunLock.Lock()
+	defer h.refreshRunLock.Unlock()
+
+	var result RefreshResult
+
+	health := newBackupHealthCollector(h.now, h.config.HealthFreshness)
+
+	err := h.forEachArangoDeployment(h.watchedNamespace(), meta.ListOptions{
+		LabelSelector: h.config.DeploymentSelector,
+	}, func() {
+		// Deployments are listed again, refresh is idempotent so only aggregated values are dropped
+		result = RefreshResult{}
+		health = newBackupHealthCollector(h.now, h.config.HealthFreshness)
+	}, func(deployment *database.ArangoDeployment) error {
+		if err := h.collectDeploymentHealth(health, deployment); err != nil {
 			return err
 		}
+
+		r, err := h.refreshDeployment(deployment)
+		result.add(r)
+		return err
+	})
+	if err != nil {
+		return result, err
 	}
 
-	return nil
+	h.metrics.set(health.health())
+
+	return result, nil
+}
+
+// watchedNamespace returns namespace of the deployments handled by the operator, empty namespace means all namespaces
+func (h *handler) watchedNamespace() string {
+	if h.config.AllNamespaces {
+		return meta.NamespaceAll
+	}
+
+	return h.operator.Namespace()
 }
 
-func (h *handler) refreshDeployment(deployment *database.ArangoDeployment) error {
-	m := h.getDeploymentMutex(deployment.Namespace, deployment.Name)
-	m.Lock()
-	defer m.Unlock()
+func (h *handler) refreshDeployment(deployment *database.ArangoDeployment) (RefreshResult, error) {
+	var result RefreshResult
+
+	// Backups should not be imported or created while cluster is in maintenance
+	if deployment.Spec.Database.GetMaintenance() {
+		log.Debug().
+			Str("namespace", deployment.Namespace).
+			Str("deployment", deployment.Name).
+			Msgf("Skipping backup refresh of %s/%s, deployment is in maintenance mode", deployment.Namespace, deployment.Name)
+		return result, nil
+	}
+
+	// Import and retention need to see all backups of the deployment, no other operation can run in the same time
+	s := h.getDeploymentSemaphore(deployment.Namespace, deployment.Name)
+	s.AcquireAll()
+	defer s.ReleaseAll()
 
 	client, err := h.arangoClientFactory(deployment, nil)
 	if err != nil {
-		return err
+		return result, err
+	}
+
+	if _, _, err := h.checkClockSkew(deployment, client); err != nil {
+		log.Warn().Err(err).
+			Str("namespace", deployment.Namespace).
+			Str("deployment", deployment.Name).
+			Msgf("Unable to check clock skew of %s/%s", deployment.Namespace, deployment.Name)
 	}
 
-	backups, err := h.client.BackupV1().ArangoBackups(deployment.Namespace).List(meta.ListOptions{})
+	backups, references, err := h.listDeploymentBackups(deployment)
 	if err != nil {
-		return err
+		return result, err
 	}
 
-	existingBackups, err := client.List()
+	h.checkEncryptionSecretVersion(deployment, backups)
+
+	existingBackups, err := client.List(h.ctx)
 	if err != nil {
-		return err
+		return result, err
 	}
 
 	for _, backupMeta := range existingBackups {
-		if err = h.refreshDeploymentBackup(deployment, backupMeta, backups.Items); err != nil {
-			return err
+		imported, err := h.refreshDeploymentBackup(deployment, client, backupMeta, backups, references)
+		if imported {
+			result.Imported++
+		}
+		if err != nil {
+			return result, err
 		}
 	}
 
-	return nil
+	result.Pruned, err = h.pruneDeploymentBackups(deployment, backups)
+	if err != nil {
+		return result, err
+	}
+
+	deleted, err := h.deleteExpiredFailedBackups(deployment, backups)
+	result.Pruned += deleted
+	return result, err
 }
 
-func (h *handler) refreshDeploymentBackup(deployment *database.ArangoDeployment, backupMeta driver.BackupMeta, backups []backupApi.ArangoBackup) error {
-	for _, backup := range backups {
-		if download := backup.Spec.Download; download != nil {
-			if download.ID == string(backupMeta.ID) {
-				return nil
-			}
-		}
+func (h *handler) refreshDeploymentBackup(deployment *database.ArangoDeployment, client ArangoBackupClient, backupMeta driver.BackupMeta, backups []backupApi.ArangoBackup, references backupReferences) (bool, error) {
+	if references.has(backupMeta) {
+		return false, nil
+	}
 
-		if backup.Status.Backup == nil {
-			continue
-		}
+	// Backup will be adopted by the existing ArangoBackup
+	if hasAdoptableArangoBackup(deployment, backupMeta, backups) {
+		return false, nil
+	}
 
-		if backup.Status.Backup.ID == string(backupMeta.ID) {
-			return nil
-		}
+	// Listed backups can be outdated, check if backup was not already created
+	if exists, err := h.backupWithIDExists(deployment.Namespace, string(backupMeta.ID)); err != nil {
+		return false, err
+	} else if exists {
+		return false, nil
+	}
+
+	created := backupMeta.DateTime
+	if created.IsZero() {
+		created = time.Now()
 	}
 
 	// New backup found, need to recreate
 	backup := &backupApi.ArangoBackup{
 		ObjectMeta: meta.ObjectMeta{
-			Name:      fmt.Sprintf("backup-%s", uuid.NewUUID()),
+			Name:      h.config.Import.Name(deployment.Name, created),
 			Namespace: deployment.Namespace,
+			Labels:    h.config.Import.RenderLabels(deployment.Name, created),
 		},
 		Spec: backupApi.ArangoBackupSpec{
 			Deployment: backupApi.ArangoBackupSpecDeployment{
@@ -173,25 +320,34 @@ func (h *handler) refreshDeploymentBackup(deployment *database.ArangoDeployment,
 			},
 		},
 	}
+	setBackupIDLabel(backup, string(backupMeta.ID))
 
-	_, err := h.client.BackupV1().ArangoBackups(backup.Namespace).Create(backup)
+	backup, err := h.client.BackupV1().ArangoBackups(backup.Namespace).Create(backup)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	status := updateStatus(backup,
 		updateStatusState(backupApi.ArangoBackupStateReady, ""),
 		updateStatusBackup(backupMeta),
+		updateStatusBackupServerVersion(h.serverVersion(client)),
 		updateStatusBackupImported(util.NewBool(true)))
 
 	backup.Status = *status
 
 	err = h.updateBackupStatus(backup)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	h.eventRecorder.Normal(backup, ImportedBackup, "Imported backup %s with version %s from deployment %s",
+		backupMeta.ID,
+		backupMeta.Version,
+		deployment.Name)
+
+	h.metrics.importedBackups.WithLabelValues(deployment.Namespace).Inc()
+
+	return true, nil
 }
 
 func (h *handler) Name() string {
@@ -199,7 +355,7 @@ func (h *handler) Name() string {
 }
 
 func (h *handler) updateBackupStatus(b *backupApi.ArangoBackup) error {
-	return utils.Retry(retryCount, retryDelay, func() error {
+	return utils.RetryBackoff(retryInitialDelay, retryMaxDelay, retryTimeout, func() error {
 		backup, err := h.client.BackupV1().ArangoBackups(b.Namespace).Get(b.Name, meta.GetOptions{})
 		if err != nil {
 			return err
@@ -212,28 +368,33 @@ func (h *handler) updateBackupStatus(b *backupApi.ArangoBackup) error {
 	})
 }
 
-func (h *handler) getDeploymentMutex(namespace, deployment string) *sync.Mutex {
-	h.lock.Lock()
-	defer h.lock.Unlock()
-
-	if h.locks == nil {
-		h.locks = map[string]*sync.Mutex{}
+func (h *handler) Handle(item operation.Item) error {
+	if h.skipNotLeader(item) {
+		return nil
 	}
 
-	name := fmt.Sprintf("%s/%s", namespace, deployment)
-
-	if _, ok := h.locks[name]; !ok {
-		h.locks[name] = &sync.Mutex{}
+	// Limit number of backups handled in same time by the operator
+	if !h.acquireGlobalSlot() {
+		return nil
 	}
+	defer h.releaseGlobalSlot()
+
+	// Do not start new work when operator is shutting down
+	if !h.beginOperation() {
+		log.Debug().
+			Str("kind", item.Kind).
+			Str("namespace", item.Namespace).
+			Str("name", item.Name).
+			Msgf("Operator is shutting down, skipping")
+		return nil
+	}
+	defer h.endOperation()
 
-	return h.locks[name]
-}
-
-func (h *handler) Handle(item operation.Item) error {
 	// Get Backup object. It also cover NotFound case
 	b, err := h.client.BackupV1().ArangoBackups(item.Namespace).Get(item.Name, meta.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
+			h.requeueLimiter.Forget(item.String())
 			return nil
 		}
 
@@ -242,21 +403,49 @@ func (h *handler) Handle(item operation.Item) error {
 
 	// Check if we should start finalizer
 	if b.DeletionTimestamp != nil {
-		log.Debug().Msgf("Finalizing %s %s/%s",
-			item.Kind,
-			item.Namespace,
-			item.Name)
+		log.Debug().
+			Str("kind", item.Kind).
+			Str("namespace", item.Namespace).
+			Str("name", item.Name).
+			Msgf("Finalizing %s %s/%s",
+				item.Kind,
+				item.Namespace,
+				item.Name)
 
 		return h.finalize(b)
 	}
 
+	// Paused backup is not changed until the annotation is removed
+	if b.IsPaused() || b.Status.Paused {
+		return h.handlePaused(item, b)
+	}
+
 	// Add finalizers
 	if !hasFinalizers(b) {
+		// Backup of missing deployment can never proceed, it is failed without finalizers to not block its deletion
+		if _, err := h.getArangoDeploymentObject(b); err != nil {
+			if _, ok := err.(temporaryError); ok {
+				return err
+			}
+
+			if b.Status.State == backupApi.ArangoBackupStateFailed ||
+				backupApi.ArangoBackupStateMap.Transit(b.Status.State, backupApi.ArangoBackupStateFailed) != nil {
+				return nil
+			}
+
+			status, _ := setFailedState(b, err)
+			return h.applyStatus(item, b, status)
+		}
+
 		b.Finalizers = appendFinalizers(b)
-		log.Info().Msgf("Updating finalizers %s %s/%s",
-			item.Kind,
-			item.Namespace,
-			item.Name)
+		log.Info().
+			Str("kind", item.Kind).
+			Str("namespace", item.Namespace).
+			Str("name", item.Name).
+			Msgf("Updating finalizers %s %s/%s",
+				item.Kind,
+				item.Namespace,
+				item.Name)
 
 		if _, err = h.client.BackupV1().ArangoBackups(item.Namespace).Update(b); err != nil {
 			return err
@@ -265,18 +454,23 @@ func (h *handler) Handle(item operation.Item) error {
 		return nil
 	}
 
-	// Create lock per namespace to ensure that we are not using 2 goroutines in same time
-	lock := h.getDeploymentMutex(b.Namespace, b.Spec.Deployment.Name)
-	lock.Lock()
-	defer lock.Unlock()
+	// Limit number of goroutines working on the same deployment in same time
+	s := h.getDeploymentSemaphore(b.Namespace, b.Spec.Deployment.Name)
+	if startsOperation(b) {
+		// Check for running operations and start of the new one need to be atomic,
+		// otherwise parallel handlers pass the check before any of them saves the new state
+		s.AcquireAll()
+		defer s.ReleaseAll()
+	} else {
+		s.Acquire()
+		defer s.Release()
+	}
 
 	// Add owner reference
-	if b.OwnerReferences == nil || len(b.OwnerReferences) == 0 {
+	if !h.config.OwnerReferenceDisabled && !hasDeploymentOwnerReference(b) {
 		deployment, err := h.client.DatabaseV1().ArangoDeployments(b.Namespace).Get(b.Spec.Deployment.Name, meta.GetOptions{})
 		if err == nil {
-			b.OwnerReferences = []meta.OwnerReference{
-				deployment.AsOwner(),
-			}
+			b.OwnerReferences = append(b.OwnerReferences, h.deploymentOwnerReference(deployment))
 
 			if _, err = h.client.BackupV1().ArangoBackups(item.Namespace).Update(b); err != nil {
 				return err
@@ -293,12 +487,37 @@ func (h *handler) Handle(item operation.Item) error {
 		}
 	}
 
+	// Add label with server backup ID
+	if b.Status.Backup != nil && setBackupIDLabel(b, b.Status.Backup.ID) {
+		if _, err = h.client.BackupV1().ArangoBackups(item.Namespace).Update(b); err != nil {
+			return err
+		}
+
+		b, err = h.client.BackupV1().ArangoBackups(item.Namespace).Get(item.Name, meta.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+
+			return err
+		}
+	}
+
 	status, err := h.processArangoBackup(b.DeepCopy())
 	if err != nil {
-		log.Warn().Err(err).Msgf("Fail for %s %s/%s",
-			item.Kind,
-			item.Namespace,
-			item.Name)
+		log.Warn().Err(err).
+			Str("kind", item.Kind).
+			Str("namespace", item.Namespace).
+			Str("name", item.Name).
+			Msgf("Fail for %s %s/%s",
+				item.Kind,
+				item.Namespace,
+				item.Name)
+
+		// Retry will not help with unknown state, object is handled again after next change
+		if isUnsupportedBackupStateError(err) {
+			return nil
+		}
 
 		cError := switchError(err)
 
@@ -309,21 +528,31 @@ func (h *handler) Handle(item operation.Item) error {
 		status, _ = setFailedState(b, cError)
 	}
 
+	return h.applyStatus(item, b, status)
+}
+
+// applyStatus moves backup to the new status, records the state transition and saves the status
+func (h *handler) applyStatus(item operation.Item, b *backupApi.ArangoBackup, status *backupApi.ArangoBackupStatus) error {
 	if status == nil {
 		return nil
 	}
 
 	// Nothing to update, objects are equal
 	if b.Status.Equal(status) {
+		// Status updates and resyncs are not handled, item is handled again after requeue delay
+		if h.operator != nil {
+			h.requeue(item, b.Status.State, status)
+		}
+
 		return nil
 	}
 
 	if h.operator != nil {
-		h.operator.EnqueueItem(item)
+		h.requeue(item, b.Status.State, status)
 	}
 
 	// Ensure that transit is possible
-	if err = backupApi.ArangoBackupStateMap.Transit(b.Status.State, status.State); err != nil {
+	if err := backupApi.ArangoBackupStateMap.Transit(b.Status.State, status.State); err != nil {
 		return err
 	}
 
@@ -346,33 +575,73 @@ func (h *handler) Handle(item operation.Item) error {
 					status.State)
 			}
 		}
+
+		status.History = status.History.Append(backupApi.ArangoBackupStateTransition{
+			From:    b.Status.State,
+			To:      status.State,
+			Time:    status.Time,
+			Message: status.Message,
+		}, h.config.StateHistoryLimit)
 	}
 
+	if h.config.TrimFinalStatus {
+		trimFinalStatus(status)
+	}
+
+	previousState := b.Status.State
 	b.Status = *status
 
-	log.Debug().Msgf("Updating %s %s/%s",
-		item.Kind,
-		item.Namespace,
-		item.Name)
+	log.Debug().
+		Str("kind", item.Kind).
+		Str("namespace", item.Namespace).
+		Str("name", item.Name).
+		Msgf("Updating %s %s/%s",
+			item.Kind,
+			item.Namespace,
+			item.Name)
 
 	// Update status on object
 	if err := h.updateBackupStatus(b); err != nil {
 		return err
 	}
 
+	if previousState != b.Status.State {
+		h.sendCallback(b)
+	}
+
 	return nil
 }
 
 func (h *handler) processArangoBackup(backup *backupApi.ArangoBackup) (*backupApi.ArangoBackupStatus, error) {
+	backup = h.applySpecDefaults(backup)
+
 	if err := backup.Validate(); err != nil {
 		return setFailedState(backup, err)
 	}
 
+	if !h.config.ImportedBackupsEditable {
+		if err := backup.ValidateImported(); err != nil {
+			message := fmt.Sprintf("Spec change rejected: %s", err.Error())
+
+			if backup.Status.Message != message {
+				h.eventRecorder.Warning(backup, SpecChangeRejected, "%s", message)
+			}
+
+			return wrapUpdateStatus(backup,
+				updateStatusState(backup.Status.State, message))
+		}
+	}
+
+	if status, err := h.checkStateTimeout(backup); status != nil || err != nil {
+		return status, err
+	}
+
 	if f, ok := stateHolders[backup.Status.State]; ok {
-		return f(h, backup)
+		status, err := f(h, backup)
+		return applyAvailablePolicy(backup, status), err
 	}
 
-	return nil, fmt.Errorf("state %s is not supported", backup.Status.State)
+	return nil, UnsupportedBackupStateError{State: backup.Status.State}
 }
 
 func (h *handler) CanBeHandled(item operation.Item) bool {
@@ -381,11 +650,41 @@ func (h *handler) CanBeHandled(item operation.Item) bool {
 		item.Kind == backup.ArangoBackupResourceKind
 }
 
+// deploymentOwnerReference returns owner reference of the deployment with flags set according to the configuration
+func (h *handler) deploymentOwnerReference(deployment *database.ArangoDeployment) meta.OwnerReference {
+	owner := deployment.AsOwner()
+
+	owner.Controller = util.NewBool(h.config.OwnerReferenceController)
+
+	if h.config.OwnerReferenceBlockOwnerDeletion {
+		owner.BlockOwnerDeletion = util.NewBool(true)
+	} else {
+		owner.BlockOwnerDeletion = nil
+	}
+
+	return owner
+}
+
+// hasDeploymentOwnerReference returns true if backup is already owned by the deployment, other owners (e.g. policy) are ignored
+func hasDeploymentOwnerReference(backup *backupApi.ArangoBackup) bool {
+	for _, owner := range backup.OwnerReferences {
+		if owner.Kind == deploymentType.ArangoDeploymentResourceKind {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (h *handler) getArangoDeploymentObject(backup *backupApi.ArangoBackup) (*database.ArangoDeployment, error) {
 	if backup.Spec.Deployment.Name == "" {
 		return nil, newFatalErrorf("deployment ref is not specified for backup %s/%s", backup.Namespace, backup.Name)
 	}
 
+	if err := backup.Spec.Deployment.ValidateNamespace(backup.Namespace); err != nil {
+		return nil, newFatalError(err)
+	}
+
 	obj, err := h.client.DatabaseV1().ArangoDeployments(backup.Namespace).Get(backup.Spec.Deployment.Name, meta.GetOptions{})
 	if err == nil {
 		return obj, nil
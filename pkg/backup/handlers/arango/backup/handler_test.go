@@ -23,9 +23,22 @@
 package backup
 
 import (
+	"context"
+	goErrors "errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator"
 	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
 
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -58,3 +71,485 @@ func Test_ObjectNotFound(t *testing.T) {
 		})
 	}
 }
+
+func Test_ImportedBackup_SpecChangeRejected(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{
+		ID:       "id",
+		Imported: util.NewBool(true),
+	}
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateReady, newObj.Status.State)
+	require.Equal(t, "Spec change rejected: upload can not be specified for imported backup", newObj.Status.Message)
+}
+
+func Test_ImportedBackup_DeploymentChangeRejected(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{
+		ID:       "id",
+		Imported: util.NewBool(true),
+	}
+	obj.OwnerReferences = []meta.OwnerReference{
+		deployment.AsOwner(),
+	}
+	obj.Spec.Deployment.Name = "other"
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateReady, newObj.Status.State)
+	require.Equal(t, fmt.Sprintf("Spec change rejected: deployment of imported backup can not be changed from %s to other", deployment.Name), newObj.Status.Message)
+}
+
+func Test_ImportedBackup_SpecChangeAllowed(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.ImportedBackupsEditable = true
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+	obj.Status.Backup.Imported = util.NewBool(true)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateUpload, newObj.Status.State)
+}
+
+func Test_OwnerReference_Flags(t *testing.T) {
+	cases := map[string]struct {
+		controller, blockOwnerDeletion bool
+	}{
+		"default":              {controller: true},
+		"no controller":        {},
+		"block owner deletion": {controller: true, blockOwnerDeletion: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+			handler.config.OwnerReferenceController = c.controller
+			handler.config.OwnerReferenceBlockOwnerDeletion = c.blockOwnerDeletion
+
+			obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+			// Act
+			createArangoDeployment(t, handler, deployment)
+			createArangoBackup(t, handler, obj)
+
+			require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+			// Assert
+			newObj := refreshArangoBackup(t, handler, obj)
+			require.Len(t, newObj.OwnerReferences, 1)
+
+			owner := newObj.OwnerReferences[0]
+			require.Equal(t, deployment.Name, owner.Name)
+			require.NotNil(t, owner.Controller)
+			require.Equal(t, c.controller, *owner.Controller)
+
+			if c.blockOwnerDeletion {
+				require.NotNil(t, owner.BlockOwnerDeletion)
+				require.True(t, *owner.BlockOwnerDeletion)
+			} else {
+				require.Nil(t, owner.BlockOwnerDeletion)
+			}
+		})
+	}
+}
+
+func Test_OwnerReference_Disabled(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.OwnerReferenceDisabled = true
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Len(t, newObj.OwnerReferences, 0)
+	require.Equal(t, backupApi.ArangoBackupStatePending, newObj.Status.State)
+}
+
+func Test_OwnerReference_PolicyOwner(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	policy := &backupApi.ArangoBackupPolicy{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      "policy",
+			Namespace: obj.Namespace,
+			UID:       uuid.NewUUID(),
+		},
+	}
+	obj.OwnerReferences = []meta.OwnerReference{
+		policy.AsOwner(),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Len(t, newObj.OwnerReferences, 2)
+	require.True(t, policy.IsOwnerOf(newObj))
+	require.Equal(t, deployment.Name, newObj.OwnerReferences[1].Name)
+}
+
+func Test_ImportedBackup_CreationTimestamp(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	created := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+
+	mock.state.backups["imported"] = driver.BackupMeta{
+		ID:       "imported",
+		Version:  "3.6.0",
+		DateTime: created,
+	}
+	mock.state.backups["imported-no-time"] = driver.BackupMeta{
+		ID:      "imported-no-time",
+		Version: "3.6.0",
+	}
+
+	createArangoDeployment(t, handler, deployment)
+
+	// Act
+	result, err := handler.refreshDeployment(deployment)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Imported)
+
+	// Assert
+	backups, err := handler.client.BackupV1().ArangoBackups(deployment.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, backups.Items, 2)
+
+	for _, backup := range backups.Items {
+		require.NotNil(t, backup.Status.Backup)
+		require.NotNil(t, backup.Status.Backup.Imported)
+		require.True(t, *backup.Status.Backup.Imported)
+		require.Nil(t, backup.Status.Backup.DurationSeconds)
+
+		switch backup.Status.Backup.ID {
+		case "imported":
+			require.True(t, created.Equal(backup.Status.Backup.CreationTimestamp.Time))
+		case "imported-no-time":
+			require.WithinDuration(t, time.Now(), backup.Status.Backup.CreationTimestamp.Time, time.Minute)
+		default:
+			require.Failf(t, "unexpected backup", "backup %s", backup.Status.Backup.ID)
+		}
+	}
+}
+
+func Test_DeploymentNamespace(t *testing.T) {
+	t.Run("Same namespace", func(t *testing.T) {
+		// Arrange
+		handler := newFakeHandler()
+
+		obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+		obj.Spec.Deployment.Namespace = obj.Namespace
+
+		// Act
+		createArangoDeployment(t, handler, deployment)
+		createArangoBackup(t, handler, obj)
+
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		// Assert
+		newObj := refreshArangoBackup(t, handler, obj)
+		require.Equal(t, backupApi.ArangoBackupStateScheduled, newObj.Status.State)
+	})
+
+	t.Run("Cross namespace", func(t *testing.T) {
+		// Arrange
+		handler := newFakeHandler()
+
+		obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+		obj.Spec.Deployment.Namespace = "other"
+
+		// Act
+		createArangoDeployment(t, handler, deployment)
+		createArangoBackup(t, handler, obj)
+
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		// Assert
+		newObj := refreshArangoBackup(t, handler, obj)
+		require.Equal(t, backupApi.ArangoBackupStateFailed, newObj.Status.State)
+		require.Equal(t, createStateMessage(backupApi.ArangoBackupStatePending, backupApi.ArangoBackupStateFailed,
+			fmt.Sprintf("deployment other/%s is not in backup namespace %s, cross-namespace references are not supported", deployment.Name, obj.Namespace)),
+			newObj.Status.Message)
+	})
+}
+
+func Test_ImportedBackup_Event(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	mock.state.backups["imported"] = driver.BackupMeta{
+		ID:      "imported",
+		Version: "3.6.0",
+	}
+
+	createArangoDeployment(t, handler, deployment)
+
+	// Act
+	_, err := handler.refreshDeployment(deployment)
+	require.NoError(t, err)
+
+	// Assert
+	backups, err := handler.client.BackupV1().ArangoBackups(deployment.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, backups.Items, 1)
+
+	events, err := handler.kubeClient.CoreV1().Events(deployment.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 1)
+
+	event := events.Items[0]
+	require.Equal(t, ImportedBackup, event.Reason)
+	require.Equal(t, core.EventTypeNormal, event.Type)
+	require.Equal(t, backups.Items[0].Name, event.InvolvedObject.Name)
+	require.Equal(t, fmt.Sprintf("Imported backup imported with version 3.6.0 from deployment %s", deployment.Name), event.Message)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(handler.metrics.importedBackups.WithLabelValues(deployment.Namespace)))
+
+	// Act
+	_, err = handler.refreshDeployment(deployment)
+	require.NoError(t, err)
+
+	// Assert
+	require.Equal(t, float64(1), testutil.ToFloat64(handler.metrics.importedBackups.WithLabelValues(deployment.Namespace)))
+}
+
+func Test_Refresh_AllNamespaces(t *testing.T) {
+	run := func(t *testing.T, allNamespaces bool) (int, int) {
+		// Arrange
+		handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+		handler.config.AllNamespaces = allNamespaces
+
+		_, local := newObjectSet(backupApi.ArangoBackupStateReady)
+		_, remote := newObjectSet(backupApi.ArangoBackupStateReady)
+
+		handler.operator = operator.NewOperator("mock", local.Namespace)
+
+		mock.state.backups["imported"] = driver.BackupMeta{
+			ID:      "imported",
+			Version: "3.6.0",
+		}
+
+		createArangoDeployment(t, handler, local, remote)
+
+		// Act
+		_, err := handler.refresh()
+		require.NoError(t, err)
+
+		// Assert
+		localBackups, err := handler.client.BackupV1().ArangoBackups(local.Namespace).List(meta.ListOptions{})
+		require.NoError(t, err)
+
+		remoteBackups, err := handler.client.BackupV1().ArangoBackups(remote.Namespace).List(meta.ListOptions{})
+		require.NoError(t, err)
+
+		return len(localBackups.Items), len(remoteBackups.Items)
+	}
+
+	t.Run("Operator namespace", func(t *testing.T) {
+		local, remote := run(t, false)
+		require.Equal(t, 1, local)
+		require.Equal(t, 0, remote)
+	})
+
+	t.Run("All namespaces", func(t *testing.T) {
+		local, remote := run(t, true)
+		require.Equal(t, 1, local)
+		require.Equal(t, 1, remote)
+	})
+}
+
+func Test_Refresh_Maintenance(t *testing.T) {
+	run := func(t *testing.T, maintenance bool) int {
+		// Arrange
+		handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+		_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+		deployment.Spec.Database = &database.DatabaseSpec{
+			Maintenance: util.NewBool(maintenance),
+		}
+
+		handler.operator = operator.NewOperator("mock", deployment.Namespace)
+
+		mock.state.backups["imported"] = driver.BackupMeta{
+			ID:      "imported",
+			Version: "3.6.0",
+		}
+
+		createArangoDeployment(t, handler, deployment)
+
+		// Act
+		_, err := handler.refresh()
+		require.NoError(t, err)
+
+		// Assert
+		backups, err := handler.client.BackupV1().ArangoBackups(deployment.Namespace).List(meta.ListOptions{})
+		require.NoError(t, err)
+
+		return len(backups.Items)
+	}
+
+	t.Run("Maintenance disabled", func(t *testing.T) {
+		require.Equal(t, 1, run(t, false))
+	})
+
+	t.Run("Maintenance enabled", func(t *testing.T) {
+		require.Equal(t, 0, run(t, true))
+	})
+}
+
+func Test_Refresh_DeploymentSelector(t *testing.T) {
+	run := func(t *testing.T, selector string) (int, int) {
+		// Arrange
+		handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+		handler.config.AllNamespaces = true
+		handler.config.DeploymentSelector = selector
+
+		_, matching := newObjectSet(backupApi.ArangoBackupStateReady)
+		matching.Labels = map[string]string{
+			"team": "a",
+		}
+		_, other := newObjectSet(backupApi.ArangoBackupStateReady)
+		other.Labels = map[string]string{
+			"team": "b",
+		}
+
+		mock.state.backups["imported"] = driver.BackupMeta{
+			ID:      "imported",
+			Version: "3.6.0",
+		}
+
+		createArangoDeployment(t, handler, matching, other)
+
+		// Act
+		_, err := handler.refresh()
+		require.NoError(t, err)
+
+		// Assert
+		matchingBackups, err := handler.client.BackupV1().ArangoBackups(matching.Namespace).List(meta.ListOptions{})
+		require.NoError(t, err)
+
+		otherBackups, err := handler.client.BackupV1().ArangoBackups(other.Namespace).List(meta.ListOptions{})
+		require.NoError(t, err)
+
+		return len(matchingBackups.Items), len(otherBackups.Items)
+	}
+
+	t.Run("Without selector", func(t *testing.T) {
+		matching, other := run(t, "")
+		require.Equal(t, 1, matching)
+		require.Equal(t, 1, other)
+	})
+
+	t.Run("With selector", func(t *testing.T) {
+		matching, other := run(t, "team=a")
+		require.Equal(t, 1, matching)
+		require.Equal(t, 0, other)
+	})
+}
+
+func Test_UnsupportedState(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	// State known to the state map, but without handler
+	holder := stateHolders[backupApi.ArangoBackupStateRejected]
+	delete(stateHolders, backupApi.ArangoBackupStateRejected)
+	defer func() {
+		stateHolders[backupApi.ArangoBackupStateRejected] = holder
+	}()
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateRejected)
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	// Act
+	_, err := handler.processArangoBackup(obj)
+
+	// Assert
+	require.EqualError(t, err, "state Rejected is not supported")
+	require.True(t, goErrors.Is(err, ErrUnsupportedBackupState))
+	require.False(t, goErrors.Is(fmt.Errorf("state Rejected is not supported"), ErrUnsupportedBackupState))
+
+	var stateErr UnsupportedBackupStateError
+	require.True(t, goErrors.As(err, &stateErr))
+	require.Equal(t, backupApi.ArangoBackupStateRejected, stateErr.State)
+
+	// Handle does not fail and does not change the object
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, obj.Status, newObj.Status)
+}
+
+func Test_RefreshDelay(t *testing.T) {
+	require.Equal(t, time.Minute, refreshDelay(time.Minute, 0))
+
+	for i := 0; i < 100; i++ {
+		d := refreshDelay(time.Minute, 0.2)
+		require.True(t, d >= 48*time.Second, d)
+		require.True(t, d <= 72*time.Second, d)
+	}
+}
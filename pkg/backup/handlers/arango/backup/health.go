@@ -0,0 +1,237 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ prometheus.Collector = &handler{}
+
+type backupHealth struct {
+	// FreshDeployments is the fraction of the deployments with the fresh Ready backup
+	FreshDeployments float64
+
+	// FailedBackups is the number of the backups in Failed state
+	FailedBackups int
+}
+
+type prometheusMetrics struct {
+	freshDeployments prometheus.Gauge
+	failedBackups    prometheus.Gauge
+	clockSkew        *prometheus.GaugeVec
+	lastRefresh      prometheus.Gauge
+	refreshStale     prometheus.Gauge
+	importedBackups  *prometheus.CounterVec
+}
+
+func newPrometheusMetrics() *prometheusMetrics {
+	return &prometheusMetrics{
+		freshDeployments: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "arango_operator_backup_health_fresh_deployments_ratio",
+			Help: "Fraction of the deployments with the fresh Ready backup",
+		}),
+		failedBackups: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "arango_operator_backup_health_failed_backups",
+			Help: "Number of backups in Failed state",
+		}),
+		clockSkew: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "arango_operator_backup_clock_skew_seconds",
+			Help: "Difference between operator and database server clock",
+		}, []string{"namespace", "deployment"}),
+		lastRefresh: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "arango_operator_backup_last_successful_refresh_timestamp_seconds",
+			Help: "Unix time of the last successful refresh of the database objects",
+		}),
+		refreshStale: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "arango_operator_backup_refresh_stale",
+			Help: "Set to 1 if there was no successful refresh of the database objects within the last refresh intervals",
+		}),
+		importedBackups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "arango_operator_backup_imported_total",
+			Help: "Number of ArangoBackups created for the backups found on the database servers",
+		}, []string{"namespace"}),
+	}
+}
+
+func (p *prometheusMetrics) connectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		p.freshDeployments,
+		p.failedBackups,
+		p.clockSkew,
+		p.lastRefresh,
+		p.refreshStale,
+		p.importedBackups,
+	}
+}
+
+func (p *prometheusMetrics) set(health backupHealth) {
+	p.freshDeployments.Set(health.FreshDeployments)
+	p.failedBackups.Set(float64(health.FailedBackups))
+}
+
+func (h *handler) Describe(r chan<- *prometheus.Desc) {
+	for _, c := range h.metrics.connectors() {
+		c.Describe(r)
+	}
+}
+
+func (h *handler) Collect(r chan<- prometheus.Metric) {
+	if h.IsRefreshStale(time.Now()) {
+		h.metrics.refreshStale.Set(1)
+	} else {
+		h.metrics.refreshStale.Set(0)
+	}
+
+	for _, c := range h.metrics.connectors() {
+		c.Collect(r)
+	}
+}
+
+// backupHealthCollector aggregates health of the backups while deployments and backups are listed page by page
+type backupHealthCollector struct {
+	now       clock
+	freshness time.Duration
+
+	deployments int
+	fresh       int
+	failed      int
+
+	namespaces map[string]bool
+}
+
+func newBackupHealthCollector(now clock, freshness time.Duration) *backupHealthCollector {
+	return &backupHealthCollector{
+		now:        now,
+		freshness:  freshness,
+		namespaces: map[string]bool{},
+	}
+}
+
+// isFresh returns true if backup is the fresh Ready backup of the deployment
+func (c *backupHealthCollector) isFresh(deployment *database.ArangoDeployment, backup *backupApi.ArangoBackup) bool {
+	return backup.Spec.Deployment.Name == deployment.Name && isFreshBackup(backup, c.now(deployment), c.freshness)
+}
+
+func (c *backupHealthCollector) addDeployment(fresh bool) {
+	c.deployments++
+	if fresh {
+		c.fresh++
+	}
+}
+
+func (c *backupHealthCollector) addFailed(backup *backupApi.ArangoBackup) {
+	if backup.Status.State == backupApi.ArangoBackupStateFailed {
+		c.failed++
+	}
+}
+
+func (c *backupHealthCollector) health() backupHealth {
+	health := backupHealth{
+		FailedBackups: c.failed,
+	}
+
+	if c.deployments > 0 {
+		health.FreshDeployments = float64(c.fresh) / float64(c.deployments)
+	}
+
+	return health
+}
+
+// collectDeploymentHealth adds deployment to the collector. Failed backups are counted once per namespace.
+func (h *handler) collectDeploymentHealth(c *backupHealthCollector, deployment *database.ArangoDeployment) error {
+	countFailed := !c.namespaces[deployment.Namespace]
+
+	fresh := false
+	failed := 0
+
+	err := h.forEachArangoBackup(deployment.Namespace, meta.ListOptions{}, func() {
+		fresh = false
+		failed = 0
+	}, func(backup *backupApi.ArangoBackup) error {
+		if c.isFresh(deployment, backup) {
+			fresh = true
+		}
+
+		if countFailed && backup.Status.State == backupApi.ArangoBackupStateFailed {
+			failed++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.namespaces[deployment.Namespace] = true
+	c.failed += failed
+	c.addDeployment(fresh)
+
+	return nil
+}
+
+// computeBackupHealth calculates aggregated health of the backups. Backups are grouped by namespace.
+func computeBackupHealth(deployments []database.ArangoDeployment, backups map[string][]backupApi.ArangoBackup, now clock, freshness time.Duration) backupHealth {
+	c := newBackupHealthCollector(now, freshness)
+
+	for _, list := range backups {
+		for id := range list {
+			c.addFailed(&list[id])
+		}
+	}
+
+	for id := range deployments {
+		deployment := &deployments[id]
+
+		list := backups[deployment.Namespace]
+
+		fresh := false
+		for backup := range list {
+			if c.isFresh(deployment, &list[backup]) {
+				fresh = true
+				break
+			}
+		}
+
+		c.addDeployment(fresh)
+	}
+
+	return c.health()
+}
+
+func isFreshBackup(backup *backupApi.ArangoBackup, now time.Time, freshness time.Duration) bool {
+	if backup.Status.State != backupApi.ArangoBackupStateReady {
+		return false
+	}
+
+	if backup.Status.Backup == nil {
+		return false
+	}
+
+	return now.Sub(backup.Status.Backup.CreationTimestamp.Time) <= freshness
+}
@@ -0,0 +1,109 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+func newHealthBackup(deployment *database.ArangoDeployment, state backupApi.ArangoBackupState, created time.Time) backupApi.ArangoBackup {
+	obj := newArangoBackup(deployment.Name, deployment.Namespace, string(uuid.NewUUID()), state.State)
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{
+		CreationTimestamp: meta.Time{Time: created},
+	}
+
+	return *obj
+}
+
+func fixedClock(now time.Time) clock {
+	return func(*database.ArangoDeployment) time.Time {
+		return now
+	}
+}
+
+func Test_Health_Compute(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	freshness := time.Hour
+
+	fresh := newArangoDeployment("test", "fresh")
+	stale := newArangoDeployment("test", "stale")
+	failed := newArangoDeployment("test", "failed")
+	empty := newArangoDeployment("test", "empty")
+
+	ready := backupApi.ArangoBackupState{State: backupApi.ArangoBackupStateReady}
+	failure := backupApi.ArangoBackupState{State: backupApi.ArangoBackupStateFailed}
+
+	backups := map[string][]backupApi.ArangoBackup{
+		"test": {
+			newHealthBackup(fresh, ready, now.Add(-time.Minute)),
+			newHealthBackup(stale, ready, now.Add(-2*time.Hour)),
+			newHealthBackup(failed, failure, now.Add(-time.Minute)),
+			newHealthBackup(failed, failure, now.Add(-time.Minute)),
+		},
+	}
+
+	// Act
+	health := computeBackupHealth([]database.ArangoDeployment{*fresh, *stale, *failed, *empty}, backups, fixedClock(now), freshness)
+
+	// Assert
+	require.Equal(t, 0.25, health.FreshDeployments)
+	require.Equal(t, 2, health.FailedBackups)
+}
+
+func Test_Health_Compute_NoDeployments(t *testing.T) {
+	// Act
+	health := computeBackupHealth(nil, nil, fixedClock(time.Now()), time.Hour)
+
+	// Assert
+	require.Equal(t, float64(0), health.FreshDeployments)
+	require.Equal(t, 0, health.FailedBackups)
+}
+
+func Test_Health_Refresh(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	deployment := newArangoDeployment("test", "deployment")
+	obj := newHealthBackup(deployment, backupApi.ArangoBackupState{State: backupApi.ArangoBackupStateReady}, time.Now())
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, &obj)
+
+	// Act
+	c := newBackupHealthCollector(handler.now, handler.config.HealthFreshness)
+	require.NoError(t, handler.collectDeploymentHealth(c, deployment))
+	handler.metrics.set(c.health())
+
+	// Assert
+	require.Equal(t, float64(1), testutil.ToFloat64(handler.metrics.freshDeployments))
+	require.Equal(t, float64(0), testutil.ToFloat64(handler.metrics.failedBackups))
+}
@@ -0,0 +1,127 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/rs/zerolog/log"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	defaultImportNamePrefix = "backup"
+
+	// importPlaceholderDeployment is replaced with the deployment name in the import templates
+	importPlaceholderDeployment = "{deployment}"
+	// importPlaceholderDate is replaced with the backup creation date (YYYYMMDD) in the import templates
+	importPlaceholderDate = "{date}"
+
+	importDateFormat = "20060102"
+)
+
+// ImportTemplate defines names and labels of the ArangoBackups created for backups imported from the deployment.
+// Templates support {deployment} and {date} placeholders.
+type ImportTemplate struct {
+	// NamePrefix is the prefix of the imported backup name, UUID suffix is always appended to keep names unique
+	NamePrefix string
+
+	// Labels are added to the imported backups, values are templates
+	Labels map[string]string
+}
+
+// Validate validates the templates rendered with example values
+func (i ImportTemplate) Validate() error {
+	example := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := k8sutil.ValidateResourceName(i.name("deployment", example)); err != nil {
+		return fmt.Errorf("import name prefix is invalid: %s", err.Error())
+	}
+
+	for key, value := range i.Labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("import label key %s is invalid: %s", key, strings.Join(errs, ", "))
+		}
+
+		if errs := validation.IsValidLabelValue(renderImportTemplate(value, "deployment", example)); len(errs) > 0 {
+			return fmt.Errorf("import label %s value is invalid: %s", key, strings.Join(errs, ", "))
+		}
+	}
+
+	return nil
+}
+
+func (i ImportTemplate) name(deployment string, created time.Time) string {
+	prefix := i.NamePrefix
+	if prefix == "" {
+		prefix = defaultImportNamePrefix
+	}
+
+	return fmt.Sprintf("%s-%s", renderImportTemplate(prefix, deployment, created), uuid.NewUUID())
+}
+
+// Name returns name of the imported backup. Default prefix is used if the rendered name is not a valid resource name.
+func (i ImportTemplate) Name(deployment string, created time.Time) string {
+	name := i.name(deployment, created)
+
+	if err := k8sutil.ValidateResourceName(name); err != nil {
+		log.Warn().Err(err).Msgf("Imported backup name of deployment %s is invalid, using default prefix", deployment)
+
+		return fmt.Sprintf("%s-%s", defaultImportNamePrefix, uuid.NewUUID())
+	}
+
+	return name
+}
+
+// RenderLabels returns labels of the imported backup. Labels with invalid rendered value are skipped.
+func (i ImportTemplate) RenderLabels(deployment string, created time.Time) map[string]string {
+	if len(i.Labels) == 0 {
+		return nil
+	}
+
+	labels := make(map[string]string, len(i.Labels))
+
+	for key, template := range i.Labels {
+		value := renderImportTemplate(template, deployment, created)
+
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			log.Warn().Msgf("Skipping label %s of imported backup of deployment %s: %s", key, deployment, strings.Join(errs, ", "))
+			continue
+		}
+
+		labels[key] = value
+	}
+
+	return labels
+}
+
+func renderImportTemplate(template, deployment string, created time.Time) string {
+	return strings.NewReplacer(
+		importPlaceholderDeployment, deployment,
+		importPlaceholderDate, created.UTC().Format(importDateFormat),
+	).Replace(template)
+}
@@ -0,0 +1,112 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ImportTemplate_Validate(t *testing.T) {
+	require.NoError(t, ImportTemplate{}.Validate())
+	require.NoError(t, ImportTemplate{NamePrefix: "imported-{deployment}-{date}"}.Validate())
+	require.NoError(t, ImportTemplate{Labels: map[string]string{"example.com/source": "{deployment}"}}.Validate())
+
+	err := ImportTemplate{NamePrefix: "Imported"}.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "import name prefix is invalid")
+	require.Error(t, ImportTemplate{Labels: map[string]string{"in valid": "a"}}.Validate())
+	require.Error(t, ImportTemplate{Labels: map[string]string{"valid": "in valid"}}.Validate())
+}
+
+func Test_ImportTemplate_Name(t *testing.T) {
+	created := time.Date(2020, 6, 15, 23, 30, 0, 0, time.UTC)
+
+	name := ImportTemplate{NamePrefix: "imported-{deployment}-{date}"}.Name("cluster", created)
+	require.True(t, strings.HasPrefix(name, "imported-cluster-20200615-"), name)
+
+	// UUID suffix keeps names unique
+	require.NotEqual(t, name, ImportTemplate{NamePrefix: "imported-{deployment}-{date}"}.Name("cluster", created))
+
+	// Invalid rendered name falls back to default prefix
+	name = ImportTemplate{NamePrefix: "{deployment}"}.Name("Cluster", created)
+	require.True(t, strings.HasPrefix(name, "backup-"), name)
+}
+
+func Test_ImportTemplate_RenderLabels(t *testing.T) {
+	created := time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	labels := ImportTemplate{Labels: map[string]string{
+		"source":  "{deployment}",
+		"date":    "{date}",
+		"invalid": "{deployment} {date}",
+	}}.RenderLabels("cluster", created)
+
+	require.Equal(t, map[string]string{
+		"source": "cluster",
+		"date":   "20200615",
+	}, labels)
+
+	require.Nil(t, ImportTemplate{}.RenderLabels("cluster", created))
+}
+
+func Test_Refresh_ImportTemplate(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.Import = ImportTemplate{
+		NamePrefix: "imported-{date}",
+		Labels: map[string]string{
+			"example.com/deployment": "{deployment}",
+		},
+	}
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	mock.state.backups["imported"] = driver.BackupMeta{
+		ID:       "imported",
+		Version:  "3.6.0",
+		DateTime: time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	createArangoDeployment(t, handler, deployment)
+
+	// Act
+	_, err := handler.refreshDeployment(deployment)
+	require.NoError(t, err)
+
+	// Assert
+	backups, err := handler.client.BackupV1().ArangoBackups(deployment.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, backups.Items, 1)
+
+	backup := backups.Items[0]
+	require.True(t, strings.HasPrefix(backup.Name, "imported-20200615-"), backup.Name)
+	require.Equal(t, deployment.Name, backup.Labels["example.com/deployment"])
+	require.Equal(t, "imported", backup.Labels[backupApi.LabelArangoBackupID])
+}
@@ -0,0 +1,89 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+)
+
+const (
+	// maxJobErrorMessageLength limits length of job error messages stored in status
+	maxJobErrorMessageLength = 1024
+)
+
+// jobErrorMessage returns message containing errors of all failed servers
+func jobErrorMessage(errors []backupApi.ArangoBackupJobServerError) string {
+	messages := make([]string, len(errors))
+
+	for id, e := range errors {
+		messages[id] = fmt.Sprintf("%s: %s (code %d)", e.Server, e.Message, e.Code)
+	}
+
+	return truncateMessage(strings.Join(messages, ", "), maxJobErrorMessageLength)
+}
+
+// truncateMessage cuts message to the given length without breaking multi-byte characters
+func truncateMessage(message string, length int) string {
+	const suffix = "..."
+
+	if len(message) <= length {
+		return message
+	}
+
+	runes := []rune(message)
+	size := 0
+
+	for id, r := range runes {
+		size += len(string(r))
+		if size > length-len(suffix) {
+			return string(runes[:id]) + suffix
+		}
+	}
+
+	return message
+}
+
+func updateStatusJobError(jobID string, details ArangoBackupProgress) updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		servers := make([]backupApi.ArangoBackupJobServerError, len(details.Errors))
+
+		for id, e := range details.Errors {
+			servers[id] = e
+			servers[id].Message = truncateMessage(e.Message, maxJobErrorMessageLength)
+		}
+
+		status.JobError = &backupApi.ArangoBackupJobError{
+			JobID:   jobID,
+			Servers: servers,
+		}
+	}
+}
+
+func cleanStatusJobError() updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		status.JobError = nil
+	}
+}
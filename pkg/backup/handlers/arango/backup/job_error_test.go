@@ -0,0 +1,137 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_JobError_Upload(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUploading)
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	progress, err := mock.Upload(context.Background(), backupMeta.ID, backupApi.ArangoBackupSpecOperation{})
+	require.NoError(t, err)
+
+	errors := []backupApi.ArangoBackupJobServerError{
+		{
+			Server:  "PRMR-1",
+			Code:    1410,
+			Message: "failed to upload file: access denied",
+		},
+		{
+			Server:  "PRMR-2",
+			Code:    1410,
+			Message: "failed to upload file: bucket does not exist",
+		},
+	}
+
+	mock.state.progresses[progress] = ArangoBackupProgress{
+		Failed:      true,
+		FailMessage: jobErrorMessage(errors),
+		Errors:      errors,
+	}
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+
+	obj.Status.Progress = &backupApi.ArangoBackupProgress{
+		JobID: string(progress),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateUploadError, true)
+
+	require.Equal(t, "Upload failed with error: PRMR-1: failed to upload file: access denied (code 1410), "+
+		"PRMR-2: failed to upload file: bucket does not exist (code 1410)", newObj.Status.Message)
+
+	require.NotNil(t, newObj.Status.JobError)
+	require.Equal(t, string(progress), newObj.Status.JobError.JobID)
+	require.Equal(t, errors, newObj.Status.JobError.Servers)
+}
+
+func Test_JobError_CleanedOnNewJob(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+	obj.Status.JobError = &backupApi.ArangoBackupJobError{
+		JobID: "old",
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateUploading, true)
+	require.Nil(t, newObj.Status.JobError)
+}
+
+func Test_JobError_Truncate(t *testing.T) {
+	require.Equal(t, "short", truncateMessage("short", 10))
+	require.Equal(t, "0123456...", truncateMessage("0123456789ABC", 10))
+
+	// Multi-byte characters are not broken
+	message := truncateMessage(strings.Repeat("ä", 10), 10)
+	require.Equal(t, "äää...", message)
+	require.True(t, len(message) <= 10)
+
+	long := fmt.Sprintf("error: %s", strings.Repeat("x", 2*maxJobErrorMessageLength))
+	require.Len(t, jobErrorMessage([]backupApi.ArangoBackupJobServerError{{Server: "PRMR-1", Message: long}}), maxJobErrorMessageLength)
+}
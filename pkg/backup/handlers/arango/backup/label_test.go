@@ -0,0 +1,116 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"strings"
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Label_Validation(t *testing.T) {
+	cases := map[string]struct {
+		label string
+		err   string
+	}{
+		"empty":      {},
+		"valid":      {label: "nightly-2020.06_1"},
+		"max length": {label: strings.Repeat("a", backupApi.ArangoBackupLabelMaxLength)},
+		"too long": {
+			label: strings.Repeat("a", backupApi.ArangoBackupLabelMaxLength+1),
+			err:   "label can not be longer than 64 characters",
+		},
+		"slash": {
+			label: "nightly/1",
+			err:   "label nightly/1 can contain only letters, digits, '.', '_' and '-'",
+		},
+		"space": {
+			label: "nightly 1",
+			err:   "label nightly 1 can contain only letters, digits, '.', '_' and '-'",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			obj, _ := newObjectSet(backupApi.ArangoBackupStateCreate)
+			obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+				Label: c.label,
+			}
+
+			// Act
+			err := obj.Spec.Validate()
+
+			// Assert
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.err)
+			}
+		})
+	}
+}
+
+func Test_Label_CreateOptions(t *testing.T) {
+	// Arrange
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+		Label: "nightly",
+	}
+
+	// Act
+	co := backupCreateOptions(obj)
+
+	// Assert
+	require.Equal(t, "nightly", co.Label)
+}
+
+func Test_Label_StoredInStatus(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+		Label: "nightly",
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.Equal(t, "nightly", newObj.Status.Backup.Label)
+	require.True(t, strings.HasSuffix(newObj.Status.Backup.ID, "_nightly"))
+
+	backups := mock.getIDs()
+	require.Len(t, backups, 1)
+	require.Equal(t, newObj.Status.Backup.ID, backups[0])
+}
@@ -0,0 +1,71 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/rs/zerolog/log"
+)
+
+// leadershipRecheckDelay defines after which time backup skipped by non leader operator is handled again
+const leadershipRecheckDelay = 5 * time.Second
+
+// LeadershipGate reports if the operator is currently the leader.
+// Backups are handled and database objects refreshed only while the operator is the leader
+type LeadershipGate interface {
+	IsLeader() bool
+}
+
+// isLeader returns true if operator is the leader, operator without configured gate is always the leader
+func (h *handler) isLeader() bool {
+	if h.config.Leadership == nil {
+		return true
+	}
+
+	return h.config.Leadership.IsLeader()
+}
+
+// skipNotLeader returns true if item needs to be skipped because the operator is not the leader.
+// Skipped item is enqueued again, so it is handled once leadership is acquired
+func (h *handler) skipNotLeader(item operation.Item) bool {
+	if h.isLeader() {
+		return false
+	}
+
+	log.Debug().
+		Str("kind", item.Kind).
+		Str("namespace", item.Namespace).
+		Str("name", item.Name).
+		Msgf("Operator is not the leader, skipping %s %s/%s",
+			item.Kind,
+			item.Namespace,
+			item.Name)
+
+	if h.operator != nil {
+		h.operator.EnqueueItemAfter(item, leadershipRecheckDelay)
+	}
+
+	return true
+}
@@ -0,0 +1,109 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+)
+
+type leadershipGateMock struct {
+	leader int32
+}
+
+func (l *leadershipGateMock) IsLeader() bool {
+	return atomic.LoadInt32(&l.leader) == 1
+}
+
+func (l *leadershipGateMock) set(leader bool) {
+	if leader {
+		atomic.StoreInt32(&l.leader, 1)
+	} else {
+		atomic.StoreInt32(&l.leader, 0)
+	}
+}
+
+func Test_Leadership_Handle(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	gate := &leadershipGateMock{}
+	handler.config.Leadership = gate
+
+	mock := &requeueOperatorMock{}
+	handler.operator = mock
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateNone)
+	createArangoBackup(t, handler, obj)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateNone, false)
+	require.Equal(t, []time.Duration{leadershipRecheckDelay}, mock.delays)
+
+	// Act
+	gate.set(true)
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStatePending, false)
+}
+
+func Test_Leadership_Refresh(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.RefreshInterval = 10 * time.Millisecond
+	handler.config.RefreshJitter = 0
+	handler.operator = operator.NewOperator("mock", "test")
+
+	gate := &leadershipGateMock{}
+	handler.config.Leadership = gate
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// Act
+	go handler.start(stopCh)
+	time.Sleep(100 * time.Millisecond)
+
+	// Assert
+	require.True(t, handler.LastRefresh().IsZero())
+
+	// Act
+	gate.set(true)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		return !handler.LastRefresh().IsZero()
+	}, time.Second, 10*time.Millisecond)
+}
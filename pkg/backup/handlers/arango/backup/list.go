@@ -0,0 +1,132 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// listPageSize defines number of objects fetched from the API server in single request
+	listPageSize = 100
+
+	// listRestartLimit defines how many times listing is restarted after continue token expired
+	listRestartLimit = 3
+)
+
+// listPaginated calls list with Limit and Continue set until all pages are fetched.
+// List returns continue token of the next page, empty token ends the listing.
+// When continue token expires listing is restarted from the first page, restart is called before,
+// so objects processed from the previous pages can be dropped.
+func listPaginated(options meta.ListOptions, restart func(), list func(options meta.ListOptions) (string, error)) error {
+	options.Limit = listPageSize
+
+	restarts := 0
+
+	for {
+		next, err := list(options)
+		if err != nil {
+			if options.Continue == "" || !errors.IsResourceExpired(err) || restarts >= listRestartLimit {
+				return err
+			}
+
+			restarts++
+			restart()
+
+			options.Continue = ""
+			continue
+		}
+
+		if next == "" {
+			return nil
+		}
+
+		options.Continue = next
+	}
+}
+
+// forEachArangoDeployment calls handle for all deployments from namespace, fetched in pages.
+// Only single page is kept in memory.
+func (h *handler) forEachArangoDeployment(namespace string, options meta.ListOptions, restart func(), handle func(deployment *database.ArangoDeployment) error) error {
+	return listPaginated(options, restart, func(options meta.ListOptions) (string, error) {
+		list, err := h.client.DatabaseV1().ArangoDeployments(namespace).List(options)
+		if err != nil {
+			return "", err
+		}
+
+		for id := range list.Items {
+			if err := handle(&list.Items[id]); err != nil {
+				return "", err
+			}
+		}
+
+		return list.Continue, nil
+	})
+}
+
+// forEachArangoBackup calls handle for all backups from namespace, fetched in pages.
+// Only single page is kept in memory.
+func (h *handler) forEachArangoBackup(namespace string, options meta.ListOptions, restart func(), handle func(backup *backupApi.ArangoBackup) error) error {
+	return listPaginated(options, restart, func(options meta.ListOptions) (string, error) {
+		list, err := h.client.BackupV1().ArangoBackups(namespace).List(options)
+		if err != nil {
+			return "", err
+		}
+
+		for id := range list.Items {
+			if err := handle(&list.Items[id]); err != nil {
+				return "", err
+			}
+		}
+
+		return list.Continue, nil
+	})
+}
+
+// listDeploymentBackups returns backups of the deployment and IDs of server side backups referenced by all backups from
+// the deployment namespace. Backups of the other deployments are not kept.
+func (h *handler) listDeploymentBackups(deployment *database.ArangoDeployment) ([]backupApi.ArangoBackup, backupReferences, error) {
+	var backups []backupApi.ArangoBackup
+	references := backupReferences{}
+
+	err := h.forEachArangoBackup(deployment.Namespace, meta.ListOptions{}, func() {
+		backups = nil
+		references = backupReferences{}
+	}, func(backup *backupApi.ArangoBackup) error {
+		references.add(backup)
+
+		if backup.Spec.Deployment.Name == deployment.Name {
+			backups = append(backups, *backup)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return backups, references, nil
+}
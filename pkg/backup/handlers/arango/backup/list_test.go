@@ -0,0 +1,228 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	fakeClientSet "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/fake"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func noRestart() {}
+
+func Test_ListPaginated(t *testing.T) {
+	pages := map[string]string{
+		"":  "a",
+		"a": "b",
+		"b": "",
+	}
+
+	var calls []string
+
+	err := listPaginated(meta.ListOptions{LabelSelector: "app=test"}, noRestart, func(options meta.ListOptions) (string, error) {
+		require.Equal(t, int64(listPageSize), options.Limit)
+		require.Equal(t, "app=test", options.LabelSelector)
+
+		calls = append(calls, options.Continue)
+
+		return pages[options.Continue], nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"", "a", "b"}, calls)
+}
+
+func Test_ListPaginated_Error(t *testing.T) {
+	calls := 0
+
+	err := listPaginated(meta.ListOptions{}, noRestart, func(options meta.ListOptions) (string, error) {
+		calls++
+
+		if options.Continue == "a" {
+			return "", fmt.Errorf("list error")
+		}
+
+		return "a", nil
+	})
+
+	require.EqualError(t, err, "list error")
+	require.Equal(t, 2, calls)
+}
+
+func Test_ListPaginated_Expired(t *testing.T) {
+	var calls []string
+	restarts := 0
+	expired := false
+
+	err := listPaginated(meta.ListOptions{}, func() {
+		restarts++
+	}, func(options meta.ListOptions) (string, error) {
+		calls = append(calls, options.Continue)
+
+		if options.Continue == "a" && !expired {
+			expired = true
+			return "", errors.NewResourceExpired("continue token expired")
+		}
+
+		if options.Continue == "" {
+			return "a", nil
+		}
+
+		return "", nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, restarts)
+	require.Equal(t, []string{"", "a", "", "a"}, calls)
+}
+
+func Test_ListPaginated_Expired_Limit(t *testing.T) {
+	restarts := 0
+
+	err := listPaginated(meta.ListOptions{}, func() {
+		restarts++
+	}, func(options meta.ListOptions) (string, error) {
+		if options.Continue == "a" {
+			return "", errors.NewResourceExpired("continue token expired")
+		}
+
+		return "a", nil
+	})
+
+	require.Error(t, err)
+	require.True(t, errors.IsResourceExpired(err))
+	require.Equal(t, listRestartLimit, restarts)
+}
+
+func Test_ListPaginated_Expired_FirstPage(t *testing.T) {
+	restarts := 0
+
+	err := listPaginated(meta.ListOptions{}, func() {
+		restarts++
+	}, func(options meta.ListOptions) (string, error) {
+		return "", errors.NewResourceExpired("resource version too old")
+	})
+
+	require.Error(t, err)
+	require.Equal(t, 0, restarts)
+}
+
+func Test_ListArangoBackups(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	first, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	second, _ := newObjectSet(backupApi.ArangoBackupStateReady)
+	second.Namespace = deployment.Namespace
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, first)
+	createArangoBackup(t, handler, second)
+
+	// Act
+	var backups []string
+	err := handler.forEachArangoBackup(deployment.Namespace, meta.ListOptions{}, noRestart, func(backup *backupApi.ArangoBackup) error {
+		backups = append(backups, backup.Name)
+		return nil
+	})
+	require.NoError(t, err)
+
+	var deployments []string
+	err = handler.forEachArangoDeployment(deployment.Namespace, meta.ListOptions{}, noRestart, func(deployment *database.ArangoDeployment) error {
+		deployments = append(deployments, deployment.Name)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Assert
+	require.ElementsMatch(t, []string{first.Name, second.Name}, backups)
+	require.Equal(t, []string{deployment.Name}, deployments)
+}
+
+func Test_ListDeploymentBackups(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{ID: "id"}
+	other := newArangoBackup("other", deployment.Namespace, "other", backupApi.ArangoBackupStateReady)
+	other.Status.Backup = &backupApi.ArangoBackupDetails{ID: "other-id"}
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+	createArangoBackup(t, handler, other)
+
+	// Act
+	backups, references, err := handler.listDeploymentBackups(deployment)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, backups, 1)
+	require.Equal(t, obj.Name, backups[0].Name)
+	require.True(t, references["id"])
+	require.True(t, references["other-id"])
+}
+
+func Test_ListDeploymentBackups_Expired(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	lists := 0
+	handler.client.(*fakeClientSet.Clientset).PrependReactor("list", "arangobackups", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		lists++
+
+		switch lists {
+		case 1:
+			// First page with continue token
+			return true, &backupApi.ArangoBackupList{
+				ListMeta: meta.ListMeta{Continue: "next"},
+				Items:    []backupApi.ArangoBackup{*obj},
+			}, nil
+		case 2:
+			return true, nil, errors.NewResourceExpired("continue token expired")
+		}
+
+		return false, nil, nil
+	})
+
+	// Act
+	backups, _, err := handler.listDeploymentBackups(deployment)
+	require.NoError(t, err)
+
+	// Assert
+	require.Equal(t, 3, lists)
+	require.Len(t, backups, 1)
+}
@@ -0,0 +1,183 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/arangodb/kube-arangodb/pkg/apis/backup"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ManifestGenerated name of the event send when manifest of the backup was generated
+	ManifestGenerated = "ManifestGenerated"
+
+	manifestConfigMapKey = "manifest.json"
+
+	// maxManifestStatusSize is the max size of the manifest stored in the status, larger manifests are stored in ConfigMap
+	maxManifestStatusSize = 64 * 1024
+
+	// maxManifestConfigMapSize is the max size of the ConfigMap data accepted by the Kubernetes API
+	maxManifestConfigMapSize = 1024 * 1024
+)
+
+func updateStatusManifest(manifest *backupApi.ArangoBackupManifest) updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		if manifest != nil {
+			status.Manifest = manifest
+		}
+	}
+}
+
+func manifestConfigMapName(backup *backupApi.ArangoBackup) string {
+	return fmt.Sprintf("%s-manifest", backup.Name)
+}
+
+// isManifestRequested returns true if manifest was requested and not yet generated
+func isManifestRequested(backup *backupApi.ArangoBackup) bool {
+	return util.BoolOrDefault(backup.Spec.GenerateManifest) && backup.Status.Manifest == nil
+}
+
+// recordManifest generates the manifest of the backup which was just created. Content of the deployment is taken
+// right after the backup creation, as backup metadata does not contain the collections.
+// Backup already exists, so errors are not returned but kept in the manifest message.
+// Returns nil if manifest was not requested.
+func (h *handler) recordManifest(backup *backupApi.ArangoBackup, client ArangoBackupClient) *backupApi.ArangoBackupManifest {
+	if !isManifestRequested(backup) {
+		return nil
+	}
+
+	manifest := &backupApi.ArangoBackupManifest{
+		CreationTimestamp: meta.Now(),
+	}
+
+	collections, err := client.Manifest(h.ctx)
+	if err != nil {
+		manifest.Message = fmt.Sprintf("unable to get collections: %s", err.Error())
+		h.eventRecorder.Warning(backup, ManifestGenerated, "Manifest not generated: %s", manifest.Message)
+		return manifest
+	}
+
+	if err := h.storeManifest(backup, manifest, collections); err != nil {
+		manifest.Message = err.Error()
+		h.eventRecorder.Warning(backup, ManifestGenerated, "Manifest not stored: %s", manifest.Message)
+		return manifest
+	}
+
+	h.eventRecorder.Normal(backup, ManifestGenerated, "Manifest generated with %d collections", len(collections))
+
+	return manifest
+}
+
+// ensureManifest marks manifest as not available when it was requested for the backup which was not created by the operator.
+// Returns nil if there is nothing to update.
+func (h *handler) ensureManifest(backup *backupApi.ArangoBackup) *backupApi.ArangoBackupManifest {
+	if !isManifestRequested(backup) {
+		return nil
+	}
+
+	manifest := &backupApi.ArangoBackupManifest{
+		CreationTimestamp: meta.Now(),
+		Message:           "manifest is generated only when backup is created by the operator",
+	}
+
+	h.eventRecorder.Warning(backup, ManifestGenerated, "Manifest not generated: %s", manifest.Message)
+
+	return manifest
+}
+
+// storeManifest stores collections in the status or in the ConfigMap, depending on the target and the manifest size
+func (h *handler) storeManifest(backup *backupApi.ArangoBackup, manifest *backupApi.ArangoBackupManifest, collections []backupApi.ArangoBackupManifestCollection) error {
+	data, err := json.Marshal(collections)
+	if err != nil {
+		return err
+	}
+
+	if backup.Spec.ManifestTarget.Get() == backupApi.ArangoBackupManifestTargetStatus && len(data) <= maxManifestStatusSize {
+		manifest.Collections = collections
+		return nil
+	}
+
+	if len(data) > maxManifestConfigMapSize {
+		return fmt.Errorf("manifest size %d bytes exceeds ConfigMap limit of %d bytes", len(data), maxManifestConfigMapSize)
+	}
+
+	name := manifestConfigMapName(backup)
+
+	if err := h.createManifestConfigMap(backup, name, data); err != nil {
+		return err
+	}
+
+	manifest.ConfigMapName = name
+
+	return nil
+}
+
+func (h *handler) createManifestConfigMap(backupObj *backupApi.ArangoBackup, name string, data []byte) error {
+	configMap := &core.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      name,
+			Namespace: backupObj.Namespace,
+			OwnerReferences: []meta.OwnerReference{
+				{
+					APIVersion: backupApi.SchemeGroupVersion.String(),
+					Kind:       backup.ArangoBackupResourceKind,
+					Name:       backupObj.Name,
+					UID:        backupObj.UID,
+				},
+			},
+		},
+		Data: map[string]string{
+			manifestConfigMapKey: string(data),
+		},
+	}
+
+	_, err := h.kubeClient.CoreV1().ConfigMaps(backupObj.Namespace).Create(configMap)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	// Existing ConfigMap is reused only if it was created for this backup
+	existing, err := h.kubeClient.CoreV1().ConfigMaps(backupObj.Namespace).Get(name, meta.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, owner := range existing.OwnerReferences {
+		if owner.UID == backupObj.UID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("ConfigMap %s already exists and is not owned by the backup", name)
+}
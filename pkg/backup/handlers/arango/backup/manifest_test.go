@@ -0,0 +1,280 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+)
+
+func newManifestBackup(t *testing.T, target *backupApi.ArangoBackupManifestTarget) (*handler, *mockArangoClientBackup, *backupApi.ArangoBackup) {
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.GenerateManifest = util.NewBool(true)
+	obj.Spec.ManifestTarget = target
+
+	mock.state.manifest = []backupApi.ArangoBackupManifestCollection{
+		{
+			Database:    "_system",
+			Name:        "users",
+			Count:       10,
+			SizeInBytes: 1024,
+		},
+		{
+			Database: "db",
+			Name:     "orders",
+			Count:    5,
+		},
+	}
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	return handler, mock, obj
+}
+
+func Test_Manifest_Status(t *testing.T) {
+	// Arrange
+	handler, mock, obj := newManifestBackup(t, nil)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.NotNil(t, newObj.Status.Manifest)
+	require.Equal(t, mock.state.manifest, newObj.Status.Manifest.Collections)
+	require.Empty(t, newObj.Status.Manifest.ConfigMapName)
+	require.Empty(t, newObj.Status.Manifest.Message)
+}
+
+func Test_Manifest_ConfigMap(t *testing.T) {
+	// Arrange
+	target := backupApi.ArangoBackupManifestTargetConfigMap
+	handler, mock, obj := newManifestBackup(t, &target)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.NotNil(t, newObj.Status.Manifest)
+	require.Empty(t, newObj.Status.Manifest.Collections)
+	require.Equal(t, manifestConfigMapName(obj), newObj.Status.Manifest.ConfigMapName)
+
+	configMap, err := handler.kubeClient.CoreV1().ConfigMaps(obj.Namespace).Get(newObj.Status.Manifest.ConfigMapName, meta.GetOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, configMap.OwnerReferences, 1)
+	require.Equal(t, obj.Name, configMap.OwnerReferences[0].Name)
+
+	var collections []backupApi.ArangoBackupManifestCollection
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data[manifestConfigMapKey]), &collections))
+	require.Equal(t, mock.state.manifest, collections)
+}
+
+func Test_Manifest_ConfigMap_TooLarge(t *testing.T) {
+	// Arrange
+	target := backupApi.ArangoBackupManifestTargetConfigMap
+	handler, mock, obj := newManifestBackup(t, &target)
+
+	mock.state.manifest = []backupApi.ArangoBackupManifestCollection{
+		{
+			Database: "_system",
+			Name:     strings.Repeat("a", maxManifestConfigMapSize),
+		},
+	}
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.NotNil(t, newObj.Status.Manifest)
+	require.Empty(t, newObj.Status.Manifest.ConfigMapName)
+	require.NotEmpty(t, newObj.Status.Manifest.Message)
+
+	configMaps, err := handler.kubeClient.CoreV1().ConfigMaps(obj.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, configMaps.Items, 0)
+}
+
+func Test_Manifest_Status_TooLarge(t *testing.T) {
+	// Arrange
+	handler, mock, obj := newManifestBackup(t, nil)
+
+	mock.state.manifest = []backupApi.ArangoBackupManifestCollection{
+		{
+			Database: "_system",
+			Name:     strings.Repeat("a", maxManifestStatusSize),
+		},
+	}
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.NotNil(t, newObj.Status.Manifest)
+	require.Empty(t, newObj.Status.Manifest.Collections)
+	require.Equal(t, manifestConfigMapName(obj), newObj.Status.Manifest.ConfigMapName)
+}
+
+func Test_Manifest_ConfigMap_NotOwned(t *testing.T) {
+	// Arrange
+	target := backupApi.ArangoBackupManifestTargetConfigMap
+	handler, _, obj := newManifestBackup(t, &target)
+
+	_, err := handler.kubeClient.CoreV1().ConfigMaps(obj.Namespace).Create(&core.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      manifestConfigMapName(obj),
+			Namespace: obj.Namespace,
+		},
+	})
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.NotNil(t, newObj.Status.Manifest)
+	require.Empty(t, newObj.Status.Manifest.ConfigMapName)
+	require.Contains(t, newObj.Status.Manifest.Message, "not owned by the backup")
+}
+
+func Test_Manifest_ConfigMap_Owned(t *testing.T) {
+	// Arrange
+	target := backupApi.ArangoBackupManifestTargetConfigMap
+	handler, _, obj := newManifestBackup(t, &target)
+
+	_, err := handler.kubeClient.CoreV1().ConfigMaps(obj.Namespace).Create(&core.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      manifestConfigMapName(obj),
+			Namespace: obj.Namespace,
+			OwnerReferences: []meta.OwnerReference{
+				{
+					Name: obj.Name,
+					UID:  obj.UID,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.NotNil(t, newObj.Status.Manifest)
+	require.Equal(t, manifestConfigMapName(obj), newObj.Status.Manifest.ConfigMapName)
+	require.Empty(t, newObj.Status.Manifest.Message)
+}
+
+func Test_Manifest_Error(t *testing.T) {
+	// Arrange
+	handler, mock, obj := newManifestBackup(t, nil)
+	mock.state.errors.manifestError = fmt.Errorf("manifest error")
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.NotNil(t, newObj.Status.Manifest)
+	require.Empty(t, newObj.Status.Manifest.Collections)
+	require.Contains(t, newObj.Status.Manifest.Message, "manifest error")
+	require.Len(t, mock.getIDs(), 1)
+}
+
+func Test_Manifest_NotCreatedByOperator(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Spec.GenerateManifest = util.NewBool(true)
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.NotNil(t, newObj.Status.Manifest)
+	require.Empty(t, newObj.Status.Manifest.Collections)
+	require.NotEmpty(t, newObj.Status.Manifest.Message)
+}
+
+func Test_Manifest_NotRequested(t *testing.T) {
+	// Arrange
+	handler, _, obj := newManifestBackup(t, nil)
+	obj.Spec.GenerateManifest = nil
+
+	_, err := handler.client.BackupV1().ArangoBackups(obj.Namespace).Update(obj)
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+	require.Nil(t, newObj.Status.Manifest)
+}
@@ -0,0 +1,136 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"sort"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Admin provides administrative operations of the backup handler
+type Admin interface {
+	// OrphanedBackups returns server side backups of the deployment which have no ArangoBackup resource
+	OrphanedBackups(namespace, deployment string) ([]OrphanedBackup, error)
+
+	// ResetDeploymentLock releases the lock of the deployment held by a stuck operation, returns false if the deployment has no lock
+	ResetDeploymentLock(namespace, deployment string) bool
+
+	// Refresh imports and prunes backups of all handled deployments immediately
+	Refresh() (RefreshResult, error)
+}
+
+var _ Admin = &handler{}
+
+// OrphanedBackup is a server side backup which has no ArangoBackup resource
+type OrphanedBackup struct {
+	id                string
+	version           string
+	creationTimestamp time.Time
+}
+
+// ID returns ID of the backup on the server
+func (o OrphanedBackup) ID() string {
+	return o.id
+}
+
+// Version returns version of the backup
+func (o OrphanedBackup) Version() string {
+	return o.version
+}
+
+// CreationTimestamp returns time when backup was created
+func (o OrphanedBackup) CreationTimestamp() time.Time {
+	return o.creationTimestamp
+}
+
+func (h *handler) OrphanedBackups(namespace, deployment string) ([]OrphanedBackup, error) {
+	depl, err := h.client.DatabaseV1().ArangoDeployments(namespace).Get(deployment, meta.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	s := h.getDeploymentSemaphore(depl.Namespace, depl.Name)
+	s.Acquire()
+	defer s.Release()
+
+	client, err := h.arangoClientFactory(depl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, references, err := h.listDeploymentBackups(depl)
+	if err != nil {
+		return nil, err
+	}
+
+	existingBackups, err := client.List(h.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orphaned := make([]OrphanedBackup, 0)
+
+	for _, backupMeta := range existingBackups {
+		if references.has(backupMeta) {
+			continue
+		}
+
+		orphaned = append(orphaned, OrphanedBackup{
+			id:                string(backupMeta.ID),
+			version:           backupMeta.Version,
+			creationTimestamp: backupMeta.DateTime,
+		})
+	}
+
+	sort.Slice(orphaned, func(i, j int) bool {
+		if !orphaned[i].creationTimestamp.Equal(orphaned[j].creationTimestamp) {
+			return orphaned[i].creationTimestamp.Before(orphaned[j].creationTimestamp)
+		}
+
+		return orphaned[i].id < orphaned[j].id
+	})
+
+	return orphaned, nil
+}
+
+// backupReferences keeps IDs of the server side backups referenced by ArangoBackups, as created or downloaded backup
+type backupReferences map[string]bool
+
+func (r backupReferences) add(backup *backupApi.ArangoBackup) {
+	if download := backup.Spec.Download; download != nil {
+		r[download.ID] = true
+	}
+
+	if backup.Status.Backup != nil {
+		r[backup.Status.Backup.ID] = true
+	}
+}
+
+// has returns true if server side backup is referenced by one of the ArangoBackups
+func (r backupReferences) has(backupMeta driver.BackupMeta) bool {
+	return r[string(backupMeta.ID)]
+}
@@ -0,0 +1,85 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func Test_OrphanedBackups(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	known, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	downloaded, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	orphaned, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(known.BackupMeta, nil)
+
+	download, _ := newObjectSet(backupApi.ArangoBackupStateDownloading)
+	download.Namespace = deployment.Namespace
+	download.Spec.Download = &backupApi.ArangoBackupSpecDownload{
+		ArangoBackupSpecOperation: backupApi.ArangoBackupSpecOperation{
+			RepositoryURL: "s3://test",
+		},
+		ID: string(downloaded.ID),
+	}
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj, download)
+
+	// Act
+	backups, err := handler.OrphanedBackups(deployment.Namespace, deployment.Name)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, backups, 1)
+	require.Equal(t, string(orphaned.ID), backups[0].ID())
+	require.Equal(t, orphaned.Version, backups[0].Version())
+	require.Equal(t, orphaned.DateTime, backups[0].CreationTimestamp())
+}
+
+func Test_OrphanedBackups_MissingDeployment(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	// Act
+	_, err := handler.OrphanedBackups(deployment.Namespace, deployment.Name)
+
+	// Assert
+	require.True(t, errors.IsNotFound(err))
+}
@@ -0,0 +1,66 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Paused name of the event send when reconciliation of the backup was paused
+	Paused = "Paused"
+
+	// Resumed name of the event send when reconciliation of the backup was resumed
+	Resumed = "Resumed"
+)
+
+func updateStatusPaused(paused bool) updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		status.Paused = paused
+	}
+}
+
+// handlePaused records pause of the backup in status. After the annotation is removed pause is cleared
+// and backup is handled again from the state it was paused in
+func (h *handler) handlePaused(item operation.Item, b *backupApi.ArangoBackup) error {
+	paused := b.IsPaused()
+
+	if paused == b.Status.Paused {
+		log.Debug().
+			Str("kind", item.Kind).
+			Str("namespace", item.Namespace).
+			Str("name", item.Name).
+			Msgf("Backup is paused, skipping")
+		return nil
+	}
+
+	if paused {
+		h.eventRecorder.Normal(b, Paused, "Reconciliation paused in state %s", b.Status.State)
+	} else {
+		h.eventRecorder.Normal(b, Resumed, "Reconciliation resumed in state %s", b.Status.State)
+	}
+
+	return h.applyStatus(item, b, updateStatus(b, updateStatusPaused(paused)))
+}
@@ -0,0 +1,108 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Paused_SkipAndResume(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+	obj.Annotations = map[string]string{
+		backupApi.AnnotationArangoBackupPaused: "true",
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStatePending, newObj.Status.State)
+	require.True(t, newObj.Status.Paused)
+
+	// Act
+	newObj.Annotations = nil
+	_, err := handler.client.BackupV1().ArangoBackups(newObj.Namespace).Update(newObj)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStatePending, newObj.Status.State)
+	require.False(t, newObj.Status.Paused)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateScheduled, newObj.Status.State)
+}
+
+func Test_Paused_Finalize(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Annotations = map[string]string{
+		backupApi.AnnotationArangoBackupPaused: "true",
+	}
+	obj.Finalizers = []string{
+		backupApi.FinalizerArangoBackup,
+	}
+
+	now := meta.Now()
+	obj.DeletionTimestamp = &now
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Delete, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateDeleting, newObj.Status.State)
+}
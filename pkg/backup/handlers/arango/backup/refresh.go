@@ -0,0 +1,53 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"time"
+)
+
+// RefreshResult summarizes changes done by the refresh of the deployments
+type RefreshResult struct {
+	// Imported is number of server backups imported as new ArangoBackups
+	Imported int
+
+	// Pruned is number of ArangoBackups deleted because of exceeded retention or expired failure
+	Pruned int
+}
+
+func (r *RefreshResult) add(o RefreshResult) {
+	r.Imported += o.Imported
+	r.Pruned += o.Pruned
+}
+
+// Refresh runs the refresh of the deployments immediately. It never runs together with the periodic refresh.
+func (h *handler) Refresh() (RefreshResult, error) {
+	result, err := h.refresh()
+	if err != nil {
+		return result, err
+	}
+
+	h.markRefreshed(time.Now())
+
+	return result, nil
+}
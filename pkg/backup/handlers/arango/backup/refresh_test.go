@@ -0,0 +1,93 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Refresh_Manual(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	handler.operator = operator.NewOperator("mock", deployment.Namespace)
+
+	mock.state.backups["imported"] = driver.BackupMeta{
+		ID:      "imported",
+		Version: "3.6.0",
+	}
+
+	createArangoDeployment(t, handler, deployment)
+
+	// Act
+	result, err := handler.Refresh()
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, RefreshResult{Imported: 1}, result)
+	require.False(t, handler.LastRefresh().IsZero())
+
+	// Imported backup is not imported again
+	result, err = handler.Refresh()
+	require.NoError(t, err)
+	require.Equal(t, RefreshResult{}, result)
+}
+
+func Test_Refresh_Serialized(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.operator = operator.NewOperator("mock", "test")
+
+	handler.refreshRunLock.Lock()
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		_, err := handler.Refresh()
+		require.NoError(t, err)
+		close(done)
+	}()
+
+	// Assert
+	select {
+	case <-done:
+		require.Fail(t, "Refresh should wait for the running refresh")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	handler.refreshRunLock.Unlock()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "Refresh should not be blocked")
+	}
+}
@@ -23,6 +23,8 @@
 package backup
 
 import (
+	"context"
+
 	"github.com/arangodb/kube-arangodb/pkg/apis/backup"
 	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
 	"github.com/arangodb/kube-arangodb/pkg/backup/operator"
@@ -39,12 +41,17 @@ func newEventInstance(recorder event.Recorder) event.RecorderInstance {
 }
 
 // RegisterInformer into operator
-func RegisterInformer(operator operator.Operator, recorder event.Recorder, client arangoClientSet.Interface, kubeClient kubernetes.Interface, informer arangoInformer.SharedInformerFactory) error {
-	if err := operator.RegisterInformer(informer.Backup().V1().ArangoBackups().Informer(),
+func RegisterInformer(o operator.Operator, recorder event.Recorder, client arangoClientSet.Interface, kubeClient kubernetes.Interface, informer arangoInformer.SharedInformerFactory, config Config) (Admin, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := o.RegisterInformer(informer.Backup().V1().ArangoBackups().Informer(),
 		backupApi.SchemeGroupVersion.Group,
 		backupApi.SchemeGroupVersion.Version,
-		backup.ArangoBackupResourceKind); err != nil {
-		return err
+		backup.ArangoBackupResourceKind,
+		operator.IgnoreStatusUpdates); err != nil {
+		return nil, err
 	}
 
 	h := &handler{
@@ -53,19 +60,24 @@ func RegisterInformer(operator operator.Operator, recorder event.Recorder, clien
 
 		eventRecorder: newEventInstance(recorder),
 
-		operator: operator,
+		operator:       o,
+		requeueLimiter: newRequeueLimiter(config),
 
 		arangoClientTimeout: defaultArangoClientTimeout,
+
+		config:  config,
+		metrics: newPrometheusMetrics(),
 	}
+	h.ctx, h.cancel = context.WithCancel(context.Background())
 	h.arangoClientFactory = newArangoClientBackupFactory(h)
 
-	if err := operator.RegisterHandler(h); err != nil {
-		return err
+	if err := o.RegisterHandler(h); err != nil {
+		return nil, err
 	}
 
-	if err := operator.RegisterStarter(h); err != nil {
-		return err
+	if err := o.RegisterStarter(h); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return h, nil
 }
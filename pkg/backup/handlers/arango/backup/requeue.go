@@ -0,0 +1,102 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/backup/state"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// jobStates are states in which backup waits for the upload or download job running on the server
+var jobStates = map[state.State]bool{
+	backupApi.ArangoBackupStateUploading:   true,
+	backupApi.ArangoBackupStateDownloading: true,
+}
+
+func newRequeueLimiter(config Config) workqueue.RateLimiter {
+	return workqueue.NewItemExponentialFailureRateLimiter(config.RequeueBaseDelay, config.RequeueMaxDelay)
+}
+
+// requeue schedules next handling of the item. Status updates and resyncs of ArangoBackups are not handled,
+// so requeue drives all handling without spec or metadata change.
+// While server job is running item is handled after job poll delay.
+// After state transition item is handled immediately and delay is reset,
+// otherwise delay grows exponentially, so backup stuck in the same state does not hot-loop
+func (h *handler) requeue(item operation.Item, from state.State, status *backupApi.ArangoBackupStatus) {
+	key := item.String()
+
+	if jobStates[status.State] {
+		h.requeueLimiter.Forget(key)
+		h.operator.EnqueueItemAfter(item, h.jobPollDelay(status.Progress, time.Now()))
+		return
+	}
+
+	if from != status.State {
+		h.requeueLimiter.Forget(key)
+		h.operator.EnqueueItem(item)
+		return
+	}
+
+	h.operator.EnqueueItemAfter(item, h.stateDeadlineDelay(status, h.requeueLimiter.When(key), time.Now()))
+}
+
+// stateDeadlineDelay shortens the delay, so backup waiting for credentials is handled again
+// when CredentialsTimeout expires and can be failed in time
+func (h *handler) stateDeadlineDelay(status *backupApi.ArangoBackupStatus, delay time.Duration, now time.Time) time.Duration {
+	if status.State != backupApi.ArangoBackupStateWaitingForCredentials || status.Time.IsZero() {
+		return delay
+	}
+
+	remaining := status.Time.Add(h.config.CredentialsTimeout).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+
+	if remaining < delay {
+		return remaining
+	}
+
+	return delay
+}
+
+// jobPollDelay returns delay of the next job progress check. Delay starts at JobPollInterval and doubles
+// while it stays below the time the job is running, so long jobs are polled less frequently, up to JobPollMaxDelay
+func (h *handler) jobPollDelay(progress *backupApi.ArangoBackupProgress, now time.Time) time.Duration {
+	delay := h.config.JobPollInterval
+
+	if progress == nil || progress.StartedAt.IsZero() {
+		return delay
+	}
+
+	running := now.Sub(progress.StartedAt.Time)
+
+	for delay*2 <= running && delay*2 <= h.config.JobPollMaxDelay {
+		delay *= 2
+	}
+
+	return delay
+}
@@ -0,0 +1,388 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/backup/state"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type requeueOperatorMock struct {
+	operator.Operator
+
+	immediate int
+	delays    []time.Duration
+}
+
+func (r *requeueOperatorMock) EnqueueItem(item operation.Item) {
+	r.immediate++
+}
+
+func (r *requeueOperatorMock) EnqueueItemAfter(item operation.Item, delay time.Duration) {
+	r.delays = append(r.delays, delay)
+}
+
+func newRequeueStatus(s state.State) *backupApi.ArangoBackupStatus {
+	return &backupApi.ArangoBackupStatus{
+		ArangoBackupState: backupApi.ArangoBackupState{
+			State: s,
+		},
+	}
+}
+
+func Test_Requeue_Delay(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+	handler.config.RequeueBaseDelay = 10 * time.Millisecond
+	handler.config.RequeueMaxDelay = 40 * time.Millisecond
+	handler.requeueLimiter = newRequeueLimiter(handler.config)
+
+	mock := &requeueOperatorMock{}
+	handler.operator = mock
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateReady)
+	item := newItemFromBackup(operation.Update, obj)
+
+	// Act
+	for i := 0; i < 4; i++ {
+		handler.requeue(item, backupApi.ArangoBackupStateReady, newRequeueStatus(backupApi.ArangoBackupStateReady))
+	}
+
+	// Assert
+	require.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond}, mock.delays)
+	require.Equal(t, 0, mock.immediate)
+
+	// Act
+	handler.requeue(item, backupApi.ArangoBackupStateUploading, newRequeueStatus(backupApi.ArangoBackupStateReady))
+	handler.requeue(item, backupApi.ArangoBackupStateReady, newRequeueStatus(backupApi.ArangoBackupStateReady))
+
+	// Assert
+	require.Equal(t, 1, mock.immediate)
+	require.Equal(t, 10*time.Millisecond, mock.delays[4])
+}
+
+func Test_Requeue_Handle(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	mock := &requeueOperatorMock{}
+	handler.operator = mock
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	require.Equal(t, 1, mock.immediate)
+	require.Len(t, mock.delays, 0)
+}
+
+func Test_Requeue_Handle_Unchanged(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.RequeueBaseDelay = 10 * time.Millisecond
+	handler.config.RequeueMaxDelay = 40 * time.Millisecond
+	handler.requeueLimiter = newRequeueLimiter(handler.config)
+
+	operatorMock := &requeueOperatorMock{}
+	handler.operator = operatorMock
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+	obj.Status.Available = true
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	// Act
+	for i := 0; i < 4; i++ {
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	}
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.Equal(t, 0, operatorMock.immediate)
+	require.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond}, operatorMock.delays)
+}
+
+func Test_Requeue_Handle_ResetAfterTransition(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.RequeueBaseDelay = 10 * time.Millisecond
+	handler.config.RequeueMaxDelay = 40 * time.Millisecond
+	handler.config.JobPollInterval = 15 * time.Second
+	handler.requeueLimiter = newRequeueLimiter(handler.config)
+
+	operatorMock := &requeueOperatorMock{}
+	handler.operator = operatorMock
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+	obj.Status.Available = true
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	// Act & Assert
+	t.Run("Delay grows without transition", func(t *testing.T) {
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		require.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}, operatorMock.delays)
+	})
+
+	t.Run("Transition is handled immediately", func(t *testing.T) {
+		newObj := refreshArangoBackup(t, handler, obj)
+		newObj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+			RepositoryURL: "s3://test",
+		}
+		_, err := handler.client.BackupV1().ArangoBackups(newObj.Namespace).Update(newObj)
+		require.NoError(t, err)
+
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		newObj = refreshArangoBackup(t, handler, obj)
+		require.Equal(t, backupApi.ArangoBackupStateUpload, newObj.Status.State)
+		require.Equal(t, 1, operatorMock.immediate)
+	})
+
+	t.Run("Job is polled after poll interval", func(t *testing.T) {
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		newObj := refreshArangoBackup(t, handler, obj)
+		require.Equal(t, backupApi.ArangoBackupStateUploading, newObj.Status.State)
+		require.Equal(t, 15*time.Second, operatorMock.delays[2])
+
+		mock.state.progresses[driver.BackupTransferJobID(newObj.Status.Progress.JobID)] = ArangoBackupProgress{
+			Completed: true,
+		}
+
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		newObj = refreshArangoBackup(t, handler, obj)
+		require.Equal(t, backupApi.ArangoBackupStateReady, newObj.Status.State)
+		require.Equal(t, 2, operatorMock.immediate)
+	})
+
+	t.Run("Delay is reset after transition", func(t *testing.T) {
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		require.Equal(t, 10*time.Millisecond, operatorMock.delays[len(operatorMock.delays)-1])
+	})
+}
+
+func Test_Requeue_JobStates(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+	handler.config.JobPollInterval = 15 * time.Second
+
+	mock := &requeueOperatorMock{}
+	handler.operator = mock
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateUploading)
+	item := newItemFromBackup(operation.Update, obj)
+
+	// Act
+	handler.requeue(item, backupApi.ArangoBackupStateUpload, newRequeueStatus(backupApi.ArangoBackupStateUploading))
+	handler.requeue(item, backupApi.ArangoBackupStateUploading, newRequeueStatus(backupApi.ArangoBackupStateUploading))
+	handler.requeue(item, backupApi.ArangoBackupStateDownload, newRequeueStatus(backupApi.ArangoBackupStateDownloading))
+
+	// Assert
+	require.Equal(t, []time.Duration{15 * time.Second, 15 * time.Second, 15 * time.Second}, mock.delays)
+	require.Equal(t, 0, mock.immediate)
+}
+
+func Test_Requeue_Handle_Uploading(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	operatorMock := &requeueOperatorMock{}
+	handler.operator = operatorMock
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateUploading, newObj.Status.State)
+
+	require.Equal(t, 0, operatorMock.immediate)
+	require.Equal(t, []time.Duration{defaultJobPollInterval}, operatorMock.delays)
+}
+
+func Test_Requeue_Handle_UploadingUnchanged(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.JobPollInterval = 10 * time.Second
+	handler.config.JobPollMaxDelay = 5 * time.Minute
+
+	operatorMock := &requeueOperatorMock{}
+	handler.operator = operatorMock
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Job is running for an hour without progress change
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateUploading, newObj.Status.State)
+	newObj.Status.Progress.StartedAt = meta.NewTime(time.Now().Add(-time.Hour))
+	_, err = handler.client.BackupV1().ArangoBackups(newObj.Namespace).UpdateStatus(newObj)
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStateUploading, newObj.Status.State)
+
+	require.Equal(t, 0, operatorMock.immediate)
+	require.Equal(t, []time.Duration{10 * time.Second, 160 * time.Second}, operatorMock.delays)
+}
+
+func Test_Requeue_JobPollDelay(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+	handler.config.JobPollInterval = 10 * time.Second
+	handler.config.JobPollMaxDelay = time.Minute
+
+	now := time.Now()
+
+	cases := map[string]struct {
+		progress *backupApi.ArangoBackupProgress
+		delay    time.Duration
+	}{
+		"no progress":       {delay: 10 * time.Second},
+		"no start time":     {progress: &backupApi.ArangoBackupProgress{JobID: "job"}, delay: 10 * time.Second},
+		"just started":      {progress: newRequeueProgress(now), delay: 10 * time.Second},
+		"running for 25s":   {progress: newRequeueProgress(now.Add(-25 * time.Second)), delay: 20 * time.Second},
+		"running for 45s":   {progress: newRequeueProgress(now.Add(-45 * time.Second)), delay: 40 * time.Second},
+		"running for hours": {progress: newRequeueProgress(now.Add(-3 * time.Hour)), delay: 40 * time.Second},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			delay := handler.jobPollDelay(c.progress, now)
+
+			// Assert
+			require.Equal(t, c.delay, delay)
+		})
+	}
+
+	// Delay is capped by the maximum
+	handler.config.JobPollMaxDelay = 80 * time.Second
+	require.Equal(t, 80*time.Second, handler.jobPollDelay(newRequeueProgress(now.Add(-3*time.Hour)), now))
+}
+
+func newRequeueProgress(started time.Time) *backupApi.ArangoBackupProgress {
+	return &backupApi.ArangoBackupProgress{
+		JobID:     "job",
+		StartedAt: meta.NewTime(started),
+	}
+}
+
+func Test_Requeue_JobStartTimeKept(t *testing.T) {
+	// Arrange
+	started := meta.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateUploading)
+	obj.Status.Progress = &backupApi.ArangoBackupProgress{
+		JobID:     "job",
+		Progress:  "10%",
+		StartedAt: started,
+	}
+
+	// Act
+	status := updateStatus(obj, updateStatusJob("job", "20%"))
+
+	// Assert
+	require.Equal(t, "20%", status.Progress.Progress)
+	require.True(t, started.Equal(&status.Progress.StartedAt))
+
+	// Act
+	status = updateStatus(obj, updateStatusJob("other", "0%"))
+
+	// Assert
+	require.True(t, status.Progress.StartedAt.After(started.Time))
+}
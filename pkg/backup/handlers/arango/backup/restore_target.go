@@ -0,0 +1,82 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func updateStatusRestoreTarget(target *backupApi.ArangoBackupRestoreTarget) updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		status.RestoreTarget = target
+	}
+}
+
+// checkRestoreTarget validates if backup of the source deployment can be restored in the restore target deployment.
+// Returns nil if restore target deployment is not specified.
+func (h *handler) checkRestoreTarget(backup *backupApi.ArangoBackup, source *database.ArangoDeployment) (*backupApi.ArangoBackupRestoreTarget, error) {
+	target := backup.Spec.RestoreTargetDeployment
+	if target == nil {
+		return nil, nil
+	}
+
+	result := &backupApi.ArangoBackupRestoreTarget{
+		Source: backup.Spec.Deployment.Name,
+		Target: target.Name,
+	}
+
+	deployment, err := h.client.DatabaseV1().ArangoDeployments(backup.Namespace).Get(target.Name, meta.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			result.Message = fmt.Sprintf("target deployment %s not found", target.Name)
+			return result, nil
+		}
+
+		return nil, newTemporaryError(err)
+	}
+
+	if message := restoreTargetIncompatibility(backup, source, deployment); message != "" {
+		result.Message = message
+		return result, nil
+	}
+
+	result.Ready = true
+
+	return result, nil
+}
+
+// restoreTargetIncompatibility returns reason why backup of the source deployment can not be restored in the deployment
+// or empty string
+func restoreTargetIncompatibility(backup *backupApi.ArangoBackup, source, deployment *database.ArangoDeployment) string {
+	// Hot backup can be restored only in the deployment with the same topology
+	if mode, sourceMode := deployment.Spec.Mode.Get(), source.Spec.Mode.Get(); mode != sourceMode {
+		return fmt.Sprintf("target deployment %s mode %s does not match source deployment mode %s", deployment.Name, mode, sourceMode)
+	}
+
+	return backup.RestoreIncompatibility(deployment.Name, &deployment.Spec, deployment.Status.CurrentImage)
+}
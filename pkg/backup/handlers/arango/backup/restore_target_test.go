@@ -0,0 +1,157 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RestoreTarget(t *testing.T) {
+	type testCase struct {
+		name        string
+		maintenance bool
+		version     driver.Version
+		missing     bool
+		mode        database.DeploymentMode
+		dbservers   int
+		ready       bool
+	}
+
+	testCases := []testCase{
+		{
+			name:        "compatible",
+			maintenance: true,
+			version:     "1.0.5",
+			ready:       true,
+		},
+		{
+			name:        "incompatible minor version",
+			maintenance: true,
+			version:     "1.1.0",
+		},
+		{
+			name:        "incompatible major version",
+			maintenance: true,
+			version:     "2.0.0",
+		},
+		{
+			name:    "not in maintenance",
+			version: "1.0.0",
+		},
+		{
+			name:        "different mode",
+			maintenance: true,
+			version:     "1.0.5",
+			mode:        database.DeploymentModeActiveFailover,
+		},
+		{
+			name:        "different number of dbservers",
+			maintenance: true,
+			version:     "1.0.5",
+			dbservers:   1,
+		},
+		{
+			name:    "missing target",
+			missing: true,
+		},
+	}
+
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			// Arrange
+			handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+			obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+			target := newArangoDeployment(deployment.Namespace, "target")
+			target.Spec.Database = &database.DatabaseSpec{
+				Maintenance: util.NewBool(c.maintenance),
+			}
+			target.Status.CurrentImage = &database.ImageInfo{
+				ArangoDBVersion: c.version,
+			}
+
+			obj.Spec.RestoreTargetDeployment = &backupApi.ArangoBackupSpecDeployment{
+				Name: target.Name,
+			}
+
+			createResponse, err := mock.Create(context.Background())
+			require.NoError(t, err)
+
+			backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+			require.NoError(t, err)
+
+			obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+
+			if c.mode != "" {
+				target.Spec.Mode = database.NewMode(c.mode)
+			}
+			target.Spec.DBServers.Count = util.NewInt(int(backupMeta.NumberOfDBServers) + c.dbservers)
+
+			// Act
+			createArangoDeployment(t, handler, deployment)
+			if !c.missing {
+				createArangoDeployment(t, handler, target)
+			}
+			createArangoBackup(t, handler, obj)
+
+			require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+			// Assert
+			newObj := refreshArangoBackup(t, handler, obj)
+			checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+			require.NotNil(t, newObj.Status.RestoreTarget)
+			require.Equal(t, deployment.Name, newObj.Status.RestoreTarget.Source)
+			require.Equal(t, target.Name, newObj.Status.RestoreTarget.Target)
+			require.Equal(t, c.ready, newObj.Status.RestoreTarget.Ready)
+
+			if c.ready {
+				require.Empty(t, newObj.Status.RestoreTarget.Message)
+			} else {
+				require.NotEmpty(t, newObj.Status.RestoreTarget.Message)
+			}
+		})
+	}
+}
+
+func Test_RestoreTarget_Validate(t *testing.T) {
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	obj.Spec.RestoreTargetDeployment = &backupApi.ArangoBackupSpecDeployment{}
+	require.EqualError(t, obj.Spec.Validate(), "restore target deployment name can not be empty")
+
+	obj.Spec.RestoreTargetDeployment.Name = deployment.Name
+	require.EqualError(t, obj.Spec.Validate(), "restore target deployment needs to be different from the source deployment")
+
+	obj.Spec.RestoreTargetDeployment.Name = "target"
+	require.NoError(t, obj.Spec.Validate())
+}
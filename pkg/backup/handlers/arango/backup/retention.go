@@ -0,0 +1,143 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/rs/zerolog/log"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RetentionExceeded name of the event send when backup was deleted because of its retention
+	RetentionExceeded = "RetentionExceeded"
+)
+
+type retentionCandidate struct {
+	backup backupApi.ArangoBackup
+	reason string
+}
+
+// pruneDeploymentBackups deletes backups of the deployment which exceeded their retention.
+// Deletion goes through the finalizer, which removes backup from the deployment.
+// Deployment mutex needs to be acquired by the caller.
+func (h *handler) pruneDeploymentBackups(deployment *database.ArangoDeployment, backups []backupApi.ArangoBackup) (int, error) {
+	candidates := retentionExceededBackups(deployment.Name, backups, h.now(deployment), h.config.RetentionExcludeImported)
+
+	pruned := 0
+
+	for _, candidate := range candidates {
+		backup := candidate.backup
+
+		log.Info().Msgf("Deleting backup %s/%s: %s", backup.Namespace, backup.Name, candidate.reason)
+
+		if err := h.client.BackupV1().ArangoBackups(backup.Namespace).Delete(backup.Name, &meta.DeleteOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+
+			return pruned, err
+		}
+
+		pruned++
+
+		h.eventRecorder.Normal(&backup, RetentionExceeded, "Backup deleted: %s", candidate.reason)
+	}
+
+	return pruned, nil
+}
+
+// retentionExceededBackups returns Ready backups of the deployment which are outside of their retention window.
+// Backups are grouped by policy and sorted by creation time, newest first.
+func retentionExceededBackups(deployment string, backups []backupApi.ArangoBackup, now time.Time, excludeImported bool) []retentionCandidate {
+	groups := map[string][]backupApi.ArangoBackup{}
+
+	for _, backup := range backups {
+		if backup.Spec.Deployment.Name != deployment {
+			continue
+		}
+
+		if backup.DeletionTimestamp != nil || backup.Status.State != backupApi.ArangoBackupStateReady || backup.Status.Backup == nil {
+			continue
+		}
+
+		if excludeImported && backup.Status.IsImported() {
+			continue
+		}
+
+		var policy string
+		if backup.Spec.PolicyName != nil {
+			policy = *backup.Spec.PolicyName
+		}
+
+		groups[policy] = append(groups[policy], backup)
+	}
+
+	policies := make([]string, 0, len(groups))
+	for policy := range groups {
+		policies = append(policies, policy)
+	}
+	sort.Strings(policies)
+
+	var candidates []retentionCandidate
+
+	for _, policy := range policies {
+		group := groups[policy]
+
+		sort.Slice(group, func(i, j int) bool {
+			a, b := group[i].Status.Backup.CreationTimestamp, group[j].Status.Backup.CreationTimestamp
+			if a.Equal(&b) {
+				return group[i].Name < group[j].Name
+			}
+
+			return b.Before(&a)
+		})
+
+		for id, backup := range group {
+			retention := backup.Spec.Retention
+
+			if max, ok := retention.GetMaxCount(); ok && id >= max {
+				candidates = append(candidates, retentionCandidate{
+					backup: backup,
+					reason: fmt.Sprintf("backup is not one of the %d newest backups", max),
+				})
+				continue
+			}
+
+			if max, ok := retention.GetMaxAge(); ok && now.Sub(backup.Status.Backup.CreationTimestamp.Time) > max {
+				candidates = append(candidates, retentionCandidate{
+					backup: backup,
+					reason: fmt.Sprintf("backup is older than %s", max),
+				})
+			}
+		}
+	}
+
+	return candidates
+}
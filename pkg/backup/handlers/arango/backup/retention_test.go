@@ -0,0 +1,201 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newRetentionBackup(name, deployment string, created time.Time, retention *backupApi.ArangoBackupSpecRetention) backupApi.ArangoBackup {
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Name = name
+	obj.Spec.Deployment.Name = deployment
+	obj.Spec.Retention = retention
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{
+		ID:                name,
+		CreationTimestamp: meta.NewTime(created),
+	}
+
+	return *obj
+}
+
+func retentionCandidateNames(candidates []retentionCandidate) []string {
+	names := make([]string, len(candidates))
+
+	for id, candidate := range candidates {
+		names[id] = candidate.backup.Name
+	}
+
+	return names
+}
+
+func Test_Retention_MaxCount(t *testing.T) {
+	now := time.Now()
+	retention := &backupApi.ArangoBackupSpecRetention{
+		MaxCount: util.NewInt(2),
+	}
+
+	backups := []backupApi.ArangoBackup{
+		newRetentionBackup("b1", "depl", now.Add(-4*time.Hour), retention),
+		newRetentionBackup("b4", "depl", now.Add(-1*time.Hour), retention),
+		newRetentionBackup("b2", "depl", now.Add(-3*time.Hour), retention),
+		newRetentionBackup("b3", "depl", now.Add(-2*time.Hour), retention),
+		newRetentionBackup("other", "other", now.Add(-5*time.Hour), retention),
+	}
+
+	candidates := retentionExceededBackups("depl", backups, now, false)
+	require.Equal(t, []string{"b2", "b1"}, retentionCandidateNames(candidates))
+}
+
+func Test_Retention_MaxAge(t *testing.T) {
+	now := time.Now()
+	retention := &backupApi.ArangoBackupSpecRetention{
+		MaxAge: &meta.Duration{Duration: 90 * time.Minute},
+	}
+
+	backups := []backupApi.ArangoBackup{
+		newRetentionBackup("b1", "depl", now.Add(-2*time.Hour), retention),
+		newRetentionBackup("b2", "depl", now.Add(-1*time.Hour), retention),
+		newRetentionBackup("b3", "depl", now.Add(-3*time.Hour), nil),
+	}
+
+	candidates := retentionExceededBackups("depl", backups, now, false)
+	require.Equal(t, []string{"b1"}, retentionCandidateNames(candidates))
+}
+
+func Test_Retention_Policies(t *testing.T) {
+	now := time.Now()
+	retention := &backupApi.ArangoBackupSpecRetention{
+		MaxCount: util.NewInt(1),
+	}
+
+	backups := []backupApi.ArangoBackup{
+		newRetentionBackup("a1", "depl", now.Add(-2*time.Hour), retention),
+		newRetentionBackup("a2", "depl", now.Add(-1*time.Hour), retention),
+		newRetentionBackup("b1", "depl", now.Add(-2*time.Hour), retention),
+		newRetentionBackup("b2", "depl", now.Add(-1*time.Hour), retention),
+	}
+
+	for id := range backups {
+		policy := backups[id].Name[:1]
+		backups[id].Spec.PolicyName = &policy
+	}
+
+	// Not Ready backup is not counted
+	failed := newRetentionBackup("a3", "depl", now, retention)
+	failed.Status.State = backupApi.ArangoBackupStateFailed
+	backups = append(backups, failed)
+
+	candidates := retentionExceededBackups("depl", backups, now, false)
+	require.Equal(t, []string{"a1", "b1"}, retentionCandidateNames(candidates))
+}
+
+func Test_Retention_Imported(t *testing.T) {
+	now := time.Now()
+	retention := &backupApi.ArangoBackupSpecRetention{
+		MaxCount: util.NewInt(1),
+	}
+
+	imported := newRetentionBackup("imported", "depl", now.Add(-1*time.Hour), nil)
+	imported.Status.Backup.Imported = util.NewBool(true)
+
+	importedOld := newRetentionBackup("imported-old", "depl", now.Add(-3*time.Hour), retention)
+	importedOld.Status.Backup.Imported = util.NewBool(true)
+
+	backups := []backupApi.ArangoBackup{
+		newRetentionBackup("b1", "depl", now.Add(-2*time.Hour), retention),
+		imported,
+		importedOld,
+	}
+
+	t.Run("Included", func(t *testing.T) {
+		candidates := retentionExceededBackups("depl", backups, now, false)
+		require.Equal(t, []string{"b1", "imported-old"}, retentionCandidateNames(candidates))
+	})
+
+	t.Run("Excluded", func(t *testing.T) {
+		candidates := retentionExceededBackups("depl", backups, now, true)
+		require.Len(t, candidates, 0)
+	})
+}
+
+func Test_Retention_RefreshDeployment(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	retention := &backupApi.ArangoBackupSpecRetention{
+		MaxCount: util.NewInt(2),
+	}
+
+	now := time.Now()
+
+	createArangoDeployment(t, handler, deployment)
+
+	var backups []backupApi.ArangoBackup
+	for i := 0; i < 3; i++ {
+		backup := newRetentionBackup(fmt.Sprintf("backup-%d", i), deployment.Name, now.Add(-time.Duration(i)*time.Hour), retention)
+		backup.Namespace = deployment.Namespace
+		createArangoBackup(t, handler, &backup)
+		backups = append(backups, backup)
+	}
+
+	// Act
+	result, err := handler.refreshDeployment(deployment)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Pruned)
+
+	// Assert
+	for _, backup := range backups[:2] {
+		_, err := handler.client.BackupV1().ArangoBackups(backup.Namespace).Get(backup.Name, meta.GetOptions{})
+		require.NoError(t, err)
+	}
+
+	_, err = handler.client.BackupV1().ArangoBackups(backups[2].Namespace).Get(backups[2].Name, meta.GetOptions{})
+	require.True(t, errors.IsNotFound(err))
+}
+
+func Test_Retention_Validation(t *testing.T) {
+	// Arrange
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	// Assert
+	obj.Spec.Retention = &backupApi.ArangoBackupSpecRetention{
+		MaxCount: util.NewInt(0),
+	}
+	require.EqualError(t, obj.Spec.Validate(), "retention max count needs to be greater than 0")
+
+	obj.Spec.Retention = &backupApi.ArangoBackupSpecRetention{
+		MaxAge: &meta.Duration{},
+	}
+	require.EqualError(t, obj.Spec.Validate(), "retention max age needs to be greater than 0")
+}
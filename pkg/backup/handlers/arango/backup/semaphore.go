@@ -0,0 +1,237 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// lockCheckInterval defines how often deployment locks are checked for being stuck
+const lockCheckInterval = time.Minute
+
+// deploymentSemaphore limits number of the backup operations running in parallel on one deployment.
+// Operations above the limit wait for the free slot.
+type deploymentSemaphore struct {
+	exclusive sync.Mutex
+	slots     chan struct{}
+
+	// stateLock protects held and progress
+	stateLock sync.Mutex
+	held      int
+	progress  time.Time
+}
+
+func newDeploymentSemaphore(limit int) *deploymentSemaphore {
+	if limit < 1 {
+		limit = 1
+	}
+
+	return &deploymentSemaphore{
+		slots: make(chan struct{}, limit),
+	}
+}
+
+// Acquire blocks until one slot is available
+func (s *deploymentSemaphore) Acquire() {
+	s.slots <- struct{}{}
+	s.acquired(1)
+}
+
+// Release releases slot taken by Acquire
+func (s *deploymentSemaphore) Release() {
+	s.released(1)
+	<-s.slots
+}
+
+// AcquireAll blocks until all slots are available, no other operation on the deployment runs until ReleaseAll is called
+func (s *deploymentSemaphore) AcquireAll() {
+	// Only one goroutine can collect slots at the time, otherwise two of them could wait for each other forever
+	s.exclusive.Lock()
+	defer s.exclusive.Unlock()
+
+	for i := 0; i < cap(s.slots); i++ {
+		s.slots <- struct{}{}
+	}
+
+	s.acquired(cap(s.slots))
+}
+
+// ReleaseAll releases slots taken by AcquireAll
+func (s *deploymentSemaphore) ReleaseAll() {
+	s.released(cap(s.slots))
+
+	for i := 0; i < cap(s.slots); i++ {
+		<-s.slots
+	}
+}
+
+func (s *deploymentSemaphore) acquired(slots int) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+
+	if s.held == 0 {
+		s.progress = time.Now()
+	}
+
+	s.held += slots
+}
+
+func (s *deploymentSemaphore) released(slots int) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+
+	s.held -= slots
+	s.progress = time.Now()
+}
+
+// heldFor returns how long the semaphore is held since the last finished operation, 0 if it is not held
+func (s *deploymentSemaphore) heldFor(now time.Time) time.Duration {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+
+	if s.held == 0 {
+		return 0
+	}
+
+	return now.Sub(s.progress)
+}
+
+func (h *handler) getGlobalSlots() chan struct{} {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.globalSlots == nil && h.config.GlobalConcurrency > 0 {
+		h.globalSlots = make(chan struct{}, h.config.GlobalConcurrency)
+	}
+
+	return h.globalSlots
+}
+
+// acquireGlobalSlot blocks until one of the operator wide slots is available.
+// Returns false if handler was stopped before the slot was acquired
+func (h *handler) acquireGlobalSlot() bool {
+	slots := h.getGlobalSlots()
+	if slots == nil {
+		return true
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-h.ctx.Done():
+		return false
+	}
+}
+
+// releaseGlobalSlot releases slot taken by acquireGlobalSlot
+func (h *handler) releaseGlobalSlot() {
+	if slots := h.getGlobalSlots(); slots != nil {
+		<-slots
+	}
+}
+
+func (h *handler) getDeploymentSemaphore(namespace, deployment string) *deploymentSemaphore {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.semaphores == nil {
+		h.semaphores = map[string]*deploymentSemaphore{}
+	}
+
+	name := fmt.Sprintf("%s/%s", namespace, deployment)
+
+	if _, ok := h.semaphores[name]; !ok {
+		h.semaphores[name] = newDeploymentSemaphore(h.config.DeploymentConcurrency)
+	}
+
+	return h.semaphores[name]
+}
+
+// ResetDeploymentLock replaces the lock of the deployment with a new one, so operations blocked by a stuck holder can continue.
+// Operations holding the old lock release it without effect. Returns false if the deployment has no lock.
+func (h *handler) ResetDeploymentLock(namespace, deployment string) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	name := fmt.Sprintf("%s/%s", namespace, deployment)
+
+	if _, ok := h.semaphores[name]; !ok {
+		return false
+	}
+
+	h.semaphores[name] = newDeploymentSemaphore(h.config.DeploymentConcurrency)
+
+	log.Warn().
+		Str("namespace", namespace).
+		Str("deployment", deployment).
+		Msgf("Lock of the deployment %s/%s was reset", namespace, deployment)
+
+	return true
+}
+
+// detectStuckLocks logs deployments which lock is held longer than threshold without any finished operation
+func (h *handler) detectStuckLocks(now time.Time) []string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	var stuck []string
+
+	for name, s := range h.semaphores {
+		held := s.heldFor(now)
+		if held <= h.config.LockWarningThreshold {
+			continue
+		}
+
+		stuck = append(stuck, name)
+
+		log.Warn().
+			Str("deployment", name).
+			Dur("held", held).
+			Msgf("Lock of the deployment %s is held for %s without any finished operation, it can be reset with the admin API", name, held)
+	}
+
+	return stuck
+}
+
+// watchLocks periodically reports stuck deployment locks until stopCh is closed
+func (h *handler) watchLocks(stopCh <-chan struct{}) {
+	if h.config.LockWarningThreshold == 0 {
+		return
+	}
+
+	t := time.NewTicker(lockCheckInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-t.C:
+			h.detectStuckLocks(time.Now())
+		}
+	}
+}
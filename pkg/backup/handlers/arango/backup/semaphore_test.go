@@ -0,0 +1,299 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const semaphoreWait = 100 * time.Millisecond
+
+func acquireAsync(acquire func()) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		acquire()
+		close(done)
+	}()
+
+	return done
+}
+
+func requireBlocked(t *testing.T, done <-chan struct{}) {
+	select {
+	case <-done:
+		require.Fail(t, "Acquire should be blocked")
+	case <-time.After(semaphoreWait):
+	}
+}
+
+func requireAcquired(t *testing.T, done <-chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "Acquire should not be blocked")
+	}
+}
+
+func Test_Semaphore_Limit(t *testing.T) {
+	s := newDeploymentSemaphore(2)
+
+	requireAcquired(t, acquireAsync(s.Acquire))
+	requireAcquired(t, acquireAsync(s.Acquire))
+
+	// Third operation is queued
+	third := acquireAsync(s.Acquire)
+	requireBlocked(t, third)
+
+	s.Release()
+	requireAcquired(t, third)
+}
+
+func Test_Semaphore_All(t *testing.T) {
+	s := newDeploymentSemaphore(2)
+
+	s.Acquire()
+
+	all := acquireAsync(s.AcquireAll)
+	requireBlocked(t, all)
+
+	s.Release()
+	requireAcquired(t, all)
+
+	// Nothing runs together with AcquireAll
+	next := acquireAsync(s.Acquire)
+	requireBlocked(t, next)
+
+	s.ReleaseAll()
+	requireAcquired(t, next)
+}
+
+func Test_Semaphore_InvalidLimit(t *testing.T) {
+	s := newDeploymentSemaphore(0)
+	require.Equal(t, 1, cap(s.slots))
+}
+
+func Test_Semaphore_PerDeployment(t *testing.T) {
+	handler := newFakeHandler()
+	handler.config.DeploymentConcurrency = 3
+
+	a := handler.getDeploymentSemaphore("ns", "a")
+	require.Equal(t, 3, cap(a.slots))
+	require.True(t, a == handler.getDeploymentSemaphore("ns", "a"))
+	require.False(t, a == handler.getDeploymentSemaphore("ns", "b"))
+	require.False(t, a == handler.getDeploymentSemaphore("other", "a"))
+}
+
+func Test_Semaphore_HeldFor(t *testing.T) {
+	s := newDeploymentSemaphore(2)
+
+	now := time.Now()
+	require.Equal(t, time.Duration(0), s.heldFor(now))
+
+	s.Acquire()
+	s.Acquire()
+	require.True(t, s.heldFor(now.Add(time.Hour)) > 59*time.Minute)
+
+	// Finished operation is a progress
+	s.Release()
+	require.True(t, s.heldFor(time.Now()) < time.Minute)
+
+	s.Release()
+	require.Equal(t, time.Duration(0), s.heldFor(now.Add(time.Hour)))
+}
+
+func Test_Semaphore_DetectStuckLocks(t *testing.T) {
+	handler := newFakeHandler()
+	handler.config.LockWarningThreshold = time.Minute
+
+	handler.getDeploymentSemaphore("ns", "stuck").Acquire()
+	handler.getDeploymentSemaphore("ns", "idle")
+
+	require.Empty(t, handler.detectStuckLocks(time.Now()))
+	require.Equal(t, []string{"ns/stuck"}, handler.detectStuckLocks(time.Now().Add(time.Hour)))
+}
+
+func Test_Semaphore_ResetDeploymentLock(t *testing.T) {
+	handler := newFakeHandler()
+
+	require.False(t, handler.ResetDeploymentLock("ns", "a"))
+
+	stuck := handler.getDeploymentSemaphore("ns", "a")
+	stuck.Acquire()
+
+	blocked := acquireAsync(handler.getDeploymentSemaphore("ns", "a").Acquire)
+	requireBlocked(t, blocked)
+
+	require.True(t, handler.ResetDeploymentLock("ns", "a"))
+
+	// New operations use new lock
+	s := handler.getDeploymentSemaphore("ns", "a")
+	require.False(t, s == stuck)
+	requireAcquired(t, acquireAsync(s.Acquire))
+
+	// Stuck holder releases old lock without effect on the new one
+	stuck.Release()
+	requireAcquired(t, blocked)
+	require.Equal(t, 1, len(s.slots))
+}
+
+func Test_Semaphore_GlobalSlots(t *testing.T) {
+	handler := newFakeHandler()
+	handler.config.GlobalConcurrency = 2
+
+	acquire := func() {
+		require.True(t, handler.acquireGlobalSlot())
+	}
+
+	requireAcquired(t, acquireAsync(acquire))
+	requireAcquired(t, acquireAsync(acquire))
+
+	// Third operation is queued
+	third := acquireAsync(acquire)
+	requireBlocked(t, third)
+
+	handler.releaseGlobalSlot()
+	requireAcquired(t, third)
+}
+
+func Test_Semaphore_GlobalSlots_Disabled(t *testing.T) {
+	handler := newFakeHandler()
+
+	for i := 0; i < 10; i++ {
+		require.True(t, handler.acquireGlobalSlot())
+	}
+
+	handler.releaseGlobalSlot()
+	require.Nil(t, handler.globalSlots)
+}
+
+func Test_Semaphore_GlobalSlots_Stop(t *testing.T) {
+	handler := newFakeHandler()
+	handler.config.GlobalConcurrency = 1
+
+	require.True(t, handler.acquireGlobalSlot())
+
+	var acquired bool
+	blocked := acquireAsync(func() {
+		acquired = handler.acquireGlobalSlot()
+	})
+	requireBlocked(t, blocked)
+
+	// Stopped handler does not wait for the slot
+	handler.cancel()
+	requireAcquired(t, blocked)
+	require.False(t, acquired)
+}
+
+func Test_Semaphore_GlobalSlots_Handle(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.GlobalConcurrency = 1
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.True(t, handler.acquireGlobalSlot())
+
+	// Act
+	blocked := acquireAsync(func() {
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	})
+
+	// Assert
+	requireBlocked(t, blocked)
+	checkBackup(t, refreshArangoBackup(t, handler, obj), backupApi.ArangoBackupStateNone, false)
+
+	handler.releaseGlobalSlot()
+	requireAcquired(t, blocked)
+	checkBackup(t, refreshArangoBackup(t, handler, obj), backupApi.ArangoBackupStatePending, false)
+}
+
+func Test_Semaphore_PendingIsExclusive(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.DeploymentConcurrency = 2
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	s := handler.getDeploymentSemaphore(obj.Namespace, obj.Spec.Deployment.Name)
+	s.Acquire()
+
+	// Act
+	blocked := acquireAsync(func() {
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	})
+
+	// Assert
+	requireBlocked(t, blocked)
+	checkBackup(t, refreshArangoBackup(t, handler, obj), backupApi.ArangoBackupStatePending, false)
+
+	s.Release()
+	requireAcquired(t, blocked)
+	checkBackup(t, refreshArangoBackup(t, handler, obj), backupApi.ArangoBackupStateScheduled, false)
+}
+
+func Test_Semaphore_ParallelPending(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.DeploymentConcurrency = 4
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+	createArangoDeployment(t, handler, deployment)
+
+	backups := make([]*backupApi.ArangoBackup, 4)
+	for id := range backups {
+		backups[id] = newArangoBackup(deployment.GetName(), deployment.GetNamespace(), string(uuid.NewUUID()), backupApi.ArangoBackupStatePending)
+		createArangoBackup(t, handler, backups[id])
+	}
+
+	// Act
+	var wg sync.WaitGroup
+	for _, obj := range backups {
+		wg.Add(1)
+		go func(obj *backupApi.ArangoBackup) {
+			defer wg.Done()
+			require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+		}(obj)
+	}
+	wg.Wait()
+
+	// Assert
+	scheduled := 0
+	for _, obj := range backups {
+		if refreshArangoBackup(t, handler, obj).Status.State == backupApi.ArangoBackupStateScheduled {
+			scheduled++
+		}
+	}
+	require.Equal(t, 1, scheduled)
+}
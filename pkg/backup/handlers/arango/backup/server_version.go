@@ -0,0 +1,40 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"github.com/arangodb/go-driver"
+	"github.com/rs/zerolog/log"
+)
+
+// serverVersion returns the ArangoDB server version of the deployment.
+// Errors are only logged, missing version must not fail the backup
+func (h *handler) serverVersion(client ArangoBackupClient) driver.Version {
+	version, err := client.ServerVersion(h.ctx)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Unable to fetch server version")
+		return ""
+	}
+
+	return version
+}
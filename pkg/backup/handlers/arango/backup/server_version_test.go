@@ -0,0 +1,96 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ServerVersion_Create(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+	require.NotNil(t, newObj.Status.Backup)
+	require.Equal(t, mockVersion, newObj.Status.Backup.Version)
+	require.Equal(t, mockServerVersion, newObj.Status.Backup.ServerVersion)
+}
+
+func Test_ServerVersion_Create_Error(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{
+		serverVersionError: fmt.Errorf("version error"),
+	})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+	require.NotNil(t, newObj.Status.Backup)
+	require.Empty(t, newObj.Status.Backup.ServerVersion)
+}
+
+func Test_ServerVersion_Import(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	_, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
+	createArangoDeployment(t, handler, deployment)
+
+	backupMeta, err := mock.Create(handler.ctx)
+	require.NoError(t, err)
+
+	// Act
+	_, err = handler.refreshDeploymentBackup(deployment, mock, backupMeta.BackupMeta, nil, nil)
+	require.NoError(t, err)
+
+	// Assert
+	backups, err := handler.client.BackupV1().ArangoBackups(deployment.Namespace).List(meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, backups.Items, 1)
+	require.NotNil(t, backups.Items[0].Status.Backup)
+	require.Equal(t, mockServerVersion, backups.Items[0].Status.Backup.ServerVersion)
+}
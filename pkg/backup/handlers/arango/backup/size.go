@@ -0,0 +1,57 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/rs/zerolog/log"
+)
+
+// rejectOversizedBackup marks backup which exceeded size limit as Failed.
+// Backup is removed from the deployment if requested in the spec, details are kept to record its size.
+func (h *handler) rejectOversizedBackup(backup *backupApi.ArangoBackup, client ArangoBackupClient, backupMeta driver.BackupMeta, max uint64) (*backupApi.ArangoBackupStatus, error) {
+	message := fmt.Sprintf("backup size %d bytes exceeds limit of %d bytes", backupMeta.SizeInBytes, max)
+
+	if backup.Spec.Options.GetDeleteIfSizeExceeded() {
+		if err := client.Delete(h.ctx, backupMeta.ID); err != nil {
+			log.Warn().Err(err).
+				Str("namespace", backup.Namespace).
+				Str("name", backup.Name).
+				Msgf("Unable to delete oversized backup %s", backupMeta.ID)
+		} else {
+			message = fmt.Sprintf("%s, backup deleted", message)
+		}
+	}
+
+	h.eventRecorder.Warning(backup, BackupSizeExceeded, "Backup %s: %s", backupMeta.ID, message)
+
+	return wrapUpdateStatus(backup,
+		updateStatusState(backupApi.ArangoBackupStateFailed, message),
+		updateStatusAvailable(false),
+		updateStatusBackup(backupMeta),
+		cleanStatusJob(),
+	)
+}
@@ -0,0 +1,116 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Size_Exceeded(t *testing.T) {
+	run := func(t *testing.T, size, max uint64, delete bool) (*handler, *mockArangoClientBackup, *backupApi.ArangoBackup) {
+		// Arrange
+		handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+		mock.state.size = size
+
+		obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+		obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+			MaxSizeBytes:         util.NewUInt64(max),
+			DeleteIfSizeExceeded: util.NewBool(delete),
+		}
+
+		// Act
+		createArangoDeployment(t, handler, deployment)
+		createArangoBackup(t, handler, obj)
+
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		return handler, mock, refreshArangoBackup(t, handler, obj)
+	}
+
+	t.Run("Below limit", func(t *testing.T) {
+		_, _, newObj := run(t, 1024, 1024, false)
+
+		// Assert
+		checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+		require.Equal(t, uint64(1024), newObj.Status.Backup.SizeInBytes)
+	})
+
+	t.Run("Exceeded", func(t *testing.T) {
+		handler, mock, newObj := run(t, 2048, 1024, false)
+
+		// Assert
+		checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, false)
+		require.Equal(t, "backup size 2048 bytes exceeds limit of 1024 bytes", newObj.Status.Message)
+		require.NotNil(t, newObj.Status.Backup)
+		require.Equal(t, uint64(2048), newObj.Status.Backup.SizeInBytes)
+
+		exists, err := mock.Exists(context.Background(), driver.BackupID(newObj.Status.Backup.ID))
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		events, err := handler.kubeClient.CoreV1().Events(newObj.Namespace).List(meta.ListOptions{})
+		require.NoError(t, err)
+
+		var found bool
+		for _, event := range events.Items {
+			if event.Reason == BackupSizeExceeded {
+				found = true
+				require.Equal(t, core.EventTypeWarning, event.Type)
+			}
+		}
+		require.True(t, found)
+	})
+
+	t.Run("Exceeded with delete", func(t *testing.T) {
+		_, mock, newObj := run(t, 2048, 1024, true)
+
+		// Assert
+		checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, false)
+		require.Equal(t, "backup size 2048 bytes exceeds limit of 1024 bytes, backup deleted", newObj.Status.Message)
+
+		exists, err := mock.Exists(context.Background(), driver.BackupID(newObj.Status.Backup.ID))
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+}
+
+func Test_Size_Validation(t *testing.T) {
+	backup, _ := newObjectSet(backupApi.ArangoBackupStateNone)
+	backup.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+		MaxSizeBytes: util.NewUInt64(0),
+	}
+
+	require.EqualError(t, backup.Spec.Validate(), "max size bytes needs to be greater than 0")
+
+	backup.Spec.Options.MaxSizeBytes = util.NewUInt64(1)
+	require.NoError(t, backup.Spec.Validate())
+}
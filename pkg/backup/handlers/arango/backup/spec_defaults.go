@@ -0,0 +1,146 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"strconv"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/rs/zerolog/log"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// applySpecDefaults returns copy of the backup with defaults applied to values which are not set
+// in its spec. Deployment level defaults take precedence over operator level defaults and values
+// from the spec always take precedence. Defaults are not persisted in the ArangoBackup object.
+func (h *handler) applySpecDefaults(backup *backupApi.ArangoBackup) *backupApi.ArangoBackup {
+	if backup.Status.IsImported() {
+		return backup
+	}
+
+	defaults := h.specDefaults(backup)
+	obj := backup.DeepCopy()
+
+	if defaults.Timeout > 0 && (obj.Spec.Options == nil || obj.Spec.Options.Timeout == nil) {
+		if obj.Spec.Options == nil {
+			obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{}
+		}
+
+		timeout := float32(defaults.Timeout.Seconds())
+		obj.Spec.Options.Timeout = &timeout
+	}
+
+	if defaults.AllowInconsistent && obj.Spec.ConsistencyLevel == nil &&
+		(obj.Spec.Options == nil || obj.Spec.Options.AllowInconsistent == nil) {
+		if obj.Spec.Options == nil {
+			obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{}
+		}
+
+		obj.Spec.Options.AllowInconsistent = util.NewBool(true)
+	}
+
+	if defaults.StateTimeout > 0 {
+		if _, ok := obj.Spec.Options.GetStateTimeout(); !ok {
+			if obj.Spec.Options == nil {
+				obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{}
+			}
+
+			obj.Spec.Options.StateTimeoutSeconds = util.NewInt(int(defaults.StateTimeout.Seconds()))
+		}
+	}
+
+	if upload := obj.Spec.Upload; upload != nil && upload.RepositoryURL == "" && defaults.UploadRepositoryURL != "" {
+		// Credentials are defaulted only together with the repository they belong to
+		upload.RepositoryURL = defaults.UploadRepositoryURL
+
+		if upload.CredentialsSecretName == "" {
+			upload.CredentialsSecretName = defaults.UploadCredentialsSecretName
+		}
+	}
+
+	return obj
+}
+
+// specDefaults returns operator level defaults overridden by annotations of the backup deployment.
+// Operator level defaults are used if the deployment can not be fetched.
+func (h *handler) specDefaults(backup *backupApi.ArangoBackup) SpecDefaults {
+	if backup.Spec.Deployment.Name == "" || backup.Spec.Deployment.ValidateNamespace(backup.Namespace) != nil {
+		return h.config.Defaults
+	}
+
+	deployment, err := h.client.DatabaseV1().ArangoDeployments(backup.Namespace).Get(backup.Spec.Deployment.Name, meta.GetOptions{})
+	if err != nil {
+		return h.config.Defaults
+	}
+
+	return deploymentSpecDefaults(deployment, h.config.Defaults)
+}
+
+// deploymentSpecDefaults returns defaults overridden by annotations of the deployment, invalid annotations are ignored
+func deploymentSpecDefaults(deployment *database.ArangoDeployment, defaults SpecDefaults) SpecDefaults {
+	annotations := deployment.Annotations
+
+	if value, ok := annotations[backupApi.AnnotationArangoDeploymentDefaultTimeout]; ok {
+		if timeout, err := time.ParseDuration(value); err != nil || timeout <= 0 {
+			log.Warn().Err(err).Msgf("Invalid default timeout %s of %s/%s, using %s", value, deployment.Namespace, deployment.Name, defaults.Timeout)
+		} else {
+			defaults.Timeout = timeout
+		}
+	}
+
+	if value, ok := annotations[backupApi.AnnotationArangoDeploymentDefaultAllowInconsistent]; ok {
+		if allow, err := strconv.ParseBool(value); err != nil {
+			log.Warn().Err(err).Msgf("Invalid default allow inconsistent %s of %s/%s, using %t", value, deployment.Namespace, deployment.Name, defaults.AllowInconsistent)
+		} else {
+			defaults.AllowInconsistent = allow
+		}
+	}
+
+	if value, ok := annotations[backupApi.AnnotationArangoDeploymentDefaultStateTimeout]; ok {
+		if timeout, err := time.ParseDuration(value); err != nil || timeout < time.Second {
+			log.Warn().Err(err).Msgf("Invalid default state timeout %s of %s/%s, using %s", value, deployment.Namespace, deployment.Name, defaults.StateTimeout)
+		} else {
+			defaults.StateTimeout = timeout
+		}
+	}
+
+	if value, ok := annotations[backupApi.AnnotationArangoDeploymentDefaultUploadRepositoryURL]; ok {
+		// Operator credentials belong to the operator repository
+		defaults.UploadRepositoryURL = value
+		defaults.UploadCredentialsSecretName = ""
+	}
+
+	if value, ok := annotations[backupApi.AnnotationArangoDeploymentDefaultUploadCredentialsSecretName]; ok {
+		if err := k8sutil.ValidateResourceName(value); err != nil {
+			log.Warn().Err(err).Msgf("Invalid default upload credentials secret name %s of %s/%s", value, deployment.Namespace, deployment.Name)
+		} else {
+			defaults.UploadCredentialsSecretName = value
+		}
+	}
+
+	return defaults
+}
@@ -0,0 +1,208 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newSpecDefaultsHandler() *handler {
+	handler := newFakeHandler()
+	handler.config.Defaults = SpecDefaults{
+		Timeout:                     time.Minute,
+		AllowInconsistent:           true,
+		UploadRepositoryURL:         "s3://default",
+		UploadCredentialsSecretName: "default-credentials",
+	}
+
+	return handler
+}
+
+func Test_SpecDefaults_Operator(t *testing.T) {
+	// Arrange
+	handler := newSpecDefaultsHandler()
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{}
+
+	// Act
+	effective := handler.applySpecDefaults(obj)
+
+	// Assert
+	require.NotNil(t, effective.Spec.Options)
+	require.NotNil(t, effective.Spec.Options.Timeout)
+	require.Equal(t, float32(60), *effective.Spec.Options.Timeout)
+	require.True(t, effective.Spec.GetAllowInconsistent())
+	require.Equal(t, "s3://default", effective.Spec.Upload.RepositoryURL)
+	require.Equal(t, "default-credentials", effective.Spec.Upload.CredentialsSecretName)
+
+	// Defaults are not applied to the original object
+	require.Nil(t, obj.Spec.Options)
+	require.Empty(t, obj.Spec.Upload.RepositoryURL)
+}
+
+func Test_SpecDefaults_BackupPrecedence(t *testing.T) {
+	// Arrange
+	handler := newSpecDefaultsHandler()
+
+	timeout := float32(5)
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+		Timeout:           &timeout,
+		AllowInconsistent: util.NewBool(false),
+	}
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://backup",
+	}
+
+	// Act
+	effective := handler.applySpecDefaults(obj)
+
+	// Assert
+	require.Equal(t, timeout, *effective.Spec.Options.Timeout)
+	require.False(t, effective.Spec.GetAllowInconsistent())
+	require.Equal(t, "s3://backup", effective.Spec.Upload.RepositoryURL)
+	require.Empty(t, effective.Spec.Upload.CredentialsSecretName)
+
+	// Consistency level takes precedence over default
+	obj.Spec.Options = nil
+	obj.Spec.ConsistencyLevel = newConsistencyLevel(backupApi.ArangoBackupConsistencyLevelStrict)
+
+	effective = handler.applySpecDefaults(obj)
+	require.False(t, effective.Spec.GetAllowInconsistent())
+}
+
+func Test_SpecDefaults_DeploymentPrecedence(t *testing.T) {
+	// Arrange
+	handler := newSpecDefaultsHandler()
+	handler.config.Defaults.StateTimeout = time.Hour
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{}
+	deployment.Annotations = map[string]string{
+		backupApi.AnnotationArangoDeploymentDefaultTimeout:             "2m",
+		backupApi.AnnotationArangoDeploymentDefaultAllowInconsistent:   "false",
+		backupApi.AnnotationArangoDeploymentDefaultUploadRepositoryURL: "s3://deployment",
+	}
+
+	createArangoDeployment(t, handler, deployment)
+
+	// Act
+	effective := handler.applySpecDefaults(obj)
+
+	// Assert - deployment overrides operator
+	require.Equal(t, float32(120), *effective.Spec.Options.Timeout)
+	require.False(t, effective.Spec.GetAllowInconsistent())
+	require.Equal(t, "s3://deployment", effective.Spec.Upload.RepositoryURL)
+	require.Empty(t, effective.Spec.Upload.CredentialsSecretName)
+
+	// Assert - operator is used where deployment is not set
+	stateTimeout, ok := effective.Spec.Options.GetStateTimeout()
+	require.True(t, ok)
+	require.Equal(t, time.Hour, stateTimeout)
+
+	// Act - backup overrides deployment
+	timeout := float32(5)
+	obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+		Timeout: &timeout,
+	}
+	obj.Spec.Upload.RepositoryURL = "s3://backup"
+
+	effective = handler.applySpecDefaults(obj)
+
+	// Assert
+	require.Equal(t, timeout, *effective.Spec.Options.Timeout)
+	require.Equal(t, "s3://backup", effective.Spec.Upload.RepositoryURL)
+}
+
+func Test_SpecDefaults_DeploymentInvalid(t *testing.T) {
+	// Arrange
+	handler := newSpecDefaultsHandler()
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{}
+	deployment.Annotations = map[string]string{
+		backupApi.AnnotationArangoDeploymentDefaultTimeout:                     "invalid",
+		backupApi.AnnotationArangoDeploymentDefaultAllowInconsistent:           "invalid",
+		backupApi.AnnotationArangoDeploymentDefaultStateTimeout:                "500ms",
+		backupApi.AnnotationArangoDeploymentDefaultUploadCredentialsSecretName: "Invalid_Name",
+	}
+
+	createArangoDeployment(t, handler, deployment)
+
+	// Act
+	effective := handler.applySpecDefaults(obj)
+
+	// Assert - operator defaults are used
+	require.Equal(t, float32(60), *effective.Spec.Options.Timeout)
+	require.True(t, effective.Spec.GetAllowInconsistent())
+	_, ok := effective.Spec.Options.GetStateTimeout()
+	require.False(t, ok)
+	require.Equal(t, "s3://default", effective.Spec.Upload.RepositoryURL)
+	require.Equal(t, "default-credentials", effective.Spec.Upload.CredentialsSecretName)
+}
+
+func Test_SpecDefaults_Imported(t *testing.T) {
+	// Arrange
+	handler := newSpecDefaultsHandler()
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateReady)
+	obj.Status.Backup = &backupApi.ArangoBackupDetails{
+		ID:       "id",
+		Imported: util.NewBool(true),
+	}
+
+	// Act
+	effective := handler.applySpecDefaults(obj)
+
+	// Assert
+	require.Nil(t, effective.Spec.Options)
+	require.NoError(t, effective.ValidateImported())
+}
+
+func Test_SpecDefaults_Handle(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.Defaults.UploadRepositoryURL = "s3://default"
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateScheduled, false)
+	require.Empty(t, newObj.Spec.Upload.RepositoryURL)
+}
@@ -0,0 +1,82 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"time"
+)
+
+const (
+	// refreshStaleIntervals defines number of refresh intervals without successful refresh after which handler is stale
+	refreshStaleIntervals = 3
+)
+
+// markRefreshStarted records start of the refresh loop, staleness is measured from it until first successful refresh
+func (h *handler) markRefreshStarted(t time.Time) {
+	h.refreshLock.Lock()
+	defer h.refreshLock.Unlock()
+
+	h.refreshStarted = t
+}
+
+// markRefreshed records time of the successful refresh
+func (h *handler) markRefreshed(t time.Time) {
+	h.refreshLock.Lock()
+	defer h.refreshLock.Unlock()
+
+	h.lastRefresh = t
+	h.metrics.lastRefresh.Set(float64(t.Unix()))
+}
+
+// LastRefresh returns time of the last successful refresh, zero time if refresh did not succeed yet
+func (h *handler) LastRefresh() time.Time {
+	h.refreshLock.Lock()
+	defer h.refreshLock.Unlock()
+
+	return h.lastRefresh
+}
+
+// IsRefreshStale returns true if no successful refresh happened within the last refreshStaleIntervals intervals.
+// Handler with disabled or not yet started periodic refresh is never stale.
+func (h *handler) IsRefreshStale(now time.Time) bool {
+	if h.config.RefreshInterval == 0 {
+		return false
+	}
+
+	h.refreshLock.Lock()
+	defer h.refreshLock.Unlock()
+
+	last := h.lastRefresh
+	if last.IsZero() {
+		last = h.refreshStarted
+	}
+
+	if last.IsZero() {
+		return false
+	}
+
+	// Jitter can extend every interval
+	stale := time.Duration(float64(refreshStaleIntervals) * (1 + h.config.RefreshJitter) * float64(h.config.RefreshInterval))
+
+	return now.Sub(last) > stale
+}
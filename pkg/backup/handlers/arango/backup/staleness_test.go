@@ -0,0 +1,98 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RefreshStaleness(t *testing.T) {
+	handler := newFakeHandler()
+	handler.config.RefreshInterval = time.Minute
+	handler.config.RefreshJitter = 0
+
+	now := time.Now()
+
+	t.Run("Not started", func(t *testing.T) {
+		require.False(t, handler.IsRefreshStale(now))
+		require.True(t, handler.LastRefresh().IsZero())
+	})
+
+	t.Run("Started without refresh", func(t *testing.T) {
+		handler.markRefreshStarted(now.Add(-2 * time.Minute))
+		require.False(t, handler.IsRefreshStale(now))
+
+		handler.markRefreshStarted(now.Add(-4 * time.Minute))
+		require.True(t, handler.IsRefreshStale(now))
+	})
+
+	t.Run("Refreshed", func(t *testing.T) {
+		handler.markRefreshed(now.Add(-time.Minute))
+
+		require.False(t, handler.IsRefreshStale(now))
+		require.Equal(t, now.Add(-time.Minute), handler.LastRefresh())
+		require.Equal(t, float64(now.Add(-time.Minute).Unix()), testutil.ToFloat64(handler.metrics.lastRefresh))
+	})
+
+	t.Run("Refresh outdated", func(t *testing.T) {
+		require.True(t, handler.IsRefreshStale(now.Add(3*time.Minute)))
+	})
+
+	t.Run("Jitter extends intervals", func(t *testing.T) {
+		handler.config.RefreshJitter = 0.5
+
+		require.False(t, handler.IsRefreshStale(now.Add(3*time.Minute)))
+		require.True(t, handler.IsRefreshStale(now.Add(4*time.Minute)))
+	})
+
+	t.Run("Refresh disabled", func(t *testing.T) {
+		handler.config.RefreshInterval = 0
+
+		require.False(t, handler.IsRefreshStale(now.Add(time.Hour)))
+	})
+}
+
+func Test_RefreshStaleness_Metric(t *testing.T) {
+	handler := newFakeHandler()
+	handler.config.RefreshInterval = time.Minute
+	handler.config.RefreshJitter = 0
+
+	collect := func() {
+		ch := make(chan prometheus.Metric, 64)
+		handler.Collect(ch)
+		close(ch)
+	}
+
+	handler.markRefreshStarted(time.Now().Add(-4 * time.Minute))
+	collect()
+	require.Equal(t, float64(1), testutil.ToFloat64(handler.metrics.refreshStale))
+
+	handler.markRefreshed(time.Now())
+	collect()
+	require.Equal(t, float64(0), testutil.ToFloat64(handler.metrics.refreshStale))
+}
@@ -43,7 +43,12 @@ var (
 		backupApi.ArangoBackupStateDownloadError: stateDownloadErrorHandler,
 		backupApi.ArangoBackupStateReady:         stateReadyHandler,
 		backupApi.ArangoBackupStateDeleted:       stateDeletedHandler,
+		backupApi.ArangoBackupStateDeleting:      stateDeletingHandler,
 		backupApi.ArangoBackupStateFailed:        stateFailedHandler,
 		backupApi.ArangoBackupStateUnavailable:   stateUnavailableHandler,
+
+		backupApi.ArangoBackupStateWaitingForCredentials: stateWaitingForCredentialsHandler,
+		backupApi.ArangoBackupStateRejected:              stateRejectedHandler,
+		backupApi.ArangoBackupStateValidationOnly:        stateValidationOnlyHandler,
 	}
 )
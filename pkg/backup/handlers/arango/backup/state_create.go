@@ -23,6 +23,8 @@
 package backup
 
 import (
+	"time"
+
 	"github.com/arangodb/go-driver"
 	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
 )
@@ -33,17 +35,33 @@ func stateCreateHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi.
 		return nil, err
 	}
 
+	if status, err := h.waitForCredentials(backup, deployment); err != nil || status != nil {
+		return status, err
+	}
+
+	if status, err := waitForDeploymentReady(backup, deployment); err != nil || status != nil {
+		return status, err
+	}
+
 	client, err := h.arangoClientFactory(deployment, backup)
 	if err != nil {
 		return nil, newTemporaryError(err)
 	}
 
-	response, err := client.Create()
+	if err := checkUserPrivileges(h.ctx, backup, client); err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+
+	response, err := client.Create(h.ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	backupMeta, err := client.Get(response.ID)
+	duration := time.Since(started)
+
+	backupMeta, err := client.Get(h.ctx, response.ID)
 	if err != nil {
 		if driver.IsNotFound(err) {
 			return wrapUpdateStatus(backup,
@@ -56,9 +74,26 @@ func stateCreateHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi.
 		return nil, newFatalError(err)
 	}
 
+	if max, ok := backup.Spec.Options.GetMaxSizeBytes(); ok && backupMeta.SizeInBytes > max {
+		return h.rejectOversizedBackup(backup, client, backupMeta, max)
+	}
+
+	manifest := h.recordManifest(backup, client)
+
+	// Make inconsistency visible in the state message and StateChange event
+	message := ""
+	if backupMeta.PotentiallyInconsistent {
+		message = "backup is potentially inconsistent"
+	}
+
 	return wrapUpdateStatus(backup,
-		updateStatusState(backupApi.ArangoBackupStateReady, ""),
+		updateStatusState(backupApi.ArangoBackupStateReady, message),
 		updateStatusAvailable(true),
 		updateStatusBackup(backupMeta),
+		updateStatusBackupServerVersion(h.serverVersion(client)),
+		updateStatusBackupDuration(duration),
+		updateStatusBackupLabel(backup.Spec.Options.GetLabel()),
+		updateStatusEncryptionSecretVersion(h.encryptionSecretVersion(deployment)),
+		updateStatusManifest(manifest),
 	)
 }
@@ -23,7 +23,10 @@
 package backup
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/arangodb/go-driver"
 	"github.com/arangodb/kube-arangodb/pkg/util"
@@ -31,6 +34,7 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
 
 	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
 	"github.com/stretchr/testify/require"
 )
 
@@ -58,12 +62,34 @@ func Test_State_Create_Success(t *testing.T) {
 	backups := mock.getIDs()
 	require.Len(t, backups, 1)
 
-	backupMeta, err := mock.Get(driver.BackupID(backups[0]))
+	backupMeta, err := mock.Get(context.Background(), driver.BackupID(backups[0]))
 	require.NoError(t, err)
 
 	compareBackupMeta(t, backupMeta, newObj)
 }
 
+func Test_State_Create_Duration(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	mock.state.createDelay = 100 * time.Millisecond
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.NotNil(t, newObj.Status.Backup.DurationSeconds)
+	require.True(t, *newObj.Status.Backup.DurationSeconds >= 0.1)
+	require.True(t, *newObj.Status.Backup.DurationSeconds < 5)
+}
+
 func Test_State_Create_SuccessForced(t *testing.T) {
 	// Arrange
 	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
@@ -86,7 +112,7 @@ func Test_State_Create_SuccessForced(t *testing.T) {
 	backups := mock.getIDs()
 	require.Len(t, backups, 1)
 
-	backupMeta, err := mock.Get(driver.BackupID(backups[0]))
+	backupMeta, err := mock.Get(context.Background(), driver.BackupID(backups[0]))
 	require.NoError(t, err)
 
 	compareBackupMeta(t, backupMeta, newObj)
@@ -116,7 +142,7 @@ func Test_State_Create_Upload(t *testing.T) {
 	backups := mock.getIDs()
 	require.Len(t, backups, 1)
 
-	backupMeta, err := mock.Get(driver.BackupID(backups[0]))
+	backupMeta, err := mock.Get(context.Background(), driver.BackupID(backups[0]))
 	require.NoError(t, err)
 
 	compareBackupMeta(t, backupMeta, newObj)
@@ -168,3 +194,67 @@ func Test_State_Create_TemporaryCreateFailed(t *testing.T) {
 
 	require.Equal(t, obj.Status, newObj.Status)
 }
+
+func Test_State_Create_WaitingForDeployment(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	deployment.Status.Conditions = nil
+	deployment.Status.Conditions.Update(database.ConditionTypeReady, false, "Pod Not Ready", "")
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateCreate, false)
+	require.Equal(t, fmt.Sprintf("WaitingForDeployment: deployment %s is not Ready: Pod Not Ready", deployment.Name), newObj.Status.Message)
+	require.Len(t, mock.getIDs(), 0)
+
+	// Act
+	deployment.Status.Conditions.Update(database.ConditionTypeReady, true, "", "")
+	_, err := handler.client.DatabaseV1().ArangoDeployments(deployment.Namespace).Update(deployment)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+	require.Len(t, mock.getIDs(), 1)
+}
+
+func Test_State_Create_WaitingForDeployment_Requeue(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.RequeueBaseDelay = 10 * time.Millisecond
+	handler.config.RequeueMaxDelay = 40 * time.Millisecond
+	handler.requeueLimiter = newRequeueLimiter(handler.config)
+
+	operatorMock := &requeueOperatorMock{}
+	handler.operator = operatorMock
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	deployment.Status.Conditions = nil
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	// Act
+	for i := 0; i < 3; i++ {
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	}
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateCreate, false)
+	require.Equal(t, fmt.Sprintf("WaitingForDeployment: deployment %s is not Ready", deployment.Name), newObj.Status.Message)
+	require.Len(t, mock.getIDs(), 0)
+
+	require.Equal(t, 0, operatorMock.immediate)
+	require.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond}, operatorMock.delays)
+}
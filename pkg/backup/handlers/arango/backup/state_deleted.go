@@ -39,7 +39,7 @@ func stateDeletedHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi
 	}
 
 	if backup.Status.Backup != nil {
-		backupMeta, err := client.Get(driver.BackupID(backup.Status.Backup.ID))
+		backupMeta, err := client.Get(h.ctx, driver.BackupID(backup.Status.Backup.ID))
 		if err == nil {
 			return wrapUpdateStatus(backup,
 				updateStatusState(backupApi.ArangoBackupStateReady, ""),
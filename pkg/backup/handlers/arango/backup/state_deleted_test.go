@@ -23,6 +23,7 @@
 package backup
 
 import (
+	"context"
 	"testing"
 
 	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
@@ -55,10 +56,10 @@ func Test_State_Deleted_Recover(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateDeleted)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
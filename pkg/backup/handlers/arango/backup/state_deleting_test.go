@@ -0,0 +1,58 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_State_Deleting(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateDeleting)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, newObj.Status, obj.Status)
+}
+
+func Test_State_Deleting_Transitions(t *testing.T) {
+	require.NoError(t, backupApi.ArangoBackupStateMap.Transit(backupApi.ArangoBackupStateReady, backupApi.ArangoBackupStateDeleting))
+	require.NoError(t, backupApi.ArangoBackupStateMap.Transit(backupApi.ArangoBackupStateFailed, backupApi.ArangoBackupStateDeleting))
+
+	require.Error(t, backupApi.ArangoBackupStateMap.Transit(backupApi.ArangoBackupStatePending, backupApi.ArangoBackupStateDeleting))
+	require.Error(t, backupApi.ArangoBackupStateMap.Transit(backupApi.ArangoBackupStateUploading, backupApi.ArangoBackupStateDeleting))
+	require.Error(t, backupApi.ArangoBackupStateMap.Transit(backupApi.ArangoBackupStateDeleting, backupApi.ArangoBackupStateReady))
+}
@@ -34,6 +34,10 @@ func stateDownloadHandler(h *handler, backup *backupApi.ArangoBackup) (*backupAp
 		return nil, err
 	}
 
+	if status, err := h.waitForCredentials(backup, deployment); err != nil || status != nil {
+		return status, err
+	}
+
 	client, err := h.arangoClientFactory(deployment, backup)
 	if err != nil {
 		return nil, newTemporaryError(err)
@@ -57,7 +61,18 @@ func stateDownloadHandler(h *handler, backup *backupApi.ArangoBackup) (*backupAp
 		)
 	}
 
-	jobID, err := client.Download(driver.BackupID(backup.Spec.Download.ID))
+	if secret, err := h.missingTransferCredentialsSecret(backup.Namespace, backup.Spec.Download.ArangoBackupSpecOperation); err != nil {
+		return nil, err
+	} else if secret != "" {
+		return wrapUpdateStatus(backup,
+			updateStatusState(backupApi.ArangoBackupStateFailed,
+				"credentials secret %s of repository %s not found", secret, backup.Spec.Download.RepositoryURL),
+			cleanStatusJob(),
+			updateStatusAvailable(false),
+		)
+	}
+
+	jobID, err := client.Download(h.ctx, driver.BackupID(backup.Spec.Download.ID))
 	if err != nil {
 		return wrapUpdateStatus(backup,
 			updateStatusState(backupApi.ArangoBackupStateDownloadError,
@@ -70,6 +85,7 @@ func stateDownloadHandler(h *handler, backup *backupApi.ArangoBackup) (*backupAp
 	return wrapUpdateStatus(backup,
 		updateStatusState(backupApi.ArangoBackupStateDownloading, ""),
 		updateStatusJob(string(jobID), "0%"),
+		cleanStatusJobError(),
 		updateStatusAvailable(false),
 	)
 }
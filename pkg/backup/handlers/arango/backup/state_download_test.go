@@ -132,3 +132,32 @@ func Test_State_Download_TemporaryDownloadFailed(t *testing.T) {
 
 	require.Nil(t, newObj.Status.Backup)
 }
+
+func Test_State_Download_MissingCredentials(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateDownload)
+
+	obj.Spec.Download = &backupApi.ArangoBackupSpecDownload{
+		ArangoBackupSpecOperation: backupApi.ArangoBackupSpecOperation{
+			RepositoryURL:         "S3 URL",
+			CredentialsSecretName: "team-credentials",
+		},
+		ID: "test",
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, false)
+	require.Equal(t, "credentials secret team-credentials of repository S3 URL not found", newObj.Status.Message)
+
+	require.Nil(t, newObj.Status.Progress)
+	require.Len(t, mock.getProgressIDs(), 0)
+}
@@ -54,7 +54,7 @@ func stateDownloadingHandler(h *handler, backup *backupApi.ArangoBackup) (*backu
 		return nil, newFatalErrorf("missing field .spec.download.id")
 	}
 
-	details, err := client.Progress(driver.BackupTransferJobID(backup.Status.Progress.JobID))
+	details, err := client.Progress(h.ctx, driver.BackupTransferJobID(backup.Status.Progress.JobID))
 	if err != nil {
 		if driver.IsNotFound(err) {
 			return wrapUpdateStatus(backup,
@@ -70,13 +70,14 @@ func stateDownloadingHandler(h *handler, backup *backupApi.ArangoBackup) (*backu
 	if details.Failed {
 		return wrapUpdateStatus(backup,
 			updateStatusState(backupApi.ArangoBackupStateDownloadError,
-				"Download failed with error: %s", details.FailMessage),
+				"Download failed with error: %s", truncateMessage(details.FailMessage, maxJobErrorMessageLength)),
 			cleanStatusJob(),
+			updateStatusJobError(backup.Status.Progress.JobID, details),
 		)
 	}
 
 	if details.Completed {
-		backupMeta, err := client.Get(driver.BackupID(backup.Spec.Download.ID))
+		backupMeta, err := client.Get(h.ctx, driver.BackupID(backup.Spec.Download.ID))
 		if err != nil {
 			if driver.IsNotFound(err) {
 				return wrapUpdateStatus(backup,
@@ -23,6 +23,7 @@
 package backup
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -44,13 +45,13 @@ func Test_State_Downloading_Success(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateDownloading)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
-	progress, err := mock.Download(backupMeta.ID)
+	progress, err := mock.Download(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -121,13 +122,13 @@ func Test_State_Downloading_FailedDownload(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateDownloading)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
-	progress, err := mock.Download(backupMeta.ID)
+	progress, err := mock.Download(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 
 	errorMsg := errorString
@@ -171,13 +172,13 @@ func Test_State_Downloading_FailedProgress(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateDownloading)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
-	progress, err := mock.Download(backupMeta.ID)
+	progress, err := mock.Download(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -213,13 +214,13 @@ func Test_State_Downloading_TemporaryFailedProgress(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateDownloading)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
-	progress, err := mock.Download(backupMeta.ID)
+	progress, err := mock.Download(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -257,13 +258,13 @@ func Test_State_Downloading_NotFoundProgress(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateDownloading)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
-	progress, err := mock.Download(backupMeta.ID)
+	progress, err := mock.Download(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -0,0 +1,174 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StateHistory_Disabled(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	// Act
+	createArangoBackup(t, handler, obj)
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStatePending, false)
+	require.Empty(t, newObj.Status.History)
+}
+
+func Test_StateHistory_Recorded(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.StateHistoryLimit = 5
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateScheduled, false)
+
+	require.Len(t, newObj.Status.History, 2)
+	require.Equal(t, backupApi.ArangoBackupStateNone, newObj.Status.History[0].From)
+	require.Equal(t, backupApi.ArangoBackupStatePending, newObj.Status.History[0].To)
+	require.Equal(t, backupApi.ArangoBackupStatePending, newObj.Status.History[1].From)
+	require.Equal(t, backupApi.ArangoBackupStateScheduled, newObj.Status.History[1].To)
+	require.True(t, newObj.Status.History[1].Time.Equal(&newObj.Status.Time))
+}
+
+func Test_StateHistory_Limit(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.StateHistoryLimit = 1
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateScheduled, false)
+
+	require.Len(t, newObj.Status.History, 1)
+	require.Equal(t, backupApi.ArangoBackupStatePending, newObj.Status.History[0].From)
+	require.Equal(t, backupApi.ArangoBackupStateScheduled, newObj.Status.History[0].To)
+}
+
+func Test_StateHistory_Append(t *testing.T) {
+	var history backupApi.ArangoBackupStateHistory
+
+	for _, s := range []string{"A", "B", "C"} {
+		history = history.Append(backupApi.ArangoBackupStateTransition{Message: s}, 2)
+	}
+
+	require.Len(t, history, 2)
+	require.Equal(t, "B", history[0].Message)
+	require.Equal(t, "C", history[1].Message)
+
+	require.Equal(t, history, history.Append(backupApi.ArangoBackupStateTransition{Message: "D"}, 0))
+}
+
+func Test_StateHistory_TrimFinalStatus(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.StateHistoryLimit = 5
+	handler.config.TrimFinalStatus = true
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Status.History = backupApi.ArangoBackupStateHistory{
+		{From: backupApi.ArangoBackupStateScheduled, To: backupApi.ArangoBackupStateCreate},
+	}
+	obj.Status.Progress = &backupApi.ArangoBackupProgress{JobID: "job", Progress: "50%"}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+
+	require.NotNil(t, newObj.Status.Backup)
+	require.Nil(t, newObj.Status.Progress)
+	require.Nil(t, newObj.Status.History)
+}
+
+func Test_StateHistory_TrimFinalStatus_NotFinal(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.StateHistoryLimit = 5
+	handler.config.TrimFinalStatus = true
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateScheduled, false)
+	require.Len(t, newObj.Status.History, 1)
+}
+
+func Test_StateHistory_TrimFinalStatus_Disabled(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.StateHistoryLimit = 5
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Status.History = backupApi.ArangoBackupStateHistory{
+		{From: backupApi.ArangoBackupStateScheduled, To: backupApi.ArangoBackupStateCreate},
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+	require.Len(t, newObj.Status.History, 2)
+}
@@ -27,6 +27,10 @@ import (
 )
 
 func stateNoneHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi.ArangoBackupStatus, error) {
+	if status, err := h.adoptBackup(backup); err != nil || status != nil {
+		return status, err
+	}
+
 	return wrapUpdateStatus(backup,
 		updateStatusState(backupApi.ArangoBackupStatePending, ""))
 }
@@ -32,12 +32,22 @@ func statePendingHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi
 		return nil, err
 	}
 
+	if backup.IsDryRun() {
+		return wrapUpdateStatus(backup,
+			updateStatusState(backupApi.ArangoBackupStateValidationOnly, "backup validated, not created because of dry run"))
+	}
+
 	running, err := isBackupRunning(backup, h.client.BackupV1().ArangoBackups(backup.Namespace))
 	if err != nil {
 		return nil, err
 	}
 
 	if running {
+		if backup.Spec.Policy.GetRejectIfRunning() {
+			return wrapUpdateStatus(backup,
+				updateStatusState(backupApi.ArangoBackupStateRejected, "backup of the deployment already in process"))
+		}
+
 		return wrapUpdateStatus(backup,
 			updateStatusState(backupApi.ArangoBackupStatePending, "backup already in process"))
 	}
@@ -34,6 +34,7 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
 
 	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -111,6 +112,56 @@ func Test_State_Pending_MultipleBackupObjectWithLimitation(t *testing.T) {
 	})
 }
 
+func Test_State_Pending_RejectIfRunning(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj2, _ := newObjectSet(backupApi.ArangoBackupStatePending)
+	obj2.Namespace = obj.Namespace
+	obj2.Spec.Deployment.Name = deployment.Name
+	obj2.Spec.Policy = &backupApi.ArangoBackupSpecPolicy{
+		RejectIfRunning: util.NewBool(true),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj, obj2)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj2)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj2)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateRejected, false)
+	require.Equal(t, newObj.Status.Message, "backup of the deployment already in process")
+
+	// Rejected backup stays in Rejected state
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj2)))
+
+	newObj = refreshArangoBackup(t, handler, obj2)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateRejected, false)
+}
+
+func Test_State_Pending_RejectIfRunning_NotRunning(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+	obj.Spec.Policy = &backupApi.ArangoBackupSpecPolicy{
+		RejectIfRunning: util.NewBool(true),
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateScheduled, false)
+}
+
 func Test_State_Pending_KeepPendingWithForcedRunning(t *testing.T) {
 	// Arrange
 	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
@@ -159,3 +210,45 @@ func Test_State_Pending_KeepPendingWithForcedRunning(t *testing.T) {
 	require.Equal(t, 1, scheduled)
 	require.Equal(t, size-1, pending)
 }
+
+func Test_State_Pending_DryRun(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+	obj.Annotations = map[string]string{
+		backupApi.AnnotationArangoBackupDryRun: "true",
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateValidationOnly, false)
+	require.Equal(t, "backup validated, not created because of dry run", newObj.Status.Message)
+	require.Nil(t, newObj.Status.Backup)
+	require.Len(t, mock.state.backups, 0)
+}
+
+func Test_State_Pending_DryRun_MissingDeployment(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStatePending)
+	obj.Annotations = map[string]string{
+		backupApi.AnnotationArangoBackupDryRun: "true",
+	}
+
+	// Act
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, false)
+}
@@ -42,7 +42,7 @@ func stateReadyHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi.A
 		return nil, newFatalErrorf("missing field .status.backup")
 	}
 
-	backupMeta, err := client.Get(driver.BackupID(backup.Status.Backup.ID))
+	backupMeta, err := client.Get(h.ctx, driver.BackupID(backup.Status.Backup.ID))
 	if err != nil {
 		if driver.IsNotFound(err) {
 			return wrapUpdateStatus(backup,
@@ -70,8 +70,7 @@ func stateReadyHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi.A
 	}
 
 	// Check if upload flag was specified later in runtime
-	if backup.Spec.Upload != nil &&
-		(backup.Status.Backup.Uploaded == nil || (backup.Status.Backup.Uploaded != nil && !*backup.Status.Backup.Uploaded)) {
+	if isUploadRequested(backup) {
 		// Ensure that we can start upload process
 		running, err := isBackupRunning(backup, h.client.BackupV1().ArangoBackups(backup.Namespace))
 		if err != nil {
@@ -98,12 +97,22 @@ func stateReadyHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi.A
 			updateStatusState(backupApi.ArangoBackupStateReady, ""),
 			updateStatusBackup(backupMeta),
 			updateStatusBackupUpload(nil),
+			updateStatusBackupUploadedTo(nil),
 			updateStatusAvailable(true),
 		)
 	}
 
+	manifest := h.ensureManifest(backup)
+
+	restoreTarget, err := h.checkRestoreTarget(backup, deployment)
+	if err != nil {
+		return nil, err
+	}
+
 	return wrapUpdateStatus(backup,
 		updateStatusBackup(backupMeta),
 		updateStatusAvailable(true),
+		updateStatusManifest(manifest),
+		updateStatusRestoreTarget(restoreTarget),
 	)
 }
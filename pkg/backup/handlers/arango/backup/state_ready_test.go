@@ -23,6 +23,7 @@
 package backup
 
 import (
+	"context"
 	"sync"
 	"testing"
 
@@ -47,10 +48,10 @@ func Test_State_Ready_Success(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -73,10 +74,10 @@ func Test_State_Ready_Unavailable(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -103,10 +104,10 @@ func Test_State_Ready_ServerDown(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -133,10 +134,10 @@ func Test_State_Ready_Success_Update(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -254,10 +255,10 @@ func Test_State_Ready_Upload(t *testing.T) {
 		RepositoryURL: "Any",
 	}
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -286,10 +287,10 @@ func Test_State_Ready_DownloadDoNothing(t *testing.T) {
 		ID: "some",
 	}
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, &backupApi.ArangoBackupDetails{
@@ -319,10 +320,10 @@ func Test_State_Ready_DoUploadDownloadedBackup(t *testing.T) {
 		RepositoryURL: "Any",
 	}
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, &backupApi.ArangoBackupDetails{
@@ -352,10 +353,10 @@ func Test_State_Ready_DoNotReUploadBackup(t *testing.T) {
 		RepositoryURL: "Any",
 	}
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, &backupApi.ArangoBackupDetails{
@@ -382,10 +383,10 @@ func Test_State_Ready_RemoveUploadedFlag(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateReady)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, &backupApi.ArangoBackupDetails{
@@ -414,10 +415,10 @@ func Test_State_Ready_KeepPendingWithForcedRunning(t *testing.T) {
 	size := 128
 	objects := make([]*backupApi.ArangoBackup, size)
 	for id := range objects {
-		createResponse, err := mock.Create()
+		createResponse, err := mock.Create(context.Background())
 		require.NoError(t, err)
 
-		backupMeta, err := mock.Get(createResponse.ID)
+		backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 		require.NoError(t, err)
 
 		obj := newArangoBackup(name, name, string(uuid.NewUUID()), backupApi.ArangoBackupStateReady)
@@ -474,10 +475,10 @@ func Test_State_Ready_KeepPendingWithForcedRunningSameId(t *testing.T) {
 
 	name := string(uuid.NewUUID())
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	deployment := newArangoDeployment(name, name)
@@ -531,3 +532,27 @@ func Test_State_Ready_KeepPendingWithForcedRunningSameId(t *testing.T) {
 	require.Equal(t, 1, upload)
 	require.Equal(t, size-1, ready)
 }
+
+func Test_State_Ready_FailedKeepsBackupDetails(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateReady)
+
+	backupMeta, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta.BackupMeta, nil)
+	obj.Status.Available = true
+
+	// Act
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, true)
+	require.NotNil(t, newObj.Status.Backup)
+	require.Equal(t, string(backupMeta.ID), newObj.Status.Backup.ID)
+}
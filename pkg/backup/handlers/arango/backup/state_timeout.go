@@ -0,0 +1,93 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/state"
+	"github.com/rs/zerolog/log"
+)
+
+// timeoutStates are operation states in which backup is failed when it stays in them longer than the state timeout
+// UploadError is not included, backup is already available there and upload is only retried
+var timeoutStates = map[state.State]bool{
+	backupApi.ArangoBackupStateScheduled:     true,
+	backupApi.ArangoBackupStateCreate:        true,
+	backupApi.ArangoBackupStateUpload:        true,
+	backupApi.ArangoBackupStateUploading:     true,
+	backupApi.ArangoBackupStateDownload:      true,
+	backupApi.ArangoBackupStateDownloading:   true,
+	backupApi.ArangoBackupStateDownloadError: true,
+}
+
+// checkStateTimeout returns Failed status if backup stays in the operation state longer than the state timeout.
+// Running upload or download job is aborted on the best effort basis. Returns nil if timeout is not reached.
+func (h *handler) checkStateTimeout(backup *backupApi.ArangoBackup) (*backupApi.ArangoBackupStatus, error) {
+	if !timeoutStates[backup.Status.State] {
+		return nil, nil
+	}
+
+	timeout, ok := backup.Spec.Options.GetStateTimeout()
+	if !ok || backup.Status.Time.IsZero() || time.Since(backup.Status.Time.Time) <= timeout {
+		return nil, nil
+	}
+
+	if progress := backup.Status.Progress; progress != nil && progress.JobID != "" {
+		h.abortTimedOutJob(backup, driver.BackupTransferJobID(progress.JobID))
+	}
+
+	status, err := setFailedState(backup, fmt.Errorf("backup did not leave state %s within %s", backup.Status.State, timeout))
+	if err != nil {
+		return nil, err
+	}
+
+	status.Progress = nil
+
+	return status, nil
+}
+
+// abortTimedOutJob aborts the server job of the timed out backup, errors are only logged as backup fails anyway
+func (h *handler) abortTimedOutJob(backup *backupApi.ArangoBackup, jobID driver.BackupTransferJobID) {
+	deployment, err := h.getArangoDeploymentObject(backup)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Unable to abort job %s of timed out backup %s/%s", jobID, backup.Namespace, backup.Name)
+		return
+	}
+
+	client, err := h.arangoClientFactory(deployment, backup)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Unable to abort job %s of timed out backup %s/%s", jobID, backup.Namespace, backup.Name)
+		return
+	}
+
+	if err := client.Abort(h.ctx, jobID); err != nil {
+		log.Warn().Err(err).Msgf("Unable to abort job %s of timed out backup %s/%s", jobID, backup.Namespace, backup.Name)
+		return
+	}
+
+	log.Info().Msgf("Aborted job %s of timed out backup %s/%s", jobID, backup.Namespace, backup.Name)
+}
@@ -0,0 +1,185 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newStateTimeoutBackup(t *testing.T, mock *mockArangoClientBackup, entered time.Time) *backupApi.ArangoBackup {
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateUploading)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
+	obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+		StateTimeoutSeconds: util.NewInt(60),
+	}
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	// Job never completes
+	jobID, err := mock.Upload(context.Background(), backupMeta.ID, *obj.Spec.Upload)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+	obj.Status.Available = true
+	obj.Status.Time = meta.NewTime(entered)
+	obj.Status.Progress = &backupApi.ArangoBackupProgress{
+		JobID: string(jobID),
+	}
+
+	return obj
+}
+
+func Test_StateTimeout_NotReached(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj := newStateTimeoutBackup(t, mock, time.Now())
+	deployment := newArangoDeployment(obj.Namespace, obj.Spec.Deployment.Name)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateUploading, true)
+	require.NotNil(t, newObj.Status.Progress)
+}
+
+func Test_StateTimeout_Uploading(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj := newStateTimeoutBackup(t, mock, time.Now().Add(-2*time.Minute))
+	deployment := newArangoDeployment(obj.Namespace, obj.Spec.Deployment.Name)
+	jobID := obj.Status.Progress.JobID
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, true)
+	require.Equal(t, createStateMessage(backupApi.ArangoBackupStateUploading, backupApi.ArangoBackupStateFailed,
+		"backup did not leave state Uploading within 1m0s"), newObj.Status.Message)
+	require.Nil(t, newObj.Status.Progress)
+
+	progress, err := mock.Progress(context.Background(), driver.BackupTransferJobID(jobID))
+	require.NoError(t, err)
+	require.True(t, progress.Cancelled)
+}
+
+func Test_StateTimeout_AbortError(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{
+		abortError: newFatalErrorf("abort failed"),
+	})
+
+	obj := newStateTimeoutBackup(t, mock, time.Now().Add(-2*time.Minute))
+	deployment := newArangoDeployment(obj.Namespace, obj.Spec.Deployment.Name)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, true)
+}
+
+func Test_StateTimeout_UploadError(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj := newStateTimeoutBackup(t, mock, time.Now().Add(-2*time.Minute))
+	obj.Status.State = backupApi.ArangoBackupStateUploadError
+	obj.Status.Progress = nil
+	deployment := newArangoDeployment(obj.Namespace, obj.Spec.Deployment.Name)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+}
+
+func Test_StateTimeout_Default(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	handler.config.Defaults.StateTimeout = time.Minute
+
+	obj := newStateTimeoutBackup(t, mock, time.Now().Add(-2*time.Minute))
+	obj.Spec.Options = nil
+	deployment := newArangoDeployment(obj.Namespace, obj.Spec.Deployment.Name)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, true)
+	require.Nil(t, newObj.Spec.Options)
+}
+
+func Test_StateTimeout_Validation(t *testing.T) {
+	// Arrange
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateNone)
+	obj.Spec.Options = &backupApi.ArangoBackupSpecOptions{
+		StateTimeoutSeconds: util.NewInt(0),
+	}
+
+	// Assert
+	require.EqualError(t, obj.Spec.Validate(), "state timeout seconds needs to be greater than 0")
+
+	obj.Spec.Options.StateTimeoutSeconds = util.NewInt(3600)
+	require.NoError(t, obj.Spec.Validate())
+}
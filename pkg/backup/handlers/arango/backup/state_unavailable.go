@@ -42,7 +42,7 @@ func stateUnavailableHandler(h *handler, backup *backupApi.ArangoBackup) (*backu
 		return nil, newFatalErrorf("missing field .status.backup")
 	}
 
-	backupMeta, err := client.Get(driver.BackupID(backup.Status.Backup.ID))
+	backupMeta, err := client.Get(h.ctx, driver.BackupID(backup.Status.Backup.ID))
 	if err != nil {
 		if driver.IsNotFound(err) {
 			return wrapUpdateStatus(backup,
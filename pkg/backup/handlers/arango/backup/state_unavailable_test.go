@@ -23,6 +23,7 @@
 package backup
 
 import (
+	"context"
 	"testing"
 
 	"github.com/arangodb/go-driver"
@@ -43,10 +44,10 @@ func Test_State_Unavailable_Success(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUnavailable)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -69,10 +70,10 @@ func Test_State_Unavailable_Keep(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUnavailable)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -99,10 +100,10 @@ func Test_State_Unavailable_Update(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUnavailable)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -42,7 +42,7 @@ func stateUploadHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi.
 		return nil, newFatalErrorf("missing field .status.backup")
 	}
 
-	meta, err := client.Get(driver.BackupID(backup.Status.Backup.ID))
+	meta, err := client.Get(h.ctx, driver.BackupID(backup.Status.Backup.ID))
 	if err != nil {
 		if driver.IsNotFound(err) {
 			return wrapUpdateStatus(backup,
@@ -54,11 +54,36 @@ func stateUploadHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi.
 		return nil, newTemporaryError(err)
 	}
 
-	jobID, err := client.Upload(meta.ID)
+	pending := pendingUploads(backup)
+	if len(pending) == 0 {
+		return wrapUpdateStatus(backup,
+			updateStatusState(backupApi.ArangoBackupStateUploadError, "upload destination is not specified"),
+			cleanStatusJob(),
+			updateStatusBackupUpload(nil),
+			updateStatusAvailable(true),
+		)
+	}
+
+	upload := pending[0]
+
+	// Backup itself is not affected by missing upload credentials, it stays available
+	if secret, err := h.missingTransferCredentialsSecret(backup.Namespace, upload); err != nil {
+		return nil, err
+	} else if secret != "" {
+		return wrapUpdateStatus(backup,
+			updateStatusState(backupApi.ArangoBackupStateUploadError,
+				"credentials secret %s of repository %s not found", secret, upload.RepositoryURL),
+			cleanStatusJob(),
+			updateStatusBackupUpload(nil),
+			updateStatusAvailable(true),
+		)
+	}
+
+	jobID, err := client.Upload(h.ctx, meta.ID, upload)
 	if err != nil {
 		return wrapUpdateStatus(backup,
 			updateStatusState(backupApi.ArangoBackupStateUploadError,
-				"Upload failed with error: %s", err.Error()),
+				"%s", uploadFailedMessage(backup, upload.RepositoryURL, err.Error())),
 			cleanStatusJob(),
 			updateStatusBackupUpload(nil),
 			updateStatusAvailable(true),
@@ -68,6 +93,8 @@ func stateUploadHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi.
 	return wrapUpdateStatus(backup,
 		updateStatusState(backupApi.ArangoBackupStateUploading, ""),
 		updateStatusJob(string(jobID), "0%"),
+		updateStatusJobRepository(upload.RepositoryURL),
+		cleanStatusJobError(),
 		updateStatusAvailable(true),
 	)
 }
@@ -23,6 +23,7 @@
 package backup
 
 import (
+	"context"
 	"testing"
 
 	"github.com/arangodb/go-driver"
@@ -31,6 +32,8 @@ import (
 
 	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
 	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_State_Upload_Common(t *testing.T) {
@@ -43,11 +46,14 @@ func Test_State_Upload_Success(t *testing.T) {
 	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -79,7 +85,7 @@ func Test_State_Upload_TemporaryGetFailed(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(driver.BackupMeta{
@@ -106,7 +112,7 @@ func Test_State_Upload_FatalGetFailed(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(driver.BackupMeta{
@@ -135,7 +141,7 @@ func Test_State_Upload_BackupMissing(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(driver.BackupMeta{
@@ -161,8 +167,11 @@ func Test_State_Upload_TemporaryUploadFailed(t *testing.T) {
 	})
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(driver.BackupMeta{
@@ -188,8 +197,11 @@ func Test_State_Upload_FatalUploadFailed(t *testing.T) {
 	})
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://test",
+	}
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(driver.BackupMeta{
@@ -206,3 +218,54 @@ func Test_State_Upload_FatalUploadFailed(t *testing.T) {
 	newObj := refreshArangoBackup(t, handler, obj)
 	checkBackup(t, newObj, backupApi.ArangoBackupStateUploadError, true)
 }
+
+func Test_State_Upload_MissingCredentials(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL:         "s3://test",
+		CredentialsSecretName: "team-credentials",
+	}
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(createResponse.BackupMeta, nil)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	t.Run("Missing secret", func(t *testing.T) {
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		// Assert
+		newObj := refreshArangoBackup(t, handler, obj)
+		checkBackup(t, newObj, backupApi.ArangoBackupStateUploadError, true)
+		require.Equal(t, "credentials secret team-credentials of repository s3://test not found", newObj.Status.Message)
+		require.Len(t, mock.getProgressIDs(), 0)
+	})
+
+	t.Run("Existing secret", func(t *testing.T) {
+		_, err := handler.kubeClient.CoreV1().Secrets(obj.Namespace).Create(&core.Secret{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "team-credentials",
+				Namespace: obj.Namespace,
+			},
+		})
+		require.NoError(t, err)
+
+		newObj := refreshArangoBackup(t, handler, obj)
+		newObj.Status.State = backupApi.ArangoBackupStateUpload
+		require.NoError(t, handler.updateBackupStatus(newObj))
+
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		// Assert
+		newObj = refreshArangoBackup(t, handler, obj)
+		checkBackup(t, newObj, backupApi.ArangoBackupStateUploading, true)
+		require.Len(t, mock.getProgressIDs(), 1)
+	})
+}
@@ -23,6 +23,7 @@
 package backup
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -46,7 +47,7 @@ func Test_State_UploadError_Reschedule(t *testing.T) {
 		RepositoryURL: "S3 URL",
 	}
 
-	backupMeta, err := mock.Create()
+	backupMeta, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = &backupApi.ArangoBackupDetails{
@@ -83,7 +84,7 @@ func Test_State_UploadError_Wait(t *testing.T) {
 		RepositoryURL: "S3 URL",
 	}
 
-	backupMeta, err := mock.Create()
+	backupMeta, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = &backupApi.ArangoBackupDetails{
@@ -117,7 +118,7 @@ func Test_State_UploadError_BackToReady(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUploadError)
 
-	backupMeta, err := mock.Create()
+	backupMeta, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
 	obj.Status.Backup = &backupApi.ArangoBackupDetails{
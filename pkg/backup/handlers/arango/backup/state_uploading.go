@@ -50,7 +50,7 @@ func stateUploadingHandler(h *handler, backup *backupApi.ArangoBackup) (*backupA
 		return nil, newFatalErrorf("missing field .status.progress")
 	}
 
-	details, err := client.Progress(driver.BackupTransferJobID(backup.Status.Progress.JobID))
+	details, err := client.Progress(h.ctx, driver.BackupTransferJobID(backup.Status.Progress.JobID))
 	if err != nil {
 		if driver.IsNotFound(err) {
 			return wrapUpdateStatus(backup,
@@ -63,17 +63,39 @@ func stateUploadingHandler(h *handler, backup *backupApi.ArangoBackup) (*backupA
 		return nil, newTemporaryError(err)
 	}
 
+	// Destination is recorded when the job is started, spec may change while the job is running
+	repositoryURL := uploadJobRepository(backup)
+
+	// Failed destination moves backup to UploadError and not to Failed. Backup stays available and the retry
+	// continues with pending destinations only, Failed state would be final and drop the completed uploads.
 	if details.Failed {
 		return wrapUpdateStatus(backup,
 			updateStatusState(backupApi.ArangoBackupStateUploadError,
-				"Upload failed with error: %s", details.FailMessage),
+				"%s", uploadFailedMessage(backup, repositoryURL, truncateMessage(details.FailMessage, maxJobErrorMessageLength))),
 			cleanStatusJob(),
+			updateStatusJobError(backup.Status.Progress.JobID, details),
 			updateStatusAvailable(true),
 		)
 	}
 
 	if details.Completed {
+		var uploadedTo []string
+		if repositoryURL != "" {
+			uploadedTo = append(append([]string{}, backup.Status.Backup.UploadedTo...), repositoryURL)
+		}
+
+		// Continue with next destination
+		if hasPendingUploadsAfter(backup, repositoryURL) {
+			return wrapUpdateStatus(backup,
+				updateStatusState(backupApi.ArangoBackupStateUpload, ""),
+				cleanStatusJob(),
+				updateStatusBackupUploadedTo(uploadedTo),
+				updateStatusAvailable(true),
+			)
+		}
+
 		return wrapUpdateStatus(backup,
+			updateStatusBackupUploadedTo(uploadedTo),
 			updateStatusState(backupApi.ArangoBackupStateReady, ""),
 			cleanStatusJob(),
 			updateStatusBackupUpload(util.NewBool(true)),
@@ -23,6 +23,7 @@
 package backup
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -46,13 +47,13 @@ func Test_State_Uploading_Success(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUploading)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
-	progress, err := mock.Upload(backupMeta.ID)
+	progress, err := mock.Upload(context.Background(), backupMeta.ID, backupApi.ArangoBackupSpecOperation{})
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -113,13 +114,13 @@ func Test_State_Uploading_FailedUpload(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUploading)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
-	progress, err := mock.Upload(backupMeta.ID)
+	progress, err := mock.Upload(context.Background(), backupMeta.ID, backupApi.ArangoBackupSpecOperation{})
 	require.NoError(t, err)
 
 	errorMsg := errorString
@@ -156,13 +157,13 @@ func Test_StateUploading_FailedProgress(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUploading)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
-	progress, err := mock.Download(backupMeta.ID)
+	progress, err := mock.Download(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -198,13 +199,13 @@ func Test_State_Uploading_TemporaryFailedProgress(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUploading)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
-	progress, err := mock.Download(backupMeta.ID)
+	progress, err := mock.Download(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
@@ -242,13 +243,13 @@ func Test_State_Uploading_NotFoundProgress(t *testing.T) {
 
 	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUploading)
 
-	createResponse, err := mock.Create()
+	createResponse, err := mock.Create(context.Background())
 	require.NoError(t, err)
 
-	backupMeta, err := mock.Get(createResponse.ID)
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
 	require.NoError(t, err)
 
-	progress, err := mock.Download(backupMeta.ID)
+	progress, err := mock.Download(context.Background(), backupMeta.ID)
 	require.NoError(t, err)
 
 	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
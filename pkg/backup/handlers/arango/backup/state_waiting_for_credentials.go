@@ -0,0 +1,117 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	database "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func stateWaitingForCredentialsHandler(h *handler, backup *backupApi.ArangoBackup) (*backupApi.ArangoBackupStatus, error) {
+	deployment, err := h.getArangoDeploymentObject(backup)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := h.missingCredentialsSecret(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		return wrapUpdateStatus(backup,
+			updateStatusState(backupApi.ArangoBackupStateScheduled, ""))
+	}
+
+	if time.Since(backup.Status.Time.Time) > h.config.CredentialsTimeout {
+		return wrapUpdateStatus(backup,
+			updateStatusState(backupApi.ArangoBackupStateFailed,
+				"authentication secret %s of deployment %s not found within %s", secret, deployment.Name, h.config.CredentialsTimeout),
+			updateStatusAvailable(false))
+	}
+
+	return wrapUpdateStatus(backup)
+}
+
+// waitForCredentials returns status with WaitingForCredentials state if authentication secret of the deployment is missing.
+// Returns nil if credentials are present.
+func (h *handler) waitForCredentials(backup *backupApi.ArangoBackup, deployment *database.ArangoDeployment) (*backupApi.ArangoBackupStatus, error) {
+	secret, err := h.missingCredentialsSecret(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		return nil, nil
+	}
+
+	return wrapUpdateStatus(backup,
+		updateStatusState(backupApi.ArangoBackupStateWaitingForCredentials,
+			"waiting for authentication secret %s of deployment %s", secret, deployment.Name))
+}
+
+// missingCredentialsSecret returns name of the authentication secret of the deployment if it does not exist.
+// Returns empty string if authentication is disabled or secret exists.
+func (h *handler) missingCredentialsSecret(deployment *database.ArangoDeployment) (string, error) {
+	if !deployment.Spec.IsAuthenticated() {
+		return "", nil
+	}
+
+	name := deployment.Spec.Authentication.GetJWTSecretName()
+	if name == "" {
+		return "", nil
+	}
+
+	if _, err := h.kubeClient.CoreV1().Secrets(deployment.Namespace).Get(name, meta.GetOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return name, nil
+		}
+
+		return "", newTemporaryError(err)
+	}
+
+	return "", nil
+}
+
+// missingTransferCredentialsSecret returns name of the credentials secret of the upload or download if it does not exist.
+// Returns empty string if credentials are not defined or secret exists.
+func (h *handler) missingTransferCredentialsSecret(namespace string, operation backupApi.ArangoBackupSpecOperation) (string, error) {
+	name := operation.CredentialsSecretName
+	if name == "" {
+		return "", nil
+	}
+
+	if _, err := h.kubeClient.CoreV1().Secrets(namespace).Get(name, meta.GetOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return name, nil
+		}
+
+		return "", newTemporaryError(err)
+	}
+
+	return "", nil
+}
@@ -0,0 +1,229 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+)
+
+const credentialsSecretName = "deployment-jwt"
+
+func createCredentialsSecret(t *testing.T, h *handler, namespace string) {
+	_, err := h.kubeClient.CoreV1().Secrets(namespace).Create(&core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      credentialsSecretName,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			constants.SecretKeyToken: []byte("token"),
+		},
+	})
+	require.NoError(t, err)
+}
+
+func Test_State_WaitingForCredentials_Common(t *testing.T) {
+	wrapperUndefinedDeployment(t, backupApi.ArangoBackupStateWaitingForCredentials)
+}
+
+func Test_State_WaitingForCredentials_MissingThenRestored(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	deployment.Spec.Authentication.JWTSecretName = util.NewString(credentialsSecretName)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateWaitingForCredentials, false)
+	require.Contains(t, newObj.Status.Message, credentialsSecretName)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateWaitingForCredentials, false)
+
+	// Act
+	createCredentialsSecret(t, handler, deployment.Namespace)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateScheduled, false)
+}
+
+func Test_State_WaitingForCredentials_BlocksSecondBackup(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	deployment.Spec.Authentication.JWTSecretName = util.NewString(credentialsSecretName)
+
+	obj2, _ := newObjectSet(backupApi.ArangoBackupStatePending)
+	obj2.Namespace = obj.Namespace
+	obj2.Spec.Deployment.Name = deployment.Name
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj, obj2)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj2)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateWaitingForCredentials, false)
+
+	newObj2 := refreshArangoBackup(t, handler, obj2)
+	checkBackup(t, newObj2, backupApi.ArangoBackupStatePending, false)
+	require.Equal(t, "backup already in process", newObj2.Status.Message)
+
+	// Act
+	createCredentialsSecret(t, handler, deployment.Namespace)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj2)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateScheduled, false)
+
+	newObj2 = refreshArangoBackup(t, handler, obj2)
+	checkBackup(t, newObj2, backupApi.ArangoBackupStatePending, false)
+}
+
+func Test_State_WaitingForCredentials_Timeout(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateWaitingForCredentials)
+	obj.Status.Time = meta.NewTime(time.Now().Add(-2 * handler.config.CredentialsTimeout))
+	deployment.Spec.Authentication.JWTSecretName = util.NewString(credentialsSecretName)
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, false)
+	require.Contains(t, newObj.Status.Message, credentialsSecretName)
+}
+
+func Test_State_WaitingForCredentials_AuthenticationDisabled(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateWaitingForCredentials)
+	deployment.Spec.Authentication.JWTSecretName = util.NewString("None")
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateScheduled, false)
+}
+
+func Test_State_WaitingForCredentials_Requeue(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+	handler.config.RequeueBaseDelay = time.Minute
+	handler.config.RequeueMaxDelay = time.Hour
+	handler.config.CredentialsTimeout = 10 * time.Minute
+	handler.requeueLimiter = newRequeueLimiter(handler.config)
+
+	operatorMock := &requeueOperatorMock{}
+	handler.operator = operatorMock
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	deployment.Spec.Authentication.JWTSecretName = util.NewString(credentialsSecretName)
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	// Act
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateWaitingForCredentials, false)
+	require.Equal(t, 1, operatorMock.immediate)
+	require.Equal(t, []time.Duration{time.Minute}, operatorMock.delays)
+
+	// Act
+	newObj.Status.Time = meta.NewTime(time.Now().Add(-9*time.Minute - 30*time.Second))
+	_, err := handler.client.BackupV1().ArangoBackups(newObj.Namespace).UpdateStatus(newObj)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	require.Len(t, operatorMock.delays, 2)
+	require.True(t, operatorMock.delays[1] <= 30*time.Second)
+	require.True(t, operatorMock.delays[1] > 25*time.Second)
+}
+
+func Test_State_WaitingForCredentials_DeadlineDelay(t *testing.T) {
+	handler := newFakeHandler()
+	handler.config.CredentialsTimeout = 10 * time.Minute
+
+	now := time.Now()
+	status := newRequeueStatus(backupApi.ArangoBackupStateWaitingForCredentials)
+
+	status.Time = meta.NewTime(now)
+	require.Equal(t, time.Minute, handler.stateDeadlineDelay(status, time.Minute, now))
+
+	status.Time = meta.NewTime(now.Add(-9*time.Minute - 50*time.Second))
+	require.Equal(t, 10*time.Second, handler.stateDeadlineDelay(status, time.Minute, now))
+
+	status.Time = meta.NewTime(now.Add(-time.Hour))
+	require.Equal(t, time.Duration(0), handler.stateDeadlineDelay(status, time.Minute, now))
+
+	status.State = backupApi.ArangoBackupStateReady
+	require.Equal(t, time.Minute, handler.stateDeadlineDelay(status, time.Minute, now))
+}
@@ -25,6 +25,7 @@ package backup
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	shared "github.com/arangodb/kube-arangodb/pkg/apis/shared/v1"
 
@@ -67,11 +68,23 @@ func updateStatusAvailable(available bool) updateStatusFunc {
 	}
 }
 
+// updateStatusJob sets progress of the job, start time is kept while the same job is running
 func updateStatusJob(id, progress string) updateStatusFunc {
 	return func(status *backupApi.ArangoBackupStatus) {
+		startedAt := v1.Now()
+		var repositoryURL string
+		if old := status.Progress; old != nil && old.JobID == id {
+			if !old.StartedAt.IsZero() {
+				startedAt = old.StartedAt
+			}
+			repositoryURL = old.RepositoryURL
+		}
+
 		status.Progress = &backupApi.ArangoBackupProgress{
-			JobID:    id,
-			Progress: progress,
+			JobID:         id,
+			Progress:      progress,
+			StartedAt:     startedAt,
+			RepositoryURL: repositoryURL,
 		}
 	}
 }
@@ -106,16 +119,74 @@ func updateStatusBackup(backupMeta driver.BackupMeta) updateStatusFunc {
 	}
 }
 
+func updateStatusBackupServerVersion(version driver.Version) updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		if status.Backup == nil {
+			return
+		}
+
+		status.Backup.ServerVersion = string(version)
+	}
+}
+
+func updateStatusBackupDuration(duration time.Duration) updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		if status.Backup == nil {
+			return
+		}
+
+		seconds := float32(duration.Seconds())
+		status.Backup.DurationSeconds = &seconds
+	}
+}
+
+func updateStatusBackupLabel(label string) updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		if status.Backup == nil {
+			return
+		}
+
+		status.Backup.Label = label
+	}
+}
+
+func updateStatusEncryptionSecretVersion(version string) updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		status.EncryptionSecretVersion = version
+	}
+}
+
 func cleanStatusJob() updateStatusFunc {
 	return func(status *backupApi.ArangoBackupStatus) {
 		status.Progress = nil
 	}
 }
 
+// finalStates are states in which backup does not run any operation
+var finalStates = map[state.State]bool{
+	backupApi.ArangoBackupStateReady:          true,
+	backupApi.ArangoBackupStateFailed:         true,
+	backupApi.ArangoBackupStateDeleted:        true,
+	backupApi.ArangoBackupStateRejected:       true,
+	backupApi.ArangoBackupStateValidationOnly: true,
+}
+
+// trimFinalStatus drops operation progress and state history from the status of backup in final state.
+// Backup details and state are kept
+func trimFinalStatus(status *backupApi.ArangoBackupStatus) {
+	if !finalStates[status.State] {
+		return
+	}
+
+	status.Progress = nil
+	status.History = nil
+}
+
+// setFailedState moves backup to the Failed state. Backup details and availability are kept, so a transient
+// error does not hide a backup which still exists on the deployment.
 func setFailedState(backup *backupApi.ArangoBackup, err error) (*backupApi.ArangoBackupStatus, error) {
 	return wrapUpdateStatus(backup,
-		updateStatusState(backupApi.ArangoBackupStateFailed, createStateMessage(backup.Status.State, backupApi.ArangoBackupStateFailed, err.Error())),
-		updateStatusAvailable(false))
+		updateStatusState(backupApi.ArangoBackupStateFailed, createStateMessage(backup.Status.State, backupApi.ArangoBackupStateFailed, err.Error())))
 }
 
 func createStateMessage(from, to state.State, message string) string {
@@ -133,9 +204,19 @@ func createBackupFromMeta(backupMeta driver.BackupMeta, old *backupApi.ArangoBac
 
 	obj.Keys = keysToHashList(backupMeta.Keys)
 	obj.PotentiallyInconsistent = util.NewBool(backupMeta.PotentiallyInconsistent)
+	if backupMeta.PotentiallyInconsistent {
+		obj.ConsistencyLevel = backupApi.ArangoBackupConsistencyLevelEventual
+	} else {
+		obj.ConsistencyLevel = backupApi.ArangoBackupConsistencyLevelStrict
+	}
 	obj.SizeInBytes = backupMeta.SizeInBytes
-	obj.CreationTimestamp = v1.Time{
-		Time: backupMeta.DateTime,
+	if !backupMeta.DateTime.IsZero() {
+		obj.CreationTimestamp = v1.Time{
+			Time: backupMeta.DateTime,
+		}
+	} else if obj.CreationTimestamp.IsZero() {
+		// Server did not report creation time, fallback to now
+		obj.CreationTimestamp = v1.Now()
 	}
 	obj.NumberOfDBServers = backupMeta.NumberOfDBServers
 	obj.Version = backupMeta.Version
@@ -0,0 +1,70 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"testing"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	fakeClientSet "github.com/arangodb/kube-arangodb/pkg/generated/clientset/versioned/fake"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func Test_UpdateBackupStatus_Conflict(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateNone)
+	createArangoBackup(t, handler, obj)
+
+	conflicts := 3
+	attempts := 0
+
+	handler.client.(*fakeClientSet.Clientset).PrependReactor("update", "arangobackups", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "status" {
+			return false, nil, nil
+		}
+
+		attempts++
+		if attempts <= conflicts {
+			return true, nil, errors.NewConflict(schema.GroupResource{Group: backupApi.SchemeGroupVersion.Group, Resource: "arangobackups"}, obj.Name, nil)
+		}
+
+		return false, nil, nil
+	})
+
+	obj.Status.State = backupApi.ArangoBackupStatePending
+
+	// Act
+	require.NoError(t, handler.updateBackupStatus(obj))
+
+	// Assert
+	require.Equal(t, conflicts+1, attempts)
+
+	newObj := refreshArangoBackup(t, handler, obj)
+	require.Equal(t, backupApi.ArangoBackupStatePending, newObj.Status.State)
+}
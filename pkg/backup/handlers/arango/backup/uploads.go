@@ -0,0 +1,101 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"fmt"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+)
+
+// pendingUploads returns upload destinations to which backup is not uploaded yet, in the upload order
+func pendingUploads(backup *backupApi.ArangoBackup) []backupApi.ArangoBackupSpecOperation {
+	uploaded := map[string]bool{}
+
+	if details := backup.Status.Backup; details != nil {
+		for _, repository := range details.UploadedTo {
+			uploaded[repository] = true
+		}
+	}
+
+	var pending []backupApi.ArangoBackupSpecOperation
+
+	for _, upload := range backup.Spec.GetUploads() {
+		if !uploaded[upload.RepositoryURL] {
+			pending = append(pending, upload)
+		}
+	}
+
+	return pending
+}
+
+// uploadFailedMessage returns message of the failed upload, destination is named if backup has more than one
+func uploadFailedMessage(backup *backupApi.ArangoBackup, repositoryURL, message string) string {
+	if len(backup.Spec.Uploads) == 0 {
+		return fmt.Sprintf("Upload failed with error: %s", message)
+	}
+
+	return fmt.Sprintf("Upload to %s failed with error: %s", repositoryURL, message)
+}
+
+// hasPendingUploadsAfter returns true if destinations other than the given one are still pending
+func hasPendingUploadsAfter(backup *backupApi.ArangoBackup, repositoryURL string) bool {
+	for _, upload := range pendingUploads(backup) {
+		if upload.RepositoryURL != repositoryURL {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateStatusJobRepository records destination of the running upload job
+func updateStatusJobRepository(repositoryURL string) updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		if status.Progress != nil {
+			status.Progress.RepositoryURL = repositoryURL
+		}
+	}
+}
+
+// uploadJobRepository returns destination of the running upload job. Jobs started without recorded
+// destination are uploaded to the first pending destination.
+func uploadJobRepository(backup *backupApi.ArangoBackup) string {
+	if progress := backup.Status.Progress; progress != nil && progress.RepositoryURL != "" {
+		return progress.RepositoryURL
+	}
+
+	if pending := pendingUploads(backup); len(pending) > 0 {
+		return pending[0].RepositoryURL
+	}
+
+	return ""
+}
+
+func updateStatusBackupUploadedTo(repositories []string) updateStatusFunc {
+	return func(status *backupApi.ArangoBackupStatus) {
+		if status.Backup != nil {
+			status.Backup.UploadedTo = repositories
+		}
+	}
+}
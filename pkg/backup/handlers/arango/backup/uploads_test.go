@@ -0,0 +1,271 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/stretchr/testify/require"
+)
+
+func newMultiUploadBackup(t *testing.T, handler *handler, mock *mockArangoClientBackup) *backupApi.ArangoBackup {
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateUpload)
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://first",
+	}
+	obj.Spec.Uploads = []backupApi.ArangoBackupSpecOperation{
+		{
+			RepositoryURL: "s3://second",
+		},
+	}
+
+	createResponse, err := mock.Create(context.Background())
+	require.NoError(t, err)
+
+	backupMeta, err := mock.Get(context.Background(), createResponse.ID)
+	require.NoError(t, err)
+
+	obj.Status.Backup = createBackupFromMeta(backupMeta, nil)
+
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	return obj
+}
+
+func Test_Uploads_Success(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	obj := newMultiUploadBackup(t, handler, mock)
+
+	for _, repository := range []string{"s3://first", "s3://second"} {
+		// Act
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+		// Assert
+		newObj := refreshArangoBackup(t, handler, obj)
+		checkBackup(t, newObj, backupApi.ArangoBackupStateUploading, true)
+		require.NotNil(t, newObj.Status.Progress)
+
+		jobID := driver.BackupTransferJobID(newObj.Status.Progress.JobID)
+		require.Equal(t, repository, mock.state.uploads[jobID])
+
+		mock.state.progresses[jobID] = ArangoBackupProgress{
+			Completed: true,
+		}
+
+		require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	}
+
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+	require.Nil(t, newObj.Status.Progress)
+	require.Equal(t, []string{"s3://first", "s3://second"}, newObj.Status.Backup.UploadedTo)
+	require.NotNil(t, newObj.Status.Backup.Uploaded)
+	require.True(t, *newObj.Status.Backup.Uploaded)
+}
+
+func Test_Uploads_PartialFailure(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	obj := newMultiUploadBackup(t, handler, mock)
+
+	// First destination succeeds
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	newObj := refreshArangoBackup(t, handler, obj)
+	mock.state.progresses[driver.BackupTransferJobID(newObj.Status.Progress.JobID)] = ArangoBackupProgress{
+		Completed: true,
+	}
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Second destination fails
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+	newObj = refreshArangoBackup(t, handler, obj)
+	mock.state.progresses[driver.BackupTransferJobID(newObj.Status.Progress.JobID)] = ArangoBackupProgress{
+		Failed:      true,
+		FailMessage: "access denied",
+	}
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateUploadError, true)
+	require.Equal(t, "Upload to s3://second failed with error: access denied", newObj.Status.Message)
+	require.Equal(t, []string{"s3://first"}, newObj.Status.Backup.UploadedTo)
+
+	// Retry continues with failed destination only
+	newObj.Status.State = backupApi.ArangoBackupStateUpload
+	_, err := handler.client.BackupV1().ArangoBackups(newObj.Namespace).UpdateStatus(newObj)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateUploading, true)
+	require.Equal(t, "s3://second", mock.state.uploads[driver.BackupTransferJobID(newObj.Status.Progress.JobID)])
+}
+
+func Test_Uploads_Validation(t *testing.T) {
+	// Arrange
+	obj, _ := newObjectSet(backupApi.ArangoBackupStateNone)
+
+	obj.Spec.Uploads = []backupApi.ArangoBackupSpecOperation{
+		{
+			RepositoryURL: "s3://second",
+		},
+	}
+
+	// Assert
+	require.EqualError(t, obj.Spec.Validate(), "uploads require upload to be specified")
+
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://second",
+	}
+	require.EqualError(t, obj.Spec.Validate(), "upload repository s3://second is specified more than once")
+
+	obj.Spec.Upload.RepositoryURL = "s3://first"
+	require.NoError(t, obj.Spec.Validate())
+
+	obj.Spec.Uploads[0].RepositoryURL = ""
+	require.EqualError(t, obj.Spec.Validate(), "RepositoryURL can not be empty")
+}
+
+func Test_Uploads_DownloadConflict(t *testing.T) {
+	download := &backupApi.ArangoBackupSpecDownload{
+		ArangoBackupSpecOperation: backupApi.ArangoBackupSpecOperation{
+			RepositoryURL: "s3://download",
+		},
+		ID: "backup",
+	}
+	upload := &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://upload",
+	}
+
+	cases := map[string]struct {
+		download *backupApi.ArangoBackupSpecDownload
+		upload   *backupApi.ArangoBackupSpecOperation
+		err      string
+	}{
+		"download": {download: download},
+		"upload":   {upload: upload},
+		"both":     {download: download, upload: upload, err: "download and upload can not be specified at the same time"},
+		"none":     {},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			obj, _ := newObjectSet(backupApi.ArangoBackupStateNone)
+			obj.Spec.Download = c.download
+			obj.Spec.Upload = c.upload
+
+			// Act
+			err := obj.Spec.Validate()
+
+			// Assert
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.err)
+			}
+		})
+	}
+}
+
+func Test_Uploads_DownloadConflict_Failed(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStatePending)
+	obj.Spec.Download = &backupApi.ArangoBackupSpecDownload{
+		ArangoBackupSpecOperation: backupApi.ArangoBackupSpecOperation{
+			RepositoryURL: "s3://download",
+		},
+		ID: "backup",
+	}
+	obj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://upload",
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, false)
+	require.Equal(t, createStateMessage(backupApi.ArangoBackupStatePending, backupApi.ArangoBackupStateFailed,
+		"download and upload can not be specified at the same time"), newObj.Status.Message)
+}
+
+func Test_Uploads_SpecChangedWhileUploading(t *testing.T) {
+	// Arrange
+	handler, mock := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+	obj := newMultiUploadBackup(t, handler, mock)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateUploading, true)
+	require.Equal(t, "s3://first", newObj.Status.Progress.RepositoryURL)
+
+	// Act
+	newObj.Spec.Upload = &backupApi.ArangoBackupSpecOperation{
+		RepositoryURL: "s3://second",
+	}
+	newObj.Spec.Uploads = nil
+	_, err := handler.client.BackupV1().ArangoBackups(newObj.Namespace).Update(newObj)
+	require.NoError(t, err)
+
+	jobID := driver.BackupTransferJobID(newObj.Status.Progress.JobID)
+	mock.state.progresses[jobID] = ArangoBackupProgress{
+		Progress: 50,
+	}
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateUploading, true)
+	require.Equal(t, "s3://first", newObj.Status.Progress.RepositoryURL)
+
+	mock.state.progresses[jobID] = ArangoBackupProgress{
+		Completed: true,
+	}
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateUpload, true)
+	require.Equal(t, []string{"s3://first"}, newObj.Status.Backup.UploadedTo)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	newObj = refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateUploading, true)
+	require.Equal(t, "s3://second", newObj.Status.Progress.RepositoryURL)
+	require.Equal(t, "s3://second", mock.state.uploads[driver.BackupTransferJobID(newObj.Status.Progress.JobID)])
+}
@@ -0,0 +1,58 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// backupUserAuthentication returns authentication of the database user defined in the backup spec
+func backupUserAuthentication(secrets k8sutil.SecretInterface, user *backupApi.ArangoBackupSpecUser) (driver.Authentication, error) {
+	username, password, err := k8sutil.GetBasicAuthSecret(secrets, user.CredentialsSecretName)
+	if err != nil {
+		return nil, newTemporaryError(err)
+	}
+
+	return driver.BasicAuthentication(username, password), nil
+}
+
+// checkUserPrivileges ensures that database user defined in the backup spec is able to manage backups
+func checkUserPrivileges(ctx context.Context, backup *backupApi.ArangoBackup, client ArangoBackupClient) error {
+	if backup.Spec.User == nil {
+		return nil
+	}
+
+	if _, err := client.List(ctx); err != nil {
+		if driver.IsUnauthorized(err) || driver.IsForbidden(err) {
+			return newFatalErrorf("user from secret %s does not have backup privileges", backup.Spec.User.CredentialsSecretName)
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package backup
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/arangodb/go-driver"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+)
+
+func Test_User_Authentication(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	secrets := handler.kubeClient.CoreV1().Secrets("test")
+
+	_, err := secrets.Create(&core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Name: "backup-user",
+		},
+		Data: map[string][]byte{
+			constants.SecretUsername: []byte("backup"),
+			constants.SecretPassword: []byte("password"),
+		},
+	})
+	require.NoError(t, err)
+
+	// Act
+	auth, err := backupUserAuthentication(secrets, &backupApi.ArangoBackupSpecUser{
+		CredentialsSecretName: "backup-user",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, driver.AuthenticationTypeBasic, auth.Type())
+	require.Equal(t, "backup", auth.Get("username"))
+}
+
+func Test_User_Authentication_MissingSecret(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	// Act
+	_, err := backupUserAuthentication(handler.kubeClient.CoreV1().Secrets("test"), &backupApi.ArangoBackupSpecUser{
+		CredentialsSecretName: "backup-user",
+	})
+
+	// Assert
+	require.Error(t, err)
+	require.True(t, isTemporaryError(err))
+}
+
+func Test_User_InsufficientPrivileges(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{
+		listError: driver.ArangoError{
+			HasError: true,
+			Code:     http.StatusForbidden,
+		},
+	})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.User = &backupApi.ArangoBackupSpecUser{
+		CredentialsSecretName: "backup-user",
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateFailed, false)
+	require.Equal(t, createStateMessage(backupApi.ArangoBackupStateCreate, backupApi.ArangoBackupStateFailed,
+		"user from secret backup-user does not have backup privileges"), newObj.Status.Message)
+}
+
+func Test_User_Privileges(t *testing.T) {
+	// Arrange
+	handler, _ := newErrorsFakeHandler(mockErrorsArangoClientBackup{})
+
+	obj, deployment := newObjectSet(backupApi.ArangoBackupStateCreate)
+	obj.Spec.User = &backupApi.ArangoBackupSpecUser{
+		CredentialsSecretName: "backup-user",
+	}
+
+	// Act
+	createArangoDeployment(t, handler, deployment)
+	createArangoBackup(t, handler, obj)
+
+	require.NoError(t, handler.Handle(newItemFromBackup(operation.Update, obj)))
+
+	// Assert
+	newObj := refreshArangoBackup(t, handler, obj)
+	checkBackup(t, newObj, backupApi.ArangoBackupStateReady, true)
+}
@@ -41,6 +41,7 @@ var (
 		backupApi.ArangoBackupStateDownloading,
 		backupApi.ArangoBackupStateUpload,
 		backupApi.ArangoBackupStateUploading,
+		backupApi.ArangoBackupStateWaitingForCredentials,
 	}
 )
 
@@ -54,6 +55,28 @@ func inProgress(backup *backupApi.ArangoBackup) bool {
 	return false
 }
 
+// isUploadRequested returns true if upload was specified for the backup which is not uploaded yet
+func isUploadRequested(backup *backupApi.ArangoBackup) bool {
+	if backup.Spec.Upload == nil || backup.Status.Backup == nil {
+		return false
+	}
+
+	return backup.Status.Backup.Uploaded == nil || !*backup.Status.Backup.Uploaded
+}
+
+// startsOperation returns true if handling of the backup checks for running operations with isBackupRunning
+// and can start a new operation on the deployment
+func startsOperation(backup *backupApi.ArangoBackup) bool {
+	switch backup.Status.State {
+	case backupApi.ArangoBackupStatePending:
+		return true
+	case backupApi.ArangoBackupStateReady:
+		return isUploadRequested(backup)
+	}
+
+	return false
+}
+
 func isBackupRunning(backup *backupApi.ArangoBackup, client clientBackup.ArangoBackupInterface) (bool, error) {
 	backups, err := client.List(meta.ListOptions{})
 
@@ -0,0 +1,89 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package policy
+
+import (
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ensureFinalizers adds or removes cascade finalizer according to the policy spec, returns true if policy was changed
+func ensureFinalizers(policy *backupApi.ArangoBackupPolicy) bool {
+	var finalizers utils.StringList = policy.Finalizers
+
+	has := finalizers.Has(backupApi.FinalizerArangoBackupPolicy)
+
+	if policy.Spec.GetCascade() == has {
+		return false
+	}
+
+	if has {
+		policy.Finalizers = finalizers.Remove(backupApi.FinalizerArangoBackupPolicy)
+	} else {
+		policy.Finalizers = finalizers.Append(backupApi.FinalizerArangoBackupPolicy)
+	}
+
+	return true
+}
+
+// finalize removes backups created by the policy before the policy is removed
+func (h *handler) finalize(policy *backupApi.ArangoBackupPolicy) error {
+	var finalizers utils.StringList = policy.Finalizers
+
+	if !finalizers.Has(backupApi.FinalizerArangoBackupPolicy) {
+		return nil
+	}
+
+	backups, err := h.client.BackupV1().ArangoBackups(policy.Namespace).List(meta.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, b := range backups.Items {
+		if !policy.IsOwnerOf(&b) || b.DeletionTimestamp != nil {
+			continue
+		}
+
+		if err := h.client.BackupV1().ArangoBackups(b.Namespace).Delete(b.Name, &meta.DeleteOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+
+			return err
+		}
+
+		h.eventRecorder.Normal(policy, backupRemoved, "Removed ArangoBackup: %s/%s", b.Namespace, b.Name)
+	}
+
+	policy.Finalizers = finalizers.Remove(backupApi.FinalizerArangoBackupPolicy)
+
+	if _, err := h.client.BackupV1().ArangoBackupPolicies(policy.Namespace).Update(policy); err != nil {
+		return err
+	}
+
+	h.eventRecorder.Normal(policy, finalizerChange, "Removed Finalizer: %s", backupApi.FinalizerArangoBackupPolicy)
+
+	return nil
+}
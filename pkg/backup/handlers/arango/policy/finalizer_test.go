@@ -0,0 +1,122 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	backupApi "github.com/arangodb/kube-arangodb/pkg/apis/backup/v1"
+	"github.com/arangodb/kube-arangodb/pkg/backup/operator/operation"
+	"github.com/arangodb/kube-arangodb/pkg/backup/utils"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+func Test_Finalizer_NotAddedWithoutCascade(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	name := string(uuid.NewUUID())
+	namespace := string(uuid.NewUUID())
+
+	policy := newArangoBackupPolicy("* * * */2 *", namespace, name, map[string]string{}, backupApi.ArangoBackupTemplate{})
+
+	// Act
+	createArangoBackupPolicy(t, handler, policy)
+
+	require.NoError(t, handler.Handle(newItemFromBackupPolicy(operation.Update, policy)))
+
+	// Assert
+	newPolicy := refreshArangoBackupPolicy(t, handler, policy)
+	require.Empty(t, newPolicy.Finalizers)
+}
+
+func Test_Finalizer_Cascade(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	name := string(uuid.NewUUID())
+	namespace := string(uuid.NewUUID())
+
+	policy := newArangoBackupPolicy("* * * */2 *", namespace, name, map[string]string{}, backupApi.ArangoBackupTemplate{})
+	policy.Spec.Cascade = util.NewBool(true)
+	policy.Status.Scheduled = meta.Time{
+		Time: time.Now().Add(-1 * time.Hour),
+	}
+
+	database := newArangoDeployment(namespace, map[string]string{})
+
+	other := newArangoBackupPolicy("* * * */2 *", namespace, string(uuid.NewUUID()), map[string]string{}, backupApi.ArangoBackupTemplate{})
+	otherBackup := other.NewBackup(database)
+
+	createArangoBackupPolicy(t, handler, policy)
+	createArangoDeployment(t, handler, database)
+
+	_, err := handler.client.BackupV1().ArangoBackups(namespace).Create(otherBackup)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(newItemFromBackupPolicy(operation.Update, policy)))
+
+	newPolicy := refreshArangoBackupPolicy(t, handler, policy)
+	require.True(t, utils.StringList(newPolicy.Finalizers).Has(backupApi.FinalizerArangoBackupPolicy))
+	require.Len(t, listArangoBackups(t, handler, namespace), 2)
+
+	// Act
+	now := meta.Now()
+	newPolicy.DeletionTimestamp = &now
+	updateArangoBackupPolicy(t, handler, newPolicy)
+
+	require.NoError(t, handler.Handle(newItemFromBackupPolicy(operation.Update, policy)))
+
+	// Assert
+	newPolicy = refreshArangoBackupPolicy(t, handler, policy)
+	require.Empty(t, newPolicy.Finalizers)
+
+	backups := listArangoBackups(t, handler, namespace)
+	require.Len(t, backups, 1)
+	require.Equal(t, otherBackup.Name, backups[0].Name)
+}
+
+func Test_Finalizer_RemovedWhenCascadeDisabled(t *testing.T) {
+	// Arrange
+	handler := newFakeHandler()
+
+	name := string(uuid.NewUUID())
+	namespace := string(uuid.NewUUID())
+
+	policy := newArangoBackupPolicy("* * * */2 *", namespace, name, map[string]string{}, backupApi.ArangoBackupTemplate{})
+	policy.Spec.Cascade = util.NewBool(false)
+	policy.Finalizers = []string{backupApi.FinalizerArangoBackupPolicy}
+
+	// Act
+	createArangoBackupPolicy(t, handler, policy)
+
+	require.NoError(t, handler.Handle(newItemFromBackupPolicy(operation.Update, policy)))
+
+	// Assert
+	newPolicy := refreshArangoBackupPolicy(t, handler, policy)
+	require.Empty(t, newPolicy.Finalizers)
+}
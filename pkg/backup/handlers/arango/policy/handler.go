@@ -25,6 +25,7 @@ package policy
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/apis/backup"
@@ -44,9 +45,11 @@ import (
 )
 
 const (
-	backupCreated = "ArangoBackupCreated"
-	policyError   = "Error"
-	rescheduled   = "Rescheduled"
+	backupCreated   = "ArangoBackupCreated"
+	backupRemoved   = "ArangoBackupRemoved"
+	finalizerChange = "FinalizerChange"
+	policyError     = "Error"
+	rescheduled     = "Rescheduled"
 )
 
 type handler struct {
@@ -55,6 +58,9 @@ type handler struct {
 	eventRecorder event.RecorderInstance
 
 	operator operator.Operator
+
+	lock    sync.Mutex
+	mutexes map[string]*sync.Mutex
 }
 
 func (*handler) Name() string {
@@ -67,12 +73,32 @@ func (h *handler) Handle(item operation.Item) error {
 		return nil
 	}
 
+	m := h.getPolicyMutex(item.Namespace, item.Name)
+	m.Lock()
+	defer m.Unlock()
+
 	// Get Backup object. It also cover NotFound case
 	policy, err := h.client.BackupV1().ArangoBackupPolicies(item.Namespace).Get(item.Name, meta.GetOptions{})
 	if err != nil {
 		return err
 	}
 
+	// Remove created backups before policy is gone
+	if policy.DeletionTimestamp != nil {
+		return h.finalize(policy)
+	}
+
+	if ensureFinalizers(policy) {
+		if _, err = h.client.BackupV1().ArangoBackupPolicies(item.Namespace).Update(policy); err != nil {
+			return err
+		}
+
+		policy, err = h.client.BackupV1().ArangoBackupPolicies(item.Namespace).Get(item.Name, meta.GetOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
 	status, err := h.processBackupPolicy(policy.DeepCopy())
 	if err != nil {
 		return err
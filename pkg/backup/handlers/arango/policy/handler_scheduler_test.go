@@ -121,6 +121,7 @@ func Test_Scheduler_Valid_OneObject_SelectAll(t *testing.T) {
 	isInList(t, backups, database)
 	require.NotNil(t, backups[0].Spec.PolicyName)
 	require.Equal(t, policy.Name, *backups[0].Spec.PolicyName)
+	require.True(t, policy.IsOwnerOf(&backups[0]))
 }
 
 func Test_Scheduler_Valid_OneObject_Selector(t *testing.T) {
@@ -0,0 +1,47 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package policy
+
+import (
+	"fmt"
+	"sync"
+)
+
+// getPolicyMutex returns mutex which serializes processing of one policy, so concurrent workers
+// do not create duplicated backups for the same schedule
+func (h *handler) getPolicyMutex(namespace, name string) *sync.Mutex {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.mutexes == nil {
+		h.mutexes = map[string]*sync.Mutex{}
+	}
+
+	key := fmt.Sprintf("%s/%s", namespace, name)
+
+	if _, ok := h.mutexes[key]; !ok {
+		h.mutexes[key] = &sync.Mutex{}
+	}
+
+	return h.mutexes[key]
+}
@@ -0,0 +1,56 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package operator
+
+import (
+	"reflect"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpdateFilter decides if update of the object is passed to the work queue.
+// Returns true if update needs to be handled
+type UpdateFilter func(oldObj, newObj meta.Object) bool
+
+// IgnoreStatusUpdates filters out updates which changed only the status of the object, including periodic resync.
+// Generation is used to detect spec changes, so filter is effective only for resources with status subresource.
+// Objects without generation are always handled. Handler of the filtered resource is responsible for requeue of the items
+// which need to be handled again without spec or metadata change.
+func IgnoreStatusUpdates(oldObj, newObj meta.Object) bool {
+	if newObj.GetGeneration() == 0 || oldObj.GetGeneration() != newObj.GetGeneration() {
+		return true
+	}
+
+	if !reflect.DeepEqual(oldObj.GetDeletionTimestamp(), newObj.GetDeletionTimestamp()) {
+		return true
+	}
+
+	if !reflect.DeepEqual(oldObj.GetLabels(), newObj.GetLabels()) ||
+		!reflect.DeepEqual(oldObj.GetAnnotations(), newObj.GetAnnotations()) ||
+		!reflect.DeepEqual(oldObj.GetFinalizers(), newObj.GetFinalizers()) ||
+		!reflect.DeepEqual(oldObj.GetOwnerReferences(), newObj.GetOwnerReferences()) {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,131 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_IgnoreStatusUpdates(t *testing.T) {
+	obj := &core.Pod{
+		ObjectMeta: meta.ObjectMeta{
+			Name:       "test",
+			Generation: 1,
+		},
+	}
+
+	// Resync and status change
+	require.False(t, IgnoreStatusUpdates(obj, obj.DeepCopy()))
+
+	// Spec change
+	changed := obj.DeepCopy()
+	changed.Generation = 2
+	require.True(t, IgnoreStatusUpdates(obj, changed))
+
+	// Metadata change
+	changed = obj.DeepCopy()
+	changed.Annotations = map[string]string{"test": "test"}
+	require.True(t, IgnoreStatusUpdates(obj, changed))
+
+	changed = obj.DeepCopy()
+	changed.Finalizers = []string{"test"}
+	require.True(t, IgnoreStatusUpdates(obj, changed))
+
+	changed = obj.DeepCopy()
+	now := meta.Now()
+	changed.DeletionTimestamp = &now
+	require.True(t, IgnoreStatusUpdates(obj, changed))
+
+	// Objects without generation
+	obj.Generation = 0
+	require.True(t, IgnoreStatusUpdates(obj, obj.DeepCopy()))
+}
+
+func Test_Operator_InformerFilter(t *testing.T) {
+	// Arrange
+	name := string(uuid.NewUUID())
+	o := NewOperator(name, name)
+
+	m, i := mockSimpleObject(name, true)
+	require.NoError(t, o.RegisterHandler(m))
+
+	client := fake.NewSimpleClientset()
+	informer := informers.NewSharedInformerFactory(client, time.Second)
+
+	require.NoError(t, o.RegisterInformer(informer.Core().V1().Pods().Informer(), "", "v1", "pods", IgnoreStatusUpdates))
+	require.NoError(t, o.RegisterStarter(informer))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	require.NoError(t, o.Start(4, stopCh))
+
+	pod, err := client.CoreV1().Pods("test").Create(&core.Pod{
+		ObjectMeta: meta.ObjectMeta{
+			Name:       "test",
+			Generation: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, waitForItems(t, i, 1, time.Second), 1)
+
+	// Act & Assert
+	t.Run("Status update is ignored", func(t *testing.T) {
+		pod.Status.Phase = core.PodRunning
+		pod, err = client.CoreV1().Pods("test").UpdateStatus(pod)
+		require.NoError(t, err)
+
+		time.Sleep(50 * time.Millisecond)
+		require.Len(t, i, 0)
+	})
+
+	t.Run("Resync is ignored", func(t *testing.T) {
+		time.Sleep(1500 * time.Millisecond)
+		require.Len(t, i, 0)
+	})
+
+	t.Run("Metadata update is handled", func(t *testing.T) {
+		pod.Annotations = map[string]string{"test": "test"}
+		pod, err = client.CoreV1().Pods("test").Update(pod)
+		require.NoError(t, err)
+
+		require.Len(t, waitForItems(t, i, 1, time.Second), 1)
+	})
+
+	t.Run("Spec update is handled", func(t *testing.T) {
+		pod.Generation = 2
+		pod, err = client.CoreV1().Pods("test").Update(pod)
+		require.NoError(t, err)
+
+		require.Len(t, waitForItems(t, i, 1, time.Second), 1)
+	})
+}
@@ -28,12 +28,13 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-func newResourceEventHandler(operator Operator, group, version, kind string) cache.ResourceEventHandler {
+func newResourceEventHandler(operator Operator, group, version, kind string, filters ...UpdateFilter) cache.ResourceEventHandler {
 	return &resourceEventWrapper{
 		Operator: operator,
 		Group:    group,
 		Version:  version,
 		Kind:     kind,
+		Filters:  filters,
 	}
 }
 
@@ -41,6 +42,8 @@ type resourceEventWrapper struct {
 	Operator Operator
 
 	Group, Version, Kind string
+
+	Filters []UpdateFilter
 }
 
 func (r *resourceEventWrapper) push(o operation.Operation, obj interface{}) {
@@ -60,6 +63,19 @@ func (r *resourceEventWrapper) OnAdd(obj interface{}) {
 }
 
 func (r *resourceEventWrapper) OnUpdate(oldObj, newObj interface{}) {
+	if len(r.Filters) > 0 {
+		oldObject, oldOk := oldObj.(meta.Object)
+		newObject, newOk := newObj.(meta.Object)
+
+		if oldOk && newOk {
+			for _, filter := range r.Filters {
+				if !filter(oldObject, newObject) {
+					return
+				}
+			}
+		}
+	}
+
 	r.push(operation.Update, newObj)
 }
 
@@ -52,11 +52,12 @@ type Operator interface {
 
 	Start(threadiness int, stopCh <-chan struct{}) error
 
-	RegisterInformer(informer cache.SharedIndexInformer, group, version, kind string) error
+	RegisterInformer(informer cache.SharedIndexInformer, group, version, kind string, filters ...UpdateFilter) error
 	RegisterStarter(starter Starter) error
 	RegisterHandler(handler Handler) error
 
 	EnqueueItem(item operation.Item)
+	EnqueueItemAfter(item operation.Item, delay time.Duration)
 	ProcessItem(item operation.Item) error
 }
 
@@ -155,7 +156,11 @@ func (o *operator) EnqueueItem(item operation.Item) {
 	o.workqueue.Add(item.String())
 }
 
-func (o *operator) RegisterInformer(informer cache.SharedIndexInformer, group, version, kind string) error {
+func (o *operator) EnqueueItemAfter(item operation.Item, delay time.Duration) {
+	o.workqueue.AddAfter(item.String(), delay)
+}
+
+func (o *operator) RegisterInformer(informer cache.SharedIndexInformer, group, version, kind string, filters ...UpdateFilter) error {
 	o.lock.Lock()
 	defer o.lock.Unlock()
 
@@ -171,7 +176,7 @@ func (o *operator) RegisterInformer(informer cache.SharedIndexInformer, group, v
 
 	o.informers = append(o.informers, informer)
 
-	informer.AddEventHandler(newResourceEventHandler(o, group, version, kind))
+	informer.AddEventHandler(newResourceEventHandler(o, group, version, kind, filters...))
 
 	return nil
 }
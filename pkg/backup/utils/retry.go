@@ -23,6 +23,7 @@
 package utils
 
 import (
+	"math/rand"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -52,3 +53,31 @@ func Retry(retries int, interval time.Duration, action func() error) error {
 		<-t.C
 	}
 }
+
+// RetryBackoff retries action with exponentially growing intervals, starting from interval and capped at maxInterval.
+// Every interval is randomized to the range [interval/2, interval] to spread retries of concurrent actions.
+// Last error is returned if next retry would start after the deadline.
+func RetryBackoff(interval, maxInterval, deadline time.Duration, action func() error) error {
+	end := time.Now().Add(deadline)
+
+	for {
+		err := action()
+
+		if err == nil {
+			return nil
+		}
+
+		wait := interval/2 + time.Duration(rand.Int63n(int64(interval/2)+1))
+		if time.Now().Add(wait).After(end) {
+			return err
+		}
+
+		log.Error().Err(err).Msgf("Failure, retrying in %s", wait)
+		time.Sleep(wait)
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
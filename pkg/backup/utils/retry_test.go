@@ -0,0 +1,60 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package utils
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RetryBackoff_Success(t *testing.T) {
+	attempts := 0
+
+	err := RetryBackoff(time.Millisecond, 4*time.Millisecond, time.Second, func() error {
+		attempts++
+		if attempts < 5 {
+			return fmt.Errorf("failure %d", attempts)
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 5, attempts)
+}
+
+func Test_RetryBackoff_Deadline(t *testing.T) {
+	attempts := 0
+	start := time.Now()
+
+	err := RetryBackoff(10*time.Millisecond, 20*time.Millisecond, 100*time.Millisecond, func() error {
+		attempts++
+		return fmt.Errorf("failure %d", attempts)
+	})
+
+	require.EqualError(t, err, fmt.Sprintf("failure %d", attempts))
+	require.True(t, attempts > 1)
+	require.True(t, time.Since(start) < time.Second)
+}
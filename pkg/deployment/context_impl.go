@@ -97,6 +97,11 @@ func (d *Deployment) GetLifecycleImage() string {
 	return d.config.LifecycleImage
 }
 
+// IsReconcileDryRunForced returns true if reconcile dry run is enabled for all deployments by the operator
+func (d *Deployment) IsReconcileDryRunForced() bool {
+	return d.config.ReconcileDryRun
+}
+
 // GetOperatorUUIDImage returns the image name containing the uuid helper (== name of operator image)
 func (d *Deployment) GetOperatorUUIDImage() string {
 	return d.config.OperatorUUIDInitImage
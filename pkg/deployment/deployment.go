@@ -66,6 +66,8 @@ type Config struct {
 	MetricsExporterImage  string
 	ArangoImage           string
 	Scope                 scope.Scope
+	// ReconcileDryRun enables reconcile dry run of the deployment, as with the reconcile dry run annotation
+	ReconcileDryRun bool
 }
 
 // Dependencies holds dependent services for a Deployment
@@ -76,6 +78,8 @@ type Dependencies struct {
 	KubeMonitoringCli monitoringClient.MonitoringV1Interface
 	DatabaseCRCli     versioned.Interface
 	EventRecorder     record.EventRecorder
+	// ReconcileHooks are invoked around the execution of plan actions
+	ReconcileHooks []reconcile.ReconcileHook
 }
 
 // deploymentEventType strongly typed type of event
@@ -92,9 +96,11 @@ type deploymentEvent struct {
 }
 
 const (
-	deploymentEventQueueSize = 256
-	minInspectionInterval    = 250 * util.Interval(time.Millisecond) // Ensure we inspect the generated resources no less than with this interval
-	maxInspectionInterval    = 10 * util.Interval(time.Second)       // Ensure we inspect the generated resources no less than with this interval
+	deploymentEventQueueSize       = 256
+	minInspectionInterval          = 250 * util.Interval(time.Millisecond) // Ensure we inspect the generated resources no less than with this interval
+	maxInspectionInterval          = 10 * util.Interval(time.Second)       // Ensure we inspect the generated resources no less than with this interval
+	maxThrottledInspectionInterval = 5 * util.Interval(time.Minute)        // Maximum backoff honored when Kubernetes API is throttling requests
+	nodeCacheRefreshInterval       = time.Minute                           // Nodes are cluster scoped, they are listed at most once per this interval
 )
 
 // Deployment is the in process state of an ArangoDeployment.
@@ -124,6 +130,7 @@ type Deployment struct {
 	chaosMonkey               *chaos.Monkey
 	syncClientCache           client.ClientCache
 	haveServiceMonitorCRD     bool
+	nodeCache                 *inspector.NodeCache
 }
 
 // New creates a new Deployment from the given API object.
@@ -138,12 +145,13 @@ func New(config Config, deps Dependencies, apiObject *api.ArangoDeployment) (*De
 		deps:      deps,
 		eventCh:   make(chan *deploymentEvent, deploymentEventQueueSize),
 		stopCh:    make(chan struct{}),
+		nodeCache: inspector.NewNodeCache(deps.KubeCli, nodeCacheRefreshInterval),
 	}
 
 	d.clientCache = newClientCache(d.getArangoDeployment, conn.NewFactory(d.getAuth, d.getConnConfig))
 
 	d.status.last = *(apiObject.Status.DeepCopy())
-	d.reconciler = reconcile.NewReconciler(deps.Log, d)
+	d.reconciler = reconcile.NewReconciler(deps.Log, d, deps.ReconcileHooks...)
 	d.resilience = resilience.NewResilience(deps.Log, d)
 	d.resources = resources.NewResources(deps.Log, d)
 	if d.status.last.AcceptedSpec == nil {
@@ -187,6 +195,7 @@ func (d *Deployment) Delete() {
 	d.deps.Log.Info().Msg("deployment is deleted by user")
 	if atomic.CompareAndSwapInt32(&d.stopped, 0, 1) {
 		close(d.stopCh)
+		d.reconciler.DeleteMetrics()
 	}
 }
 
@@ -243,7 +252,7 @@ func (d *Deployment) run() {
 	for {
 		select {
 		case <-d.stopCh:
-			cachedStatus, err := inspector.NewInspector(d.GetKubeCli(), d.GetMonitoringV1Cli(), d.GetNamespace())
+			cachedStatus, err := inspector.NewInspector(d.GetKubeCli(), d.GetMonitoringV1Cli(), d.GetNamespace(), d.nodeCache)
 			if err != nil {
 				log.Error().Err(err).Msg("Unable to get resources")
 			}
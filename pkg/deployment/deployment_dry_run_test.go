@@ -0,0 +1,103 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package deployment
+
+import (
+	"testing"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/reconcile"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func runDryRunEnsureResources(t *testing.T, config Config, annotations map[string]string) (int, int) {
+	// Arrange
+	d, _ := createTestDeployment(config, &api.ArangoDeployment{
+		Spec: api.DeploymentSpec{
+			Image:          util.NewString(testImage),
+			Authentication: noAuthentication,
+			TLS:            noTLS,
+		},
+	})
+	d.apiObject.Annotations = annotations
+	d.reconciler = reconcile.NewReconciler(d.deps.Log, d)
+
+	agent := firstAgentStatus
+	agent.PersistentVolumeClaimName = "agent-pvc"
+	d.status.last = api.DeploymentStatus{
+		Members: api.DeploymentStatusMembers{
+			Agents: api.MemberStatusList{
+				agent,
+			},
+		},
+		Images: createTestImages(false),
+	}
+
+	_, err := d.deps.DatabaseCRCli.DatabaseV1().ArangoDeployments(testNamespace).Create(d.apiObject)
+	require.NoError(t, err)
+
+	cache, err := inspector.NewInspector(d.GetKubeCli(), d.GetMonitoringV1Cli(), d.GetNamespace(), d.nodeCache)
+	require.NoError(t, err)
+
+	// Act
+	_, err = d.ensureResources(minInspectionInterval, cache)
+	require.NoError(t, err)
+
+	pods, err := d.deps.KubeCli.CoreV1().Pods(testNamespace).List(metav1.ListOptions{})
+	require.NoError(t, err)
+
+	pvcs, err := d.deps.KubeCli.CoreV1().PersistentVolumeClaims(testNamespace).List(metav1.ListOptions{})
+	require.NoError(t, err)
+
+	return len(pods.Items), len(pvcs.Items)
+}
+
+func Test_DryRun_EnsureResources_Disabled(t *testing.T) {
+	pods, pvcs := runDryRunEnsureResources(t, Config{}, nil)
+
+	// Assert
+	require.Equal(t, 1, pods)
+	require.Equal(t, 1, pvcs)
+}
+
+func Test_DryRun_EnsureResources_Annotation(t *testing.T) {
+	pods, pvcs := runDryRunEnsureResources(t, Config{}, map[string]string{
+		constants.AnnotationReconcileDryRun: "true",
+	})
+
+	// Assert
+	require.Equal(t, 0, pods)
+	require.Equal(t, 0, pvcs)
+}
+
+func Test_DryRun_EnsureResources_Operator(t *testing.T) {
+	pods, pvcs := runDryRunEnsureResources(t, Config{ReconcileDryRun: true}, nil)
+
+	// Assert
+	require.Equal(t, 0, pods)
+	require.Equal(t, 0, pvcs)
+}
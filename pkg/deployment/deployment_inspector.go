@@ -28,6 +28,7 @@ import (
 
 	operatorErrors "github.com/arangodb/kube-arangodb/pkg/util/errors"
 
+	"github.com/arangodb/kube-arangodb/pkg/deployment/reconcile"
 	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
 
 	"github.com/pkg/errors"
@@ -65,7 +66,7 @@ func (d *Deployment) inspectDeployment(lastInterval util.Interval) util.Interval
 	deploymentName := d.apiObject.GetName()
 	defer metrics.SetDuration(inspectDeploymentDurationGauges.WithLabelValues(deploymentName), start)
 
-	cachedStatus, err := inspector.NewInspector(d.GetKubeCli(), d.GetMonitoringV1Cli(), d.GetNamespace())
+	cachedStatus, err := inspector.NewInspector(d.GetKubeCli(), d.GetMonitoringV1Cli(), d.GetNamespace(), d.nodeCache)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable to get resources")
 		return minInspectionInterval // Retry ASAP
@@ -100,6 +101,15 @@ func (d *Deployment) inspectDeployment(lastInterval util.Interval) util.Interval
 		}
 
 		if inspectNextInterval, err := d.inspectDeploymentWithError(ctx, nextInterval, cachedStatus); err != nil {
+			if delay, ok := reconcile.IsThrottled(err); ok {
+				// Kubernetes API is rate-limiting us, honor the requested backoff
+				log.Warn().Err(err).Str("delay", delay.String()).Msg("Kubernetes API throttled reconciliation")
+				if delay <= 0 {
+					return maxInspectionInterval
+				}
+				return util.Interval(delay).ReduceTo(maxThrottledInspectionInterval)
+			}
+
 			if !operatorErrors.IsReconcile(err) {
 				nextInterval = inspectNextInterval
 				hasError = true
@@ -262,6 +272,11 @@ func (d *Deployment) inspectDeploymentWithError(ctx context.Context, lastInterva
 		return minInspectionInterval, errors.Wrapf(err, "Bootstrap failed")
 	}
 
+	// Cleanup removes members and pods, it is skipped in dry run
+	if d.reconciler.IsDryRun() {
+		return
+	}
+
 	// Inspect deployment for obsolete members
 	if err := d.resources.CleanupRemovedMembers(); err != nil {
 		return minInspectionInterval, errors.Wrapf(err, "Removed member cleanup failed")
@@ -278,6 +293,12 @@ func (d *Deployment) inspectDeploymentWithError(ctx context.Context, lastInterva
 }
 
 func (d *Deployment) ensureResources(lastInterval util.Interval, cachedStatus inspector.Inspector) (util.Interval, error) {
+	// In dry run member resources are not created or updated
+	if d.reconciler.IsDryRun() {
+		d.deps.Log.Debug().Msg("Dry run, resources not ensured")
+		return lastInterval, nil
+	}
+
 	// Ensure all resources are created
 	if d.haveServiceMonitorCRD {
 		if err := d.resources.EnsureServiceMonitor(); err != nil {
@@ -0,0 +1,52 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package pod
+
+import (
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil/interfaces"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TopologySpreadConstraints returns constraints for the pod. Constraints without label selector
+// are scoped to the pods of the same deployment and role.
+func TopologySpreadConstraints(p interfaces.PodCreator, constraints []core.TopologySpreadConstraint) []core.TopologySpreadConstraint {
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	result := make([]core.TopologySpreadConstraint, len(constraints))
+
+	for id, constraint := range constraints {
+		constraint.DeepCopyInto(&result[id])
+
+		if result[id].LabelSelector == nil {
+			result[id].LabelSelector = &meta.LabelSelector{
+				MatchLabels: k8sutil.LabelsForDeployment(p.GetName(), p.GetRole()),
+			}
+		}
+	}
+
+	return result
+}
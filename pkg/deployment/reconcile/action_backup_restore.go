@@ -24,6 +24,7 @@ package reconcile
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/arangodb/go-driver"
 
@@ -80,6 +81,15 @@ func (a actionBackupRestore) Start(ctx context.Context) (bool, error) {
 		return true, nil
 	}
 
+	// Backup of the other deployment needs to be present in this deployment, ID is the same after download
+	if backupResource.Spec.Deployment.Name != a.actionCtx.GetName() {
+		if message, err := a.checkBackupPresent(ctx, dbc, backupResource.Status.Backup.ID); err != nil {
+			return false, err
+		} else if message != "" {
+			return true, a.setRestoreFailed(spec.GetRestoreFrom(), message)
+		}
+	}
+
 	if err := a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
 		result := &api.DeploymentRestoreResult{
 			RequestedFrom: spec.GetRestoreFrom(),
@@ -121,3 +131,31 @@ func (a actionBackupRestore) Start(ctx context.Context) (bool, error) {
 
 	return true, nil
 }
+
+// checkBackupPresent returns message if backup is not present in the deployment
+func (a actionBackupRestore) checkBackupPresent(ctx context.Context, dbc driver.Client, id string) (string, error) {
+	backups, err := dbc.Backup().List(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := backups[driver.BackupID(id)]; !ok {
+		return fmt.Sprintf("backup %s is not present in deployment %s, it needs to be downloaded first with ArangoBackup download", id, a.actionCtx.GetName()), nil
+	}
+
+	return "", nil
+}
+
+func (a actionBackupRestore) setRestoreFailed(requestedFrom, message string) error {
+	a.log.Warn().Str("backup", requestedFrom).Msgf("Backup restore failed: %s", message)
+
+	return a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
+		s.Restore = &api.DeploymentRestoreResult{
+			RequestedFrom: requestedFrom,
+			State:         api.DeploymentRestoreStateRestoreFailed,
+			Message:       message,
+		}
+
+		return true
+	}, true)
+}
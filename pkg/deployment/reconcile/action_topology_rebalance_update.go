@@ -0,0 +1,76 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package reconcile
+
+import (
+	"context"
+	"strconv"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/rs/zerolog"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	topologySkew = "skew"
+)
+
+func init() {
+	registerAction(api.ActionTypeTopologyRebalanceUpdate, newTopologyRebalanceUpdateAction)
+}
+
+func newTopologyRebalanceUpdateAction(log zerolog.Logger, action api.Action, actionCtx ActionContext) Action {
+	a := &topologyRebalanceUpdateAction{}
+
+	a.actionImpl = newActionImplDefRef(log, action, actionCtx, defaultTimeout)
+
+	return a
+}
+
+type topologyRebalanceUpdateAction struct {
+	actionImpl
+
+	actionEmptyCheckProgress
+}
+
+func (a *topologyRebalanceUpdateAction) Start(ctx context.Context) (bool, error) {
+	skew, err := strconv.Atoi(a.action.Params[topologySkew])
+	if err != nil {
+		a.log.Error().Err(err).Msgf("Skew param is invalid")
+		return true, nil
+	}
+
+	if err := a.actionCtx.WithStatusUpdate(func(s *api.DeploymentStatus) bool {
+		s.TopologyRebalance = &api.DeploymentTopologyRebalance{
+			Time:  meta.Now(),
+			Group: a.action.Group,
+			Skew:  int32(skew),
+		}
+
+		return true
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
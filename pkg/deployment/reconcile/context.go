@@ -120,4 +120,6 @@ type Context interface {
 	GetName() string
 	// GetAuthentication return authentication for members
 	GetAuthentication() conn.Auth
+	// IsReconcileDryRunForced returns true if reconcile dry run is enabled for all deployments by the operator
+	IsReconcileDryRunForced() bool
 }
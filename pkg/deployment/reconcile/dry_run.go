@@ -0,0 +1,91 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package reconcile
+
+import (
+	"strings"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/rs/zerolog"
+)
+
+// IsDryRun returns true if the plan of the deployment should be only reported and not applied. Dry run is enabled
+// with the annotation of the deployment or for all deployments by the operator.
+func (d *Reconciler) IsDryRun() bool {
+	return d.context.IsReconcileDryRunForced() ||
+		strings.EqualFold(d.context.GetAPIObject().GetAnnotations()[constants.AnnotationReconcileDryRun], "true")
+}
+
+// dryRunPlanBuilderContext logs events of the plan builders instead of creating them
+type dryRunPlanBuilderContext struct {
+	PlanBuilderContext
+
+	log zerolog.Logger
+}
+
+func (d dryRunPlanBuilderContext) CreateEvent(evt *k8sutil.Event) {
+	d.log.Info().Str("reason", evt.Reason).Msgf("Dry run, event not created: %s", evt.Message)
+}
+
+// reportDryRunPlan logs actions which would be added to the plan. Plan is logged only when it changes.
+func (d *Reconciler) reportDryRunPlan(plan api.Plan) {
+	if dryRunPlanEqual(d.dryRunPlan, plan) {
+		return
+	}
+
+	d.dryRunPlan = plan
+
+	if len(plan) == 0 {
+		d.log.Info().Msg("Dry run, no actions planned")
+		return
+	}
+
+	for id, action := range plan {
+		d.log.Info().
+			Int("plan-index", id).
+			Str("action-type", string(action.Type)).
+			Str("group", action.Group.AsRole()).
+			Str("member-id", action.MemberID).
+			Msgf("Dry run, action not applied: %s", action.Reason)
+	}
+}
+
+// dryRunPlanEqual compares plans ignoring action IDs and times, which are different on every plan creation
+func dryRunPlanEqual(a, b api.Plan) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for id := range a {
+		if a[id].Type != b[id].Type ||
+			a[id].Group != b[id].Group ||
+			a[id].MemberID != b[id].MemberID ||
+			a[id].Reason != b[id].Reason {
+			return false
+		}
+	}
+
+	return true
+}
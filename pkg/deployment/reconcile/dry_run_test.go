@@ -0,0 +1,183 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util/constants"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_DryRun_CreatePlan(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	spec := api.DeploymentSpec{
+		Mode: api.NewMode(api.DeploymentModeCluster),
+	}
+	spec.SetDefaults("test")
+
+	c := &testContext{
+		ArangoDeployment: &api.ArangoDeployment{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "test_depl",
+				Namespace: "test",
+				Annotations: map[string]string{
+					constants.AnnotationReconcileDryRun: "true",
+				},
+			},
+			Spec: spec,
+		},
+	}
+	addAgentsToStatus(t, &c.ArangoDeployment.Status, 3)
+
+	r := NewReconciler(zerolog.Nop(), c)
+
+	// Act
+	err, changed := r.CreatePlan(ctx, inspector.NewEmptyInspector())
+
+	// Assert
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Len(t, c.ArangoDeployment.Status.Plan, 0)
+	require.Len(t, r.dryRunPlan, 6)
+	require.Equal(t, api.ActionTypeAddMember, r.dryRunPlan[0].Type)
+}
+
+func Test_DryRun_Forced(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	spec := api.DeploymentSpec{
+		Mode: api.NewMode(api.DeploymentModeCluster),
+	}
+	spec.SetDefaults("test")
+
+	c := &testContext{
+		ArangoDeployment: &api.ArangoDeployment{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "test_depl",
+				Namespace: "test",
+			},
+			Spec: spec,
+		},
+		DryRunForced: true,
+	}
+	addAgentsToStatus(t, &c.ArangoDeployment.Status, 3)
+
+	r := NewReconciler(zerolog.Nop(), c)
+
+	// Act
+	err, changed := r.CreatePlan(ctx, inspector.NewEmptyInspector())
+
+	// Assert
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Len(t, c.ArangoDeployment.Status.Plan, 0)
+	require.Len(t, r.dryRunPlan, 6)
+}
+
+func Test_DryRun_ExecutePlan(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := &testContext{
+		ArangoDeployment: &api.ArangoDeployment{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "test_depl",
+				Namespace: "test",
+				Annotations: map[string]string{
+					constants.AnnotationReconcileDryRun: "true",
+				},
+			},
+		},
+	}
+	c.ArangoDeployment.Status.Plan = api.Plan{
+		api.NewAction(api.ActionTypeAddMember, api.ServerGroupDBServers, ""),
+	}
+
+	r := NewReconciler(zerolog.Nop(), c)
+
+	// Act
+	retrySoon, err := r.ExecutePlan(ctx, inspector.NewEmptyInspector())
+
+	// Assert
+	require.NoError(t, err)
+	require.False(t, retrySoon)
+	require.Len(t, c.ArangoDeployment.Status.Plan, 1)
+	require.Nil(t, c.ArangoDeployment.Status.Plan[0].StartTime)
+}
+
+func Test_DryRun_Disabled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	spec := api.DeploymentSpec{
+		Mode: api.NewMode(api.DeploymentModeCluster),
+	}
+	spec.SetDefaults("test")
+
+	c := &testContext{
+		ArangoDeployment: &api.ArangoDeployment{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "test_depl",
+				Namespace: "test",
+				Annotations: map[string]string{
+					constants.AnnotationReconcileDryRun: "false",
+				},
+			},
+			Spec: spec,
+		},
+	}
+	addAgentsToStatus(t, &c.ArangoDeployment.Status, 3)
+
+	r := NewReconciler(zerolog.Nop(), c)
+
+	// Act
+	err, _ := r.CreatePlan(ctx, inspector.NewEmptyInspector())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, c.ArangoDeployment.Status.Plan, 6)
+	require.Len(t, r.dryRunPlan, 0)
+}
+
+func Test_DryRun_PlanEqual(t *testing.T) {
+	a := api.Plan{api.NewAction(api.ActionTypeAddMember, api.ServerGroupDBServers, "")}
+	b := api.Plan{api.NewAction(api.ActionTypeAddMember, api.ServerGroupDBServers, "")}
+
+	require.True(t, dryRunPlanEqual(a, b))
+	require.True(t, dryRunPlanEqual(nil, api.Plan{}))
+	require.False(t, dryRunPlanEqual(a, nil))
+	require.False(t, dryRunPlanEqual(a, api.Plan{api.NewAction(api.ActionTypeAddMember, api.ServerGroupCoordinators, "")}))
+}
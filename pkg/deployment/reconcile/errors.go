@@ -22,8 +22,56 @@
 
 package reconcile
 
-import "github.com/pkg/errors"
+import (
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/pkg/errors"
+)
 
 var (
 	maskAny = errors.WithStack
 )
+
+// throttledError is returned when action execution was rejected by the Kubernetes API with Too Many Requests
+type throttledError struct {
+	cause error
+	delay time.Duration
+}
+
+func (t throttledError) Error() string {
+	return t.cause.Error()
+}
+
+// wrapThrottled wraps error into throttledError if Kubernetes API requested backoff
+func wrapThrottled(err error) error {
+	if err == nil || !k8sutil.IsTooManyRequests(err) {
+		return err
+	}
+
+	delay, _ := k8sutil.GetRetryAfter(err)
+
+	return throttledError{
+		cause: err,
+		delay: delay,
+	}
+}
+
+// IsThrottled returns true and the delay requested by the Kubernetes API if the given error
+// is or is caused by API throttling during action execution
+func IsThrottled(err error) (time.Duration, bool) {
+	for err != nil {
+		if t, ok := err.(throttledError); ok {
+			return t.delay, true
+		}
+
+		c, ok := err.(interface{ Cause() error })
+		if !ok {
+			return 0, false
+		}
+
+		err = c.Cause()
+	}
+
+	return 0, false
+}
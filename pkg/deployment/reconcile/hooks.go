@@ -0,0 +1,97 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package reconcile
+
+import (
+	"context"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/rs/zerolog"
+)
+
+// Hook is invoked by the reconciler around the execution of plan actions.
+type Hook interface {
+	// Name returns the name of the hook used in logs.
+	Name() string
+	// BeforeAction is called before the planned action is started.
+	BeforeAction(ctx context.Context, apiObject k8sutil.APIObject, action api.Action) error
+	// AfterAction is called after the planned action has finished.
+	AfterAction(ctx context.Context, apiObject k8sutil.APIObject, action api.Action) error
+}
+
+// HookFailurePolicy defines how the reconciler reacts to a failing hook.
+type HookFailurePolicy string
+
+const (
+	// HookFailurePolicyIgnore only logs the hook error.
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore"
+	// HookFailurePolicyAbort stops the action before it is started, or removes
+	// the remaining plan when the action has already finished.
+	HookFailurePolicyAbort HookFailurePolicy = "Abort"
+)
+
+// ReconcileHook registers a hook together with its failure policy.
+type ReconcileHook struct {
+	Hook          Hook
+	FailurePolicy HookFailurePolicy
+}
+
+func (r ReconcileHook) abortOnError() bool {
+	return r.FailurePolicy == HookFailurePolicyAbort
+}
+
+// runBeforeActionHooks calls all hooks before the action is started.
+// Returns the first error of a hook with abort policy.
+func (d *Reconciler) runBeforeActionHooks(ctx context.Context, log zerolog.Logger, action api.Action) error {
+	return d.runHooks(log, "before", func(h Hook) error {
+		return h.BeforeAction(ctx, d.context.GetAPIObject(), action)
+	})
+}
+
+// runAfterActionHooks calls all hooks after the action has finished.
+// Returns the first error of a hook with abort policy.
+func (d *Reconciler) runAfterActionHooks(ctx context.Context, log zerolog.Logger, action api.Action) error {
+	return d.runHooks(log, "after", func(h Hook) error {
+		return h.AfterAction(ctx, d.context.GetAPIObject(), action)
+	})
+}
+
+func (d *Reconciler) runHooks(log zerolog.Logger, point string, call func(h Hook) error) error {
+	for _, h := range d.hooks {
+		if h.Hook == nil {
+			continue
+		}
+
+		if err := call(h.Hook); err != nil {
+			if h.abortOnError() {
+				log.Warn().Err(err).Str("hook", h.Hook.Name()).Str("point", point).Msg("Reconcile hook failed, aborting")
+				return maskAny(err)
+			}
+
+			log.Warn().Err(err).Str("hook", h.Hook.Name()).Str("point", point).Msg("Reconcile hook failed, ignoring")
+		}
+	}
+
+	return nil
+}
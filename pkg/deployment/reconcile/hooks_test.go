@@ -0,0 +1,164 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	actionTypeTestReady api.ActionType = "TestReady"
+)
+
+func init() {
+	registerAction(actionTypeTestReady, func(log zerolog.Logger, action api.Action, actionCtx ActionContext) Action {
+		return &testReadyAction{
+			actionImpl: newActionImplDefRef(log, action, actionCtx, time.Minute),
+		}
+	})
+}
+
+type testReadyAction struct {
+	actionImpl
+
+	actionEmptyCheckProgress
+}
+
+func (t *testReadyAction) Start(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+type testHook struct {
+	beforeErr, afterErr error
+
+	before, after []api.ActionType
+}
+
+func (t *testHook) Name() string {
+	return "test"
+}
+
+func (t *testHook) BeforeAction(ctx context.Context, apiObject k8sutil.APIObject, action api.Action) error {
+	t.before = append(t.before, action.Type)
+	return t.beforeErr
+}
+
+func (t *testHook) AfterAction(ctx context.Context, apiObject k8sutil.APIObject, action api.Action) error {
+	t.after = append(t.after, action.Type)
+	return t.afterErr
+}
+
+func newHookTestContext() *testContext {
+	c := &testContext{
+		ArangoDeployment: &api.ArangoDeployment{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "test_depl",
+				Namespace: "test",
+			},
+		},
+	}
+	c.ArangoDeployment.Status.Plan = api.Plan{
+		api.NewAction(actionTypeTestReady, api.ServerGroupDBServers, ""),
+		api.NewAction(actionTypeTestReady, api.ServerGroupDBServers, ""),
+	}
+	return c
+}
+
+func TestExecutePlanHooks(t *testing.T) {
+	// Arrange
+	c := newHookTestContext()
+	h := &testHook{}
+
+	r := NewReconciler(zerolog.Nop(), c, ReconcileHook{Hook: h, FailurePolicy: HookFailurePolicyAbort})
+
+	// Act
+	retrySoon, err := r.ExecutePlan(context.Background(), inspector.NewEmptyInspector())
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, retrySoon)
+	require.Len(t, c.ArangoDeployment.Status.Plan, 1)
+	require.Equal(t, []api.ActionType{actionTypeTestReady}, h.before)
+	require.Equal(t, []api.ActionType{actionTypeTestReady}, h.after)
+}
+
+func TestExecutePlanHooks_BeforeAbort(t *testing.T) {
+	// Arrange
+	c := newHookTestContext()
+	h := &testHook{beforeErr: errors.New("hook failed")}
+
+	r := NewReconciler(zerolog.Nop(), c, ReconcileHook{Hook: h, FailurePolicy: HookFailurePolicyAbort})
+
+	// Act
+	retrySoon, err := r.ExecutePlan(context.Background(), inspector.NewEmptyInspector())
+
+	// Assert
+	require.Error(t, err)
+	require.False(t, retrySoon)
+	require.Len(t, c.ArangoDeployment.Status.Plan, 2)
+	require.Nil(t, c.ArangoDeployment.Status.Plan[0].StartTime)
+	require.Empty(t, h.after)
+}
+
+func TestExecutePlanHooks_BeforeIgnore(t *testing.T) {
+	// Arrange
+	c := newHookTestContext()
+	h := &testHook{beforeErr: errors.New("hook failed")}
+
+	r := NewReconciler(zerolog.Nop(), c, ReconcileHook{Hook: h, FailurePolicy: HookFailurePolicyIgnore})
+
+	// Act
+	retrySoon, err := r.ExecutePlan(context.Background(), inspector.NewEmptyInspector())
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, retrySoon)
+	require.Len(t, c.ArangoDeployment.Status.Plan, 1)
+}
+
+func TestExecutePlanHooks_AfterAbort(t *testing.T) {
+	// Arrange
+	c := newHookTestContext()
+	h := &testHook{afterErr: errors.New("hook failed")}
+
+	r := NewReconciler(zerolog.Nop(), c, ReconcileHook{Hook: h, FailurePolicy: HookFailurePolicyAbort})
+
+	// Act
+	retrySoon, err := r.ExecutePlan(context.Background(), inspector.NewEmptyInspector())
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, retrySoon)
+	require.Len(t, c.ArangoDeployment.Status.Plan, 0)
+	require.NotNil(t, c.RecordedEvent)
+}
@@ -0,0 +1,100 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package reconcile
+
+import (
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Component name for metrics of this package
+	metricsComponent = "deployment_reconcile"
+
+	// operation is a label key used for the reconcile operation (create_plan|execute_plan)
+	operation = "operation"
+	// outcome is a label key used for the result of a reconcile operation (success|error|no-op)
+	outcome = "outcome"
+
+	operationCreatePlan  = "create_plan"
+	operationExecutePlan = "execute_plan"
+
+	outcomeSuccess = metrics.Success
+	outcomeError   = "error"
+	outcomeNoOp    = "no-op"
+)
+
+var (
+	reconcileDurationHistograms = metrics.MustRegisterHistogramVec(metricsComponent, "duration_seconds", "Amount of time taken by a single reconcile operation of a deployment (in sec)",
+		[]float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}, metrics.DeploymentName, metrics.Namespace, operation)
+	reconcileOutcomeCounters = metrics.MustRegisterCounterVec(metricsComponent, "outcome", "Number of reconcile operations of a deployment per outcome",
+		metrics.DeploymentName, metrics.Namespace, operation, outcome)
+
+	reconcileOperations = []string{operationCreatePlan, operationExecutePlan}
+	reconcileOutcomes   = []string{outcomeSuccess, outcomeError, outcomeNoOp}
+)
+
+// reconcileMetrics records reconcile metrics of a single deployment.
+// Labels are limited to the deployment name and namespace and fixed sets of operations and outcomes.
+type reconcileMetrics struct {
+	name, namespace string
+}
+
+func newReconcileMetrics(name, namespace string) reconcileMetrics {
+	return reconcileMetrics{
+		name:      name,
+		namespace: namespace,
+	}
+}
+
+// observe records duration since start and outcome of the operation
+func (r reconcileMetrics) observe(op, result string, start time.Time) {
+	reconcileDurationHistograms.WithLabelValues(r.name, r.namespace, op).Observe(time.Since(start).Seconds())
+	reconcileOutcomeCounters.WithLabelValues(r.name, r.namespace, op, result).Inc()
+}
+
+// delete removes all series of the deployment
+func (r reconcileMetrics) delete() {
+	for _, op := range reconcileOperations {
+		reconcileDurationHistograms.Delete(prometheus.Labels{metrics.DeploymentName: r.name, metrics.Namespace: r.namespace, operation: op})
+
+		for _, result := range reconcileOutcomes {
+			reconcileOutcomeCounters.Delete(prometheus.Labels{metrics.DeploymentName: r.name, metrics.Namespace: r.namespace, operation: op, outcome: result})
+		}
+	}
+}
+
+// reconcileOutcome returns outcome label value of the operation
+func reconcileOutcome(err error, changed bool) string {
+	if err != nil {
+		return outcomeError
+	}
+
+	if !changed {
+		return outcomeNoOp
+	}
+
+	return outcomeSuccess
+}
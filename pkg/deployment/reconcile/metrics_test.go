@@ -0,0 +1,80 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ReconcileOutcome(t *testing.T) {
+	require.Equal(t, outcomeError, reconcileOutcome(errors.New("error"), true))
+	require.Equal(t, outcomeError, reconcileOutcome(errors.New("error"), false))
+	require.Equal(t, outcomeSuccess, reconcileOutcome(nil, true))
+	require.Equal(t, outcomeNoOp, reconcileOutcome(nil, false))
+}
+
+func Test_ReconcileMetrics(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	spec := api.DeploymentSpec{
+		Mode: api.NewMode(api.DeploymentModeCluster),
+	}
+	spec.SetDefaults("test")
+
+	c := &testContext{
+		ArangoDeployment: &api.ArangoDeployment{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "metrics_depl",
+				Namespace: "metrics",
+			},
+			Spec: spec,
+		},
+	}
+	addAgentsToStatus(t, &c.ArangoDeployment.Status, 3)
+
+	r := NewReconciler(zerolog.Nop(), c)
+	defer r.DeleteMetrics()
+
+	// Act
+	err, updated := r.CreatePlan(ctx, inspector.NewEmptyInspector())
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	// Assert
+	require.Equal(t, float64(1), testutil.ToFloat64(reconcileOutcomeCounters.WithLabelValues("metrics_depl", "metrics", operationCreatePlan, outcomeSuccess)))
+
+	// Deleted series starts from zero
+	r.DeleteMetrics()
+	require.Equal(t, float64(0), testutil.ToFloat64(reconcileOutcomeCounters.WithLabelValues("metrics_depl", "metrics", operationCreatePlan, outcomeSuccess)))
+}
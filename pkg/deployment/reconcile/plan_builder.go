@@ -55,14 +55,43 @@ type upgradeDecision struct {
 // CreatePlan considers the current specification & status of the deployment creates a plan to
 // get the status in line with the specification.
 // If a plan already exists, nothing is done.
-func (d *Reconciler) CreatePlan(ctx context.Context, cachedStatus inspector.Inspector) (error, bool) {
+func (d *Reconciler) CreatePlan(ctx context.Context, cachedStatus inspector.Inspector) (err error, updated bool) {
+	defer func(start time.Time) {
+		d.metrics.observe(operationCreatePlan, reconcileOutcome(err, updated), start)
+	}(time.Now())
+
 	// Create plan
 	apiObject := d.context.GetAPIObject()
 	spec := d.context.GetSpec()
 	status, lastVersion := d.context.GetStatus()
 	builderCtx := newPlanBuilderContext(d.context)
+	dryRun := d.IsDryRun()
+	if dryRun {
+		builderCtx = dryRunPlanBuilderContext{PlanBuilderContext: builderCtx, log: d.log}
+	}
+
+	// Update upgrade pending condition
+	if conditions, changed := updateUpgradePendingCondition(d.log, spec, status, cachedStatus, builderCtx); changed && !dryRun {
+		status.Conditions = conditions
+
+		if err := d.context.UpdateStatus(status, lastVersion); err != nil {
+			return maskAny(err), false
+		}
+		return nil, true
+	}
+
 	newPlan, changed := createPlan(ctx, d.log, apiObject, status.Plan, spec, status, cachedStatus, builderCtx)
 
+	// In dry run plan is only reported, nothing is stored and executed
+	if dryRun {
+		if changed {
+			d.reportDryRunPlan(newPlan)
+		} else {
+			d.reportDryRunPlan(nil)
+		}
+		return nil, false
+	}
+
 	// If not change, we're done
 	if !changed {
 		return nil, false
@@ -222,6 +251,11 @@ func createPlan(ctx context.Context, log zerolog.Logger, apiObject k8sutil.APIOb
 		plan = pb.Apply(createRotateOrUpgradePlan)
 	}
 
+	// Check for topology spread constraints violation
+	if plan.IsEmpty() {
+		plan = pb.Apply(createTopologyRebalancePlan)
+	}
+
 	// Add keys
 	if plan.IsEmpty() {
 		plan = pb.ApplySubPlan(createEncryptionKeyStatusPropagatedFieldUpdate, createEncryptionKey)
@@ -24,6 +24,7 @@ package reconcile
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/arangodb/kube-arangodb/pkg/deployment/features"
 
@@ -60,6 +61,16 @@ func createRestorePlan(ctx context.Context,
 			return nil
 		}
 
+		if message, wait := restoreTargetRefusal(apiObject, spec, status, backup); wait {
+			log.Debug().Msgf("Restore target of backup %s is not yet checked", backup.GetName())
+			return nil
+		} else if message != "" {
+			// Refusal is kept in the restore status, so it is reported only once
+			return api.Plan{
+				api.NewAction(api.ActionTypeBackupRestoreRefused, api.ServerGroupUnknown, "").AddParam(restoreRefusedMessage, message),
+			}
+		}
+
 		if spec.RocksDB.IsEncrypted() {
 			if ok, p := createRestorePlanEncryption(ctx, log, spec, status, context, backup); !ok {
 				return nil
@@ -81,6 +92,35 @@ func createRestorePlan(ctx context.Context,
 	return nil
 }
 
+// restoreTargetRefusal returns reason why backup is not allowed to be restored in the deployment or empty string.
+// Backup is allowed to be restored if it was created in the deployment or deployment is a validated restore target of the backup.
+// Returns wait if restore target was not yet checked by the backup operator.
+// Result of the check can be outdated, so compatibility is checked again against the current spec and image.
+func restoreTargetRefusal(apiObject k8sutil.APIObject, spec api.DeploymentSpec, status api.DeploymentStatus, backup *backupv1.ArangoBackup) (string, bool) {
+	if backup.Spec.Deployment.Name == apiObject.GetName() {
+		return "", false
+	}
+
+	if t := backup.Spec.RestoreTargetDeployment; t == nil || t.Name != apiObject.GetName() {
+		return fmt.Sprintf("backup %s of deployment %s has no restore target deployment %s", backup.GetName(), backup.Spec.Deployment.Name, apiObject.GetName()), false
+	}
+
+	target := backup.Status.RestoreTarget
+	if target == nil || target.Target != apiObject.GetName() {
+		return "", true
+	}
+
+	if !target.Ready {
+		return fmt.Sprintf("backup %s can not be restored in deployment %s: %s", backup.GetName(), apiObject.GetName(), target.Message), false
+	}
+
+	if message := backup.RestoreIncompatibility(apiObject.GetName(), &spec, status.CurrentImage); message != "" {
+		return fmt.Sprintf("backup %s can not be restored in deployment %s: %s", backup.GetName(), apiObject.GetName(), message), false
+	}
+
+	return "", false
+}
+
 func restorePlan(mode api.DeploymentMode) api.Plan {
 	p := api.Plan{
 		api.NewAction(api.ActionTypeBackupRestore, api.ServerGroupUnknown, ""),
@@ -24,6 +24,7 @@ package reconcile
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/arangodb/go-driver"
 	upgraderules "github.com/arangodb/go-upgrade-rules"
@@ -171,6 +172,68 @@ func createRotateOrUpgradePlanInternal(log zerolog.Logger, apiObject k8sutil.API
 	return nil, false
 }
 
+// upgradePendingCondition checks if upgrade of the members to the new image is pending.
+// Returns reason and message with target version and the reason why upgrade is not proceeding.
+func upgradePendingCondition(log zerolog.Logger, spec api.DeploymentSpec, status api.DeploymentStatus,
+	cachedStatus inspector.Inspector, context PlanBuilderContext) (bool, string, string) {
+	var pending bool
+	var upgradeNotAllowed bool
+	var toVersion driver.Version
+
+	status.Members.ForeachServerGroup(func(group api.ServerGroup, members api.MemberStatusList) error {
+		for _, m := range members {
+			if m.Phase != api.MemberPhaseCreated || m.PodName == "" {
+				continue
+			}
+
+			pod, found := cachedStatus.Pod(m.PodName)
+			if !found {
+				continue
+			}
+
+			decision := podNeedsUpgrading(log, pod, spec, status.Images)
+			if !decision.UpgradeNeeded {
+				continue
+			}
+
+			pending = true
+			toVersion = decision.ToVersion
+
+			if !decision.UpgradeAllowed {
+				upgradeNotAllowed = true
+			}
+		}
+		return nil
+	})
+
+	if !pending {
+		return false, "", ""
+	}
+
+	if upgradeNotAllowed {
+		return true, "Upgrade not allowed", fmt.Sprintf("Upgrade to version %s is not allowed by upgrade rules", toVersion)
+	}
+
+	if !clusterReadyForUpgrade(context) && !util.BoolOrDefault(spec.AllowUnsafeUpgrade, false) {
+		return true, "Cluster not ready", fmt.Sprintf("Upgrade to version %s waits until all shards are in sync and all members are ready", toVersion)
+	}
+
+	return true, "Upgrade in progress", fmt.Sprintf("Upgrade to version %s is in progress", toVersion)
+}
+
+// updateUpgradePendingCondition returns conditions with updated UpgradePending condition and true if it was changed.
+func updateUpgradePendingCondition(log zerolog.Logger, spec api.DeploymentSpec, status api.DeploymentStatus,
+	cachedStatus inspector.Inspector, context PlanBuilderContext) (api.ConditionList, bool) {
+	conditions := status.Conditions.DeepCopy()
+
+	pending, reason, message := upgradePendingCondition(log, spec, status, cachedStatus, context)
+	if pending {
+		return conditions, conditions.Update(api.ConditionTypeUpgradePending, true, reason, message)
+	}
+
+	return conditions, conditions.Remove(api.ConditionTypeUpgradePending)
+}
+
 // podNeedsUpgrading decides if an upgrade of the pod is needed (to comply with
 // the given spec) and if that is allowed.
 func podNeedsUpgrading(log zerolog.Logger, p *core.Pod, spec api.DeploymentSpec, images api.ImageInfoList) upgradeDecision {
@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/arangodb/kube-arangodb/pkg/util/arangod/conn"
 
@@ -46,6 +47,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	driver "github.com/arangodb/go-driver"
 	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
@@ -64,6 +68,12 @@ type testContext struct {
 	PVC              *core.PersistentVolumeClaim
 	PVCErr           error
 	RecordedEvent    *k8sutil.Event
+	Backup           *backupApi.ArangoBackup
+	DryRunForced     bool
+}
+
+func (c *testContext) IsReconcileDryRunForced() bool {
+	return c.DryRunForced
 }
 
 func (c *testContext) GetAuthentication() conn.Auth {
@@ -81,7 +91,11 @@ func (c *testContext) GetName() string {
 }
 
 func (c *testContext) GetBackup(backup string) (*backupApi.ArangoBackup, error) {
-	panic("implement me")
+	if c.Backup == nil {
+		return nil, fmt.Errorf("backup %s not found", backup)
+	}
+
+	return c.Backup, nil
 }
 
 func (c *testContext) SecretsInterface() k8sutil.SecretInterface {
@@ -818,7 +832,7 @@ func TestCreatePlan(t *testing.T) {
 			if testCase.Helper != nil {
 				testCase.Helper(testCase.context.ArangoDeployment)
 			}
-			err, _ := r.CreatePlan(ctx, inspector.NewInspectorFromData(testCase.Pods, testCase.Secrets, testCase.PVCS, testCase.Services, testCase.ServiceAccounts, testCase.PDBS, testCase.ServiceMonitors))
+			err, _ := r.CreatePlan(ctx, inspector.NewInspectorFromData(testCase.Pods, testCase.Secrets, testCase.PVCS, testCase.Services, testCase.ServiceAccounts, testCase.PDBS, testCase.ServiceMonitors, nil))
 
 			// Assert
 			if testCase.ExpectedEvent != nil {
@@ -845,3 +859,409 @@ func TestCreatePlan(t *testing.T) {
 		})
 	}
 }
+
+// TestCreateRestorePlanTargetDeployment tests restore of the backup created in other deployment.
+func TestCreateRestorePlanTargetDeployment(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := zerolog.Nop()
+	spec := api.DeploymentSpec{
+		Mode:        api.NewMode(api.DeploymentModeSingle),
+		RestoreFrom: util.NewString("backup"),
+		Database: &api.DatabaseSpec{
+			Maintenance: util.NewBool(true),
+		},
+	}
+	spec.SetDefaults("staging")
+	depl := &api.ArangoDeployment{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      "staging",
+			Namespace: "test",
+		},
+		Spec: spec,
+	}
+
+	backup := &backupApi.ArangoBackup{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      "backup",
+			Namespace: "test",
+		},
+		Spec: backupApi.ArangoBackupSpec{
+			Deployment: backupApi.ArangoBackupSpecDeployment{
+				Name: "production",
+			},
+		},
+		Status: backupApi.ArangoBackupStatus{
+			Backup: &backupApi.ArangoBackupDetails{
+				ID:      "id",
+				Version: "3.7.3",
+			},
+		},
+	}
+
+	c := &testContext{
+		Backup: backup,
+	}
+
+	status := api.DeploymentStatus{
+		CurrentImage: &api.ImageInfo{
+			ArangoDBVersion: "3.7.5",
+		},
+	}
+
+	refused := func(t *testing.T, message string) {
+		plan := createRestorePlan(ctx, log, depl, spec, status, inspector.NewEmptyInspector(), c)
+		require.Len(t, plan, 1)
+		assert.Equal(t, api.ActionTypeBackupRestoreRefused, plan[0].Type)
+		assert.Contains(t, plan[0].Params[restoreRefusedMessage], message)
+	}
+
+	// Backup without restore target
+	refused(t, "has no restore target deployment staging")
+
+	// Restore target not yet checked
+	backup.Spec.RestoreTargetDeployment = &backupApi.ArangoBackupSpecDeployment{
+		Name: "staging",
+	}
+	assert.Len(t, createRestorePlan(ctx, log, depl, spec, status, inspector.NewEmptyInspector(), c), 0)
+
+	// Backup with not ready restore target
+	backup.Status.RestoreTarget = &backupApi.ArangoBackupRestoreTarget{
+		Source:  "production",
+		Target:  "staging",
+		Message: "not ready",
+	}
+	refused(t, "not ready")
+
+	// Backup with ready restore target of other deployment
+	backup.Status.RestoreTarget = &backupApi.ArangoBackupRestoreTarget{
+		Source: "production",
+		Target: "other",
+		Ready:  true,
+	}
+	assert.Len(t, createRestorePlan(ctx, log, depl, spec, status, inspector.NewEmptyInspector(), c), 0)
+
+	// Refusal already reported
+	status.Restore = &api.DeploymentRestoreResult{
+		RequestedFrom: "backup",
+		State:         api.DeploymentRestoreStateRestoreFailed,
+	}
+	assert.Len(t, createRestorePlan(ctx, log, depl, spec, status, inspector.NewEmptyInspector(), c), 0)
+	status.Restore = nil
+
+	// Backup with ready restore target
+	backup.Status.RestoreTarget.Target = "staging"
+	plan := createRestorePlan(ctx, log, depl, spec, status, inspector.NewEmptyInspector(), c)
+	require.Len(t, plan, 1)
+	assert.Equal(t, api.ActionTypeBackupRestore, plan[0].Type)
+
+	// Target upgraded after restore target was checked
+	status.CurrentImage = &api.ImageInfo{
+		ArangoDBVersion: "3.8.0",
+	}
+	refused(t, "is not compatible with target deployment version 3.8.0")
+
+	// Target left maintenance mode after restore target was checked
+	status.CurrentImage.ArangoDBVersion = "3.7.5"
+	spec.Database.Maintenance = nil
+	refused(t, "is not in maintenance mode")
+}
+
+// TestUpgradePendingCondition tests UpgradePending condition when upgrade is gated.
+func TestUpgradePendingCondition(t *testing.T) {
+	log := zerolog.Nop()
+
+	newDeployment := func(image string, ready bool) *api.ArangoDeployment {
+		spec := api.DeploymentSpec{
+			Mode:  api.NewMode(api.DeploymentModeSingle),
+			Image: util.NewString(image),
+		}
+		spec.SetDefaults("test")
+
+		depl := &api.ArangoDeployment{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "test",
+				Namespace: "test",
+			},
+			Spec: spec,
+		}
+
+		depl.Status.Images = api.ImageInfoList{
+			{Image: "old", ImageID: "old-id", ArangoDBVersion: "3.6.0"},
+			{Image: "patch", ImageID: "patch-id", ArangoDBVersion: "3.6.1"},
+			{Image: "downgrade", ImageID: "downgrade-id", ArangoDBVersion: "3.5.0"},
+		}
+		depl.Status.Members.Single = api.MemberStatusList{
+			{
+				ID:      "single",
+				PodName: "single",
+				Phase:   api.MemberPhaseCreated,
+			},
+		}
+		depl.Status.Conditions.Update(api.ConditionTypeReady, ready, "", "")
+
+		return depl
+	}
+
+	cachedStatus := inspector.NewInspectorFromData(map[string]*core.Pod{
+		"single": {
+			ObjectMeta: meta.ObjectMeta{
+				Name: "single",
+			},
+			Spec: core.PodSpec{
+				Containers: []core.Container{
+					{
+						Name:  k8sutil.ServerContainerName,
+						Image: "old-id",
+					},
+				},
+			},
+		},
+	}, nil, nil, nil, nil, nil, nil, nil)
+
+	testCases := []struct {
+		name    string
+		image   string
+		ready   bool
+		pending bool
+		reason  string
+	}{
+		{
+			name:  "No upgrade",
+			image: "old",
+			ready: true,
+		},
+		{
+			name:    "Upgrade not allowed",
+			image:   "downgrade",
+			ready:   true,
+			pending: true,
+			reason:  "Upgrade not allowed",
+		},
+		{
+			name:    "Cluster not ready",
+			image:   "patch",
+			pending: true,
+			reason:  "Cluster not ready",
+		},
+		{
+			name:    "Upgrade in progress",
+			image:   "patch",
+			ready:   true,
+			pending: true,
+			reason:  "Upgrade in progress",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			depl := newDeployment(testCase.image, testCase.ready)
+			c := &testContext{
+				ArangoDeployment: depl,
+			}
+
+			conditions, changed := updateUpgradePendingCondition(log, depl.Spec, depl.Status, cachedStatus, c)
+			assert.Equal(t, testCase.pending, changed)
+
+			condition, exists := conditions.Get(api.ConditionTypeUpgradePending)
+			require.Equal(t, testCase.pending, exists)
+
+			if testCase.pending {
+				assert.True(t, condition.IsTrue())
+				assert.Equal(t, testCase.reason, condition.Reason)
+				assert.Contains(t, condition.Message, "3.")
+
+				// Condition is removed once upgrade is completed
+				depl.Status.Conditions = conditions
+				depl.Spec.Image = util.NewString("old")
+
+				conditions, changed = updateUpgradePendingCondition(log, depl.Spec, depl.Status, cachedStatus, c)
+				assert.True(t, changed)
+
+				_, exists = conditions.Get(api.ConditionTypeUpgradePending)
+				assert.False(t, exists)
+			}
+		})
+	}
+}
+
+// TestCreateTopologyRebalancePlan tests createTopologyRebalancePlan function.
+func TestCreateTopologyRebalancePlan(t *testing.T) {
+	log := zerolog.Nop()
+
+	newNode := func(name, zone string, ready bool) *core.Node {
+		status := core.ConditionTrue
+		if !ready {
+			status = core.ConditionFalse
+		}
+
+		return &core.Node{
+			ObjectMeta: meta.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					k8sutil.TopologyKeyHostname:   name,
+					"topology.kubernetes.io/zone": zone,
+				},
+			},
+			Status: core.NodeStatus{
+				Conditions: []core.NodeCondition{
+					{
+						Type:   core.NodeReady,
+						Status: status,
+					},
+				},
+			},
+		}
+	}
+
+	newSpec := func() api.DeploymentSpec {
+		spec := api.DeploymentSpec{
+			Mode: api.NewMode(api.DeploymentModeCluster),
+		}
+		spec.SetDefaults("test")
+		spec.Coordinators.TopologySpreadConstraints = []core.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: core.DoNotSchedule,
+			},
+		}
+
+		return spec
+	}
+
+	newStatus := func(nodes *inspector.NodeCache, podNodes ...string) (api.DeploymentStatus, inspector.Inspector) {
+		var status api.DeploymentStatus
+		pods := map[string]*core.Pod{}
+
+		for id, node := range podNodes {
+			member := api.MemberStatus{
+				ID:      fmt.Sprintf("crdn-%d", id),
+				PodName: fmt.Sprintf("crdn-%d", id),
+				Phase:   api.MemberPhaseCreated,
+			}
+			member.Conditions.Update(api.ConditionTypeReady, true, "", "")
+			status.Members.Coordinators = append(status.Members.Coordinators, member)
+
+			pods[member.PodName] = &core.Pod{
+				ObjectMeta: meta.ObjectMeta{
+					Name: member.PodName,
+				},
+				Spec: core.PodSpec{
+					NodeName: node,
+				},
+			}
+		}
+
+		return status, inspector.NewInspectorFromData(pods, nil, nil, nil, nil, nil, nil, nodes)
+	}
+
+	nodes := inspector.NewNodeCacheFromData(
+		newNode("a1", "a", true),
+		newNode("a2", "a", true),
+		newNode("b1", "b", true),
+		newNode("c1", "c", true),
+	)
+
+	t.Run("Balanced", func(t *testing.T) {
+		status, cachedStatus := newStatus(nodes, "a1", "b1", "c1")
+
+		plan := createTopologyRebalancePlan(nil, log, nil, newSpec(), status, cachedStatus, &testContext{})
+		assert.Len(t, plan, 0)
+	})
+
+	t.Run("Zone recovered", func(t *testing.T) {
+		status, cachedStatus := newStatus(nodes, "a1", "a2", "b1")
+
+		plan := createTopologyRebalancePlan(nil, log, nil, newSpec(), status, cachedStatus, &testContext{})
+		require.Len(t, plan, 4)
+		assert.Equal(t, api.ActionTypeTopologyRebalanceUpdate, plan[0].Type)
+		assert.Equal(t, api.ServerGroupCoordinators, plan[0].Group)
+		assert.Equal(t, "2", plan[0].Params[topologySkew])
+		assert.Equal(t, api.ActionTypeRotateMember, plan[1].Type)
+		assert.Equal(t, api.ServerGroupCoordinators, plan[1].Group)
+		assert.Equal(t, "crdn-0", plan[1].MemberID)
+		assert.Equal(t, api.ActionTypeWaitForMemberUp, plan[2].Type)
+	})
+
+	t.Run("Zone down", func(t *testing.T) {
+		status, cachedStatus := newStatus(inspector.NewNodeCacheFromData(
+			newNode("a1", "a", true),
+			newNode("a2", "a", true),
+			newNode("b1", "b", true),
+			newNode("c1", "c", false),
+		), "a1", "a2", "b1")
+
+		plan := createTopologyRebalancePlan(nil, log, nil, newSpec(), status, cachedStatus, &testContext{})
+		assert.Len(t, plan, 0)
+	})
+
+	t.Run("Member not ready", func(t *testing.T) {
+		status, cachedStatus := newStatus(nodes, "a1", "a2", "b1")
+		status.Members.Coordinators[2].Conditions.Update(api.ConditionTypeReady, false, "", "")
+
+		plan := createTopologyRebalancePlan(nil, log, nil, newSpec(), status, cachedStatus, &testContext{})
+		assert.Len(t, plan, 0)
+	})
+
+	t.Run("Nodes unavailable", func(t *testing.T) {
+		k := fake.NewSimpleClientset()
+		k.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("unable to list nodes")
+		})
+
+		status, cachedStatus := newStatus(inspector.NewNodeCache(k, time.Minute), "a1", "a2", "b1")
+
+		plan := createTopologyRebalancePlan(nil, log, nil, newSpec(), status, cachedStatus, &testContext{})
+		assert.Len(t, plan, 0)
+	})
+
+	t.Run("Rebalanced recently", func(t *testing.T) {
+		status, cachedStatus := newStatus(nodes, "a1", "a2", "b1")
+		status.TopologyRebalance = &api.DeploymentTopologyRebalance{
+			Time:  meta.NewTime(time.Now().Add(-time.Minute)),
+			Group: api.ServerGroupCoordinators,
+			Skew:  3,
+		}
+
+		plan := createTopologyRebalancePlan(nil, log, nil, newSpec(), status, cachedStatus, &testContext{})
+		assert.Len(t, plan, 0)
+	})
+
+	t.Run("Skew not reduced", func(t *testing.T) {
+		status, cachedStatus := newStatus(nodes, "a1", "a2", "b1")
+		status.TopologyRebalance = &api.DeploymentTopologyRebalance{
+			Time:  meta.NewTime(time.Now().Add(-2 * topologyRebalanceInterval)),
+			Group: api.ServerGroupCoordinators,
+			Skew:  2,
+		}
+
+		plan := createTopologyRebalancePlan(nil, log, nil, newSpec(), status, cachedStatus, &testContext{})
+		assert.Len(t, plan, 0)
+	})
+
+	t.Run("Skew reduced", func(t *testing.T) {
+		status, cachedStatus := newStatus(nodes, "a1", "a2", "b1")
+		status.TopologyRebalance = &api.DeploymentTopologyRebalance{
+			Time:  meta.NewTime(time.Now().Add(-2 * topologyRebalanceInterval)),
+			Group: api.ServerGroupCoordinators,
+			Skew:  3,
+		}
+
+		plan := createTopologyRebalancePlan(nil, log, nil, newSpec(), status, cachedStatus, &testContext{})
+		require.Len(t, plan, 4)
+	})
+
+	t.Run("Skew not reduced, retry", func(t *testing.T) {
+		status, cachedStatus := newStatus(nodes, "a1", "a2", "b1")
+		status.TopologyRebalance = &api.DeploymentTopologyRebalance{
+			Time:  meta.NewTime(time.Now().Add(-2 * topologyRebalanceRetryInterval)),
+			Group: api.ServerGroupCoordinators,
+			Skew:  2,
+		}
+
+		plan := createTopologyRebalancePlan(nil, log, nil, newSpec(), status, cachedStatus, &testContext{})
+		require.Len(t, plan, 4)
+	})
+}
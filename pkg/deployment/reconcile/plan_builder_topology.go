@@ -0,0 +1,207 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package reconcile
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
+	"github.com/rs/zerolog"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
+)
+
+// topologyRebalanceGroups contains groups which can be moved across topology domains
+// by plain rotation. Members of other groups are bound to the zone of their volumes.
+var topologyRebalanceGroups = []api.ServerGroup{
+	api.ServerGroupCoordinators,
+	api.ServerGroupSyncWorkers,
+}
+
+const (
+	// topologyRebalanceInterval is the minimal time between rotations done to rebalance topology domains
+	topologyRebalanceInterval = 10 * time.Minute
+	// topologyRebalanceRetryInterval is the time after the group is rebalanced again when the last rotation did not reduce the skew
+	topologyRebalanceRetryInterval = time.Hour
+)
+
+// createTopologyRebalancePlan creates plan to rotate a member from the most loaded topology domain
+// when the topology spread constraints of its group are violated, e.g. after a zone recovered.
+// Rotation is recorded in the status. With ScheduleAnyway skew can not always be fixed, so group is not rotated again
+// when the last rotation did not reduce the skew, until retry interval passed.
+func createTopologyRebalancePlan(ctx context.Context,
+	log zerolog.Logger, apiObject k8sutil.APIObject,
+	spec api.DeploymentSpec, status api.DeploymentStatus,
+	cachedStatus inspector.Inspector, context PlanBuilderContext) api.Plan {
+	last := status.TopologyRebalance
+	if last != nil && time.Since(last.Time.Time) < topologyRebalanceInterval {
+		return nil
+	}
+
+	var nodes []*core.Node
+
+	for _, group := range topologyRebalanceGroups {
+		groupSpec := spec.GetServerGroupSpec(group)
+		constraints := groupSpec.GetTopologySpreadConstraints()
+		if len(constraints) == 0 {
+			continue
+		}
+
+		members := status.Members.MembersOfGroup(group)
+		if len(members) == 0 || !topologyMembersReady(members) {
+			// Rebalance only stable groups
+			continue
+		}
+
+		if nodes == nil {
+			nodes = []*core.Node{}
+			if err := cachedStatus.IterateNodes(func(node *core.Node) error {
+				nodes = append(nodes, node)
+				return nil
+			}); err != nil {
+				log.Warn().Err(err).Msg("Unable to list nodes for topology rebalance")
+				return nil
+			}
+		}
+
+		for _, constraint := range constraints {
+			member, skew, ok := topologyMemberToRebalance(constraint, groupSpec.GetNodeSelector(), nodes, members, cachedStatus)
+			if !ok {
+				continue
+			}
+
+			if last != nil && last.Group == group && skew >= last.Skew && time.Since(last.Time.Time) < topologyRebalanceRetryInterval {
+				log.Debug().
+					Str("role", group.AsRole()).
+					Int32("skew", skew).
+					Msg("Last topology rebalance did not reduce the skew")
+				continue
+			}
+
+			plan := api.Plan{
+				api.NewAction(api.ActionTypeTopologyRebalanceUpdate, group, member.ID, "Record topology rebalance").
+					AddParam(topologySkew, strconv.Itoa(int(skew))),
+			}
+
+			return append(plan, createRotateMemberPlan(log, member, group, "Topology spread constraint violated")...)
+		}
+	}
+
+	return nil
+}
+
+func topologyMembersReady(members api.MemberStatusList) bool {
+	for _, m := range members {
+		if m.Phase != api.MemberPhaseCreated || !m.Conditions.IsTrue(api.ConditionTypeReady) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// topologyMemberToRebalance returns member from the most loaded domain and the skew, when the skew between
+// domains exceeds MaxSkew of the constraint.
+func topologyMemberToRebalance(constraint core.TopologySpreadConstraint, nodeSelector map[string]string,
+	nodes []*core.Node, members api.MemberStatusList, cachedStatus inspector.Inspector) (api.MemberStatus, int32, bool) {
+	selector := labels.SelectorFromSet(nodeSelector)
+
+	// Domains of all schedulable nodes, also the empty ones
+	nodeDomains := map[string]string{}
+	domains := map[string]api.MemberStatusList{}
+	for _, node := range nodes {
+		domain, ok := node.GetLabels()[constraint.TopologyKey]
+		if !ok {
+			continue
+		}
+
+		nodeDomains[node.GetName()] = domain
+
+		if node.Spec.Unschedulable || !isNodeReady(node) || !selector.Matches(labels.Set(node.GetLabels())) {
+			continue
+		}
+
+		if _, ok := domains[domain]; !ok {
+			domains[domain] = api.MemberStatusList{}
+		}
+	}
+
+	for _, m := range members {
+		pod, ok := cachedStatus.Pod(m.PodName)
+		if !ok {
+			return api.MemberStatus{}, 0, false
+		}
+
+		domain, ok := nodeDomains[pod.Spec.NodeName]
+		if !ok {
+			// Pod not scheduled or node is out of topology
+			return api.MemberStatus{}, 0, false
+		}
+
+		domains[domain] = append(domains[domain], m)
+	}
+
+	if len(domains) < 2 {
+		return api.MemberStatus{}, 0, false
+	}
+
+	var maxDomain, minDomain string
+	first := true
+	for domain, list := range domains {
+		if first {
+			maxDomain, minDomain = domain, domain
+			first = false
+			continue
+		}
+
+		if c, max := len(list), len(domains[maxDomain]); c > max || (c == max && domain < maxDomain) {
+			maxDomain = domain
+		}
+
+		if c, min := len(list), len(domains[minDomain]); c < min || (c == min && domain < minDomain) {
+			minDomain = domain
+		}
+	}
+
+	skew := int32(len(domains[maxDomain]) - len(domains[minDomain]))
+	if skew <= constraint.MaxSkew {
+		return api.MemberStatus{}, 0, false
+	}
+
+	return domains[maxDomain][0], skew, true
+}
+
+func isNodeReady(node *core.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == core.NodeReady {
+			return c.Status == core.ConditionTrue
+		}
+	}
+
+	return false
+}
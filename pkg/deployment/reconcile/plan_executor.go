@@ -39,8 +39,23 @@ import (
 // ExecutePlan tries to execute the plan as far as possible.
 // Returns true when it has to be called again soon.
 // False otherwise.
-func (d *Reconciler) ExecutePlan(ctx context.Context, cachedStatus inspector.Inspector) (bool, error) {
+func (d *Reconciler) ExecutePlan(ctx context.Context, cachedStatus inspector.Inspector) (retrySoon bool, err error) {
+	status, _ := d.context.GetStatus()
+	planned := len(status.Plan) > 0
+	defer func(start time.Time) {
+		d.metrics.observe(operationExecutePlan, reconcileOutcome(err, planned), start)
+	}(time.Now())
+
 	log := d.log
+
+	// In dry run plan stored in the status is not executed either
+	if d.IsDryRun() {
+		if planned {
+			log.Debug().Int("plan-len", len(status.Plan)).Msg("Dry run, plan not executed")
+		}
+		return false, nil
+	}
+
 	firstLoop := true
 
 	for {
@@ -72,11 +87,14 @@ func (d *Reconciler) ExecutePlan(ctx context.Context, cachedStatus inspector.Ins
 		action := d.createAction(ctx, log, planAction, cachedStatus)
 		if planAction.StartTime.IsZero() {
 			// Not started yet
+			if err := d.runBeforeActionHooks(ctx, log, planAction); err != nil {
+				return false, maskAny(err)
+			}
 			ready, err := action.Start(ctx)
 			if err != nil {
 				log.Debug().Err(err).
 					Msg("Failed to start action")
-				return false, maskAny(err)
+				return false, maskAny(wrapThrottled(err))
 			}
 			{ // action.Start may have changed status, so reload it.
 				status, lastVersion := d.context.GetStatus()
@@ -88,6 +106,9 @@ func (d *Reconciler) ExecutePlan(ctx context.Context, cachedStatus inspector.Ins
 						// Fill in MemberID from previous action
 						status.Plan[0].MemberID = action.MemberID()
 					}
+					if err := d.runAfterActionHooks(ctx, log, planAction); err != nil {
+						d.abortPlanAfterHook(log, &status, planAction)
+					}
 				} else {
 					// Mark start time
 					now := metav1.Now()
@@ -107,7 +128,7 @@ func (d *Reconciler) ExecutePlan(ctx context.Context, cachedStatus inspector.Ins
 			ready, abort, err := action.CheckProgress(ctx)
 			if err != nil {
 				log.Debug().Err(err).Msg("Failed to check action progress")
-				return false, maskAny(err)
+				return false, maskAny(wrapThrottled(err))
 			}
 			if ready {
 				{ // action.CheckProgress may have changed status, so reload it.
@@ -118,6 +139,9 @@ func (d *Reconciler) ExecutePlan(ctx context.Context, cachedStatus inspector.Ins
 						// Fill in MemberID from previous action
 						status.Plan[0].MemberID = action.MemberID()
 					}
+					if err := d.runAfterActionHooks(ctx, log, planAction); err != nil {
+						d.abortPlanAfterHook(log, &status, planAction)
+					}
 					// Save plan update
 					if err := d.context.UpdateStatus(status, lastVersion); err != nil {
 						log.Debug().Err(err).Msg("Failed to update CR status")
@@ -162,6 +186,13 @@ func (d *Reconciler) ExecutePlan(ctx context.Context, cachedStatus inspector.Ins
 	}
 }
 
+// abortPlanAfterHook removes the remaining plan after a failing hook with abort policy.
+func (d *Reconciler) abortPlanAfterHook(log zerolog.Logger, status *api.DeploymentStatus, planAction api.Action) {
+	log.Warn().Msg("Reconcile hook failed. Removing the entire plan")
+	d.context.CreateEvent(k8sutil.NewPlanAbortedEvent(d.context.GetAPIObject(), string(planAction.Type), planAction.MemberID, planAction.Group.AsRole()))
+	status.Plan = api.Plan{}
+}
+
 // createAction create action object based on action type
 func (d *Reconciler) createAction(ctx context.Context, log zerolog.Logger, action api.Action, cachedStatus inspector.Inspector) Action {
 	actionCtx := newActionContext(log.With().Str("id", action.ID).Str("type", action.Type.String()).Logger(), d.context, cachedStatus)
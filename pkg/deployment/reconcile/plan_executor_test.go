@@ -0,0 +1,98 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/deployment/resources/inspector"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	actionTypeTestThrottled api.ActionType = "TestThrottled"
+)
+
+func init() {
+	registerAction(actionTypeTestThrottled, func(log zerolog.Logger, action api.Action, actionCtx ActionContext) Action {
+		return &testThrottledAction{
+			actionImpl: newActionImplDefRef(log, action, actionCtx, time.Minute),
+		}
+	})
+}
+
+type testThrottledAction struct {
+	actionImpl
+
+	actionEmptyCheckProgress
+}
+
+func (t *testThrottledAction) Start(ctx context.Context) (bool, error) {
+	return false, apierrors.NewTooManyRequests("throttled", 7)
+}
+
+func TestExecutePlanThrottled(t *testing.T) {
+	// Arrange
+	c := &testContext{
+		ArangoDeployment: &api.ArangoDeployment{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      "test_depl",
+				Namespace: "test",
+			},
+		},
+	}
+	c.ArangoDeployment.Status.Plan = api.Plan{
+		api.NewAction(actionTypeTestThrottled, api.ServerGroupDBServers, ""),
+	}
+
+	r := NewReconciler(zerolog.Nop(), c)
+
+	// Act
+	retrySoon, err := r.ExecutePlan(context.Background(), inspector.NewEmptyInspector())
+
+	// Assert
+	require.False(t, retrySoon)
+	require.Error(t, err)
+
+	delay, ok := IsThrottled(err)
+	require.True(t, ok)
+	require.Equal(t, 7*time.Second, delay)
+}
+
+func TestIsThrottled(t *testing.T) {
+	_, ok := IsThrottled(maskAny(apierrors.NewBadRequest("bad request")))
+	require.False(t, ok)
+
+	_, ok = IsThrottled(wrapThrottled(apierrors.NewBadRequest("bad request")))
+	require.False(t, ok)
+
+	delay, ok := IsThrottled(maskAny(wrapThrottled(apierrors.NewTooManyRequests("throttled", 3))))
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, delay)
+}
@@ -32,16 +32,31 @@ import (
 type Reconciler struct {
 	log     zerolog.Logger
 	context Context
+	metrics reconcileMetrics
+	hooks   []ReconcileHook
+
+	// dryRunPlan is the last plan reported in dry run mode
+	dryRunPlan api.Plan
 }
 
 // NewReconciler creates a new reconciler with given context.
-func NewReconciler(log zerolog.Logger, context Context) *Reconciler {
+// Optional hooks are invoked around the execution of every plan action.
+func NewReconciler(log zerolog.Logger, context Context, hooks ...ReconcileHook) *Reconciler {
+	apiObject := context.GetAPIObject()
+
 	return &Reconciler{
 		log:     log,
 		context: context,
+		metrics: newReconcileMetrics(apiObject.GetName(), apiObject.GetNamespace()),
+		hooks:   hooks,
 	}
 }
 
+// DeleteMetrics removes reconcile metrics of the deployment.
+func (r *Reconciler) DeleteMetrics() {
+	r.metrics.delete()
+}
+
 // CheckDeployment checks for obviously broken things and fixes them immediately
 func (r *Reconciler) CheckDeployment() error {
 	spec := r.context.GetSpec()
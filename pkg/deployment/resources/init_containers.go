@@ -0,0 +1,40 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package resources
+
+import (
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	core "k8s.io/api/core/v1"
+)
+
+// applyReservedInitContainersEnv adds environment variables from the server group spec to the init containers managed by operator
+func applyReservedInitContainersEnv(groupSpec api.ServerGroupSpec, containers []core.Container) {
+	for id := range containers {
+		for _, env := range groupSpec.InitContainers.GetReservedEnv(containers[id].Name) {
+			containers[id].Env = append(containers[id].Env, core.EnvVar{
+				Name:  env.Name,
+				Value: env.Value,
+			})
+		}
+	}
+}
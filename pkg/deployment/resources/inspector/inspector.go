@@ -33,7 +33,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-func NewInspector(k kubernetes.Interface, m monitoringClient.MonitoringV1Interface, namespace string) (Inspector, error) {
+func NewInspector(k kubernetes.Interface, m monitoringClient.MonitoringV1Interface, namespace string, nodes *NodeCache) (Inspector, error) {
 	pods, err := podsToMap(k, namespace)
 	if err != nil {
 		return nil, err
@@ -69,11 +69,11 @@ func NewInspector(k kubernetes.Interface, m monitoringClient.MonitoringV1Interfa
 		return nil, err
 	}
 
-	return NewInspectorFromData(pods, secrets, pvcs, services, serviceAccounts, podDisruptionBudgets, serviceMonitors), nil
+	return NewInspectorFromData(pods, secrets, pvcs, services, serviceAccounts, podDisruptionBudgets, serviceMonitors, nodes), nil
 }
 
 func NewEmptyInspector() Inspector {
-	return NewInspectorFromData(nil, nil, nil, nil, nil, nil, nil)
+	return NewInspectorFromData(nil, nil, nil, nil, nil, nil, nil, nil)
 }
 
 func NewInspectorFromData(pods map[string]*core.Pod,
@@ -82,7 +82,8 @@ func NewInspectorFromData(pods map[string]*core.Pod,
 	services map[string]*core.Service,
 	serviceAccounts map[string]*core.ServiceAccount,
 	podDisruptionBudgets map[string]*policy.PodDisruptionBudget,
-	serviceMonitors map[string]*monitoring.ServiceMonitor) Inspector {
+	serviceMonitors map[string]*monitoring.ServiceMonitor,
+	nodes *NodeCache) Inspector {
 	return &inspector{
 		pods:                 pods,
 		secrets:              secrets,
@@ -91,6 +92,7 @@ func NewInspectorFromData(pods map[string]*core.Pod,
 		serviceAccounts:      serviceAccounts,
 		podDisruptionBudgets: podDisruptionBudgets,
 		serviceMonitors:      serviceMonitors,
+		nodes:                nodes,
 	}
 }
 
@@ -117,6 +119,8 @@ type Inspector interface {
 
 	ServiceMonitor(name string) (*monitoring.ServiceMonitor, bool)
 	IterateServiceMonitors(action ServiceMonitorAction, filters ...ServiceMonitorFilter) error
+
+	IterateNodes(action NodeAction, filters ...NodeFilter) error
 }
 
 type inspector struct {
@@ -129,6 +133,7 @@ type inspector struct {
 	serviceAccounts      map[string]*core.ServiceAccount
 	podDisruptionBudgets map[string]*policy.PodDisruptionBudget
 	serviceMonitors      map[string]*monitoring.ServiceMonitor
+	nodes                *NodeCache
 
 	ns string
 	k  kubernetes.Interface
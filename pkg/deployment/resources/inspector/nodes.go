@@ -0,0 +1,163 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package inspector
+
+import (
+	"sync"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type NodeFilter func(node *core.Node) bool
+type NodeAction func(node *core.Node) error
+
+// NodeCache keeps cluster scoped nodes between inspections. Nodes are listed on first use
+// and then again only when cache is older than refresh interval.
+type NodeCache struct {
+	lock sync.Mutex
+
+	k       kubernetes.Interface
+	refresh time.Duration
+
+	nodes  map[string]*core.Node
+	loaded time.Time
+}
+
+// NewNodeCache creates node cache which lists nodes using given client
+func NewNodeCache(k kubernetes.Interface, refresh time.Duration) *NodeCache {
+	return &NodeCache{
+		k:       k,
+		refresh: refresh,
+	}
+}
+
+// NewNodeCacheFromData creates node cache with fixed list of nodes
+func NewNodeCacheFromData(nodes ...*core.Node) *NodeCache {
+	nodeMap := map[string]*core.Node{}
+
+	for _, node := range nodes {
+		nodeMap[node.GetName()] = node
+	}
+
+	return &NodeCache{
+		nodes: nodeMap,
+	}
+}
+
+func (n *NodeCache) IterateNodes(action NodeAction, filters ...NodeFilter) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if err := n.load(); err != nil {
+		return err
+	}
+
+	for _, node := range n.nodes {
+		if err := iterateNode(node, action, filters...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *NodeCache) load() error {
+	if n.k == nil {
+		return nil
+	}
+
+	if n.nodes != nil && time.Since(n.loaded) < n.refresh {
+		return nil
+	}
+
+	nodes, err := nodesToMap(n.k)
+	if err != nil {
+		return err
+	}
+
+	n.nodes = nodes
+	n.loaded = time.Now()
+
+	return nil
+}
+
+func (i *inspector) IterateNodes(action NodeAction, filters ...NodeFilter) error {
+	if i.nodes == nil {
+		return nil
+	}
+
+	return i.nodes.IterateNodes(action, filters...)
+}
+
+func iterateNode(node *core.Node, action NodeAction, filters ...NodeFilter) error {
+	for _, filter := range filters {
+		if !filter(node) {
+			return nil
+		}
+	}
+
+	return action(node)
+}
+
+func nodesToMap(k kubernetes.Interface) (map[string]*core.Node, error) {
+	nodes, err := getNodes(k, "")
+	if err != nil {
+		return nil, err
+	}
+
+	nodeMap := map[string]*core.Node{}
+
+	for _, node := range nodes {
+		nodeMap[node.GetName()] = nodePointer(node)
+	}
+
+	return nodeMap, nil
+}
+
+func nodePointer(node core.Node) *core.Node {
+	return &node
+}
+
+func getNodes(k kubernetes.Interface, cont string) ([]core.Node, error) {
+	nodes, err := k.CoreV1().Nodes().List(meta.ListOptions{
+		Limit:    128,
+		Continue: cont,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if nodes.Continue != "" {
+		nextNodesLayer, err := getNodes(k, nodes.Continue)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(nodes.Items, nextNodesLayer...), nil
+	}
+
+	return nodes.Items, nil
+}
@@ -415,11 +415,13 @@ func (m *MemberArangoDPod) GetInitContainers() ([]core.Container, error) {
 		engine := m.spec.GetStorageEngine().AsArangoArgument()
 		requireUUID := m.group == api.ServerGroupDBServers && m.status.IsInitialized
 
-		c := k8sutil.ArangodInitContainer("uuid", m.status.ID, engine, executable, operatorUUIDImage, requireUUID,
+		c := k8sutil.ArangodInitContainer(k8sutil.InitDataContainerName, m.status.ID, engine, executable, operatorUUIDImage, requireUUID,
 			m.groupSpec.SecurityContext.NewSecurityContext())
 		initContainers = append(initContainers, c)
 	}
 
+	applyReservedInitContainersEnv(m.groupSpec, initContainers)
+
 	return initContainers, nil
 }
 
@@ -475,6 +477,7 @@ func (m *MemberArangoDPod) createMetricsExporterSidecar() *core.Container {
 
 func (m *MemberArangoDPod) ApplyPodSpec(p *core.PodSpec) error {
 	p.SecurityContext = m.groupSpec.SecurityContext.NewPodSecurityContext()
+	p.TopologySpreadConstraints = pod.TopologySpreadConstraints(m, m.groupSpec.GetTopologySpreadConstraints())
 
 	return nil
 }
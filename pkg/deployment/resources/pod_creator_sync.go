@@ -277,6 +277,8 @@ func (m *MemberSyncPod) GetInitContainers() ([]core.Container, error) {
 		initContainers = append(initContainers, c)
 	}
 
+	applyReservedInitContainersEnv(m.groupSpec, initContainers)
+
 	return initContainers, nil
 }
 
@@ -309,6 +311,8 @@ func (m *MemberSyncPod) Validate(cachedStatus inspector.Inspector) error {
 }
 
 func (m *MemberSyncPod) ApplyPodSpec(spec *core.PodSpec) error {
+	spec.TopologySpreadConstraints = pod.TopologySpreadConstraints(m, m.groupSpec.GetTopologySpreadConstraints())
+
 	return nil
 }
 
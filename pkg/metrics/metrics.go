@@ -33,6 +33,8 @@ const (
 
 	// DeploymentName is a label key used for the name of a deployment
 	DeploymentName = "deployment"
+	// Namespace is a label key used for the namespace of a deployment
+	Namespace = "namespace"
 	// Result is a label key used for the result of an action (Success|Failed)
 	Result = "result"
 	// Success is a label value used for successful actions
@@ -93,6 +95,24 @@ func MustRegisterGaugeVec(component, name, help string, labelNames ...string) *p
 	return m
 }
 
+// MustRegisterHistogramVec creates and registers a histogram vector.
+// If buckets are not specified, default buckets are used.
+// Must be called from `init`.
+func MustRegisterHistogramVec(component, name, help string, buckets []float64, labelNames ...string) *prometheus.HistogramVec {
+	if buckets == nil {
+		buckets = prometheus.DefBuckets
+	}
+	m := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: component,
+		Name:      name,
+		Help:      help,
+		Buckets:   buckets,
+	}, labelNames)
+	prometheus.MustRegister(m)
+	return m
+}
+
 // MustRegisterSummary creates and registers a summary.
 // Must be called from `init`.
 func MustRegisterSummary(component, name, help string, objectives map[float64]float64) prometheus.Summary {
@@ -33,64 +33,65 @@ import (
 
 // waitForCRD waits for the CustomResourceDefinition (created externally)
 // to be ready.
+// Every CRD is awaited at most for CRDReadyTimeout.
 func (o *Operator) waitForCRD(enableDeployment, enableDeploymentReplication, enableStorage, enableBackup bool) error {
 	log := o.log
 
 	if o.Scope.IsNamespaced() {
 		if enableDeployment {
 			log.Debug().Msg("Waiting for ArangoDeployment CRD to be ready")
-			if err := crd.WaitReady(func() error {
+			if err := crd.WaitReadyWithTimeout(func() error {
 				_, err := o.CRCli.DatabaseV1().ArangoDeployments(o.Namespace).List(meta.ListOptions{})
 				return err
-			}); err != nil {
+			}, o.CRDReadyTimeout); err != nil {
 				return maskAny(err)
 			}
 		}
 
 		if enableDeploymentReplication {
 			log.Debug().Msg("Waiting for ArangoDeploymentReplication CRD to be ready")
-			if err := crd.WaitReady(func() error {
+			if err := crd.WaitReadyWithTimeout(func() error {
 				_, err := o.CRCli.ReplicationV1().ArangoDeploymentReplications(o.Namespace).List(meta.ListOptions{})
 				return err
-			}); err != nil {
+			}, o.CRDReadyTimeout); err != nil {
 				return maskAny(err)
 			}
 		}
 
 		if enableBackup {
 			log.Debug().Msg("Wait for ArangoBackup CRD to be ready")
-			if err := crd.WaitReady(func() error {
+			if err := crd.WaitReadyWithTimeout(func() error {
 				_, err := o.CRCli.BackupV1().ArangoBackups(o.Namespace).List(meta.ListOptions{})
 				return err
-			}); err != nil {
+			}, o.CRDReadyTimeout); err != nil {
 				return maskAny(err)
 			}
 		}
 	} else {
 		if enableDeployment {
 			log.Debug().Msg("Waiting for ArangoDeployment CRD to be ready")
-			if err := crd.WaitCRDReady(o.KubeExtCli, deployment.ArangoDeploymentCRDName); err != nil {
+			if err := crd.WaitCRDReadyWithTimeout(o.KubeExtCli, deployment.ArangoDeploymentCRDName, o.CRDReadyTimeout); err != nil {
 				return maskAny(err)
 			}
 		}
 
 		if enableDeploymentReplication {
 			log.Debug().Msg("Waiting for ArangoDeploymentReplication CRD to be ready")
-			if err := crd.WaitCRDReady(o.KubeExtCli, replication.ArangoDeploymentReplicationCRDName); err != nil {
+			if err := crd.WaitCRDReadyWithTimeout(o.KubeExtCli, replication.ArangoDeploymentReplicationCRDName, o.CRDReadyTimeout); err != nil {
 				return maskAny(err)
 			}
 		}
 
 		if enableStorage {
 			log.Debug().Msg("Waiting for ArangoLocalStorage CRD to be ready")
-			if err := crd.WaitCRDReady(o.KubeExtCli, lsapi.ArangoLocalStorageCRDName); err != nil {
+			if err := crd.WaitCRDReadyWithTimeout(o.KubeExtCli, lsapi.ArangoLocalStorageCRDName, o.CRDReadyTimeout); err != nil {
 				return maskAny(err)
 			}
 		}
 
 		if enableBackup {
 			log.Debug().Msg("Wait for ArangoBackup CRD to be ready")
-			if err := crd.WaitCRDReady(o.KubeExtCli, backup.ArangoBackupCRDName); err != nil {
+			if err := crd.WaitCRDReadyWithTimeout(o.KubeExtCli, backup.ArangoBackupCRDName, o.CRDReadyTimeout); err != nil {
 				return maskAny(err)
 			}
 		}
@@ -39,6 +39,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kwatch "k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
@@ -80,6 +81,7 @@ type Operator struct {
 	deployments            map[string]*deployment.Deployment
 	deploymentReplications map[string]*replication.DeploymentReplication
 	localStorages          map[string]*storage.LocalStorage
+	backupAdmin            backup.Admin
 }
 
 type Config struct {
@@ -96,8 +98,11 @@ type Config struct {
 	EnableStorage               bool
 	EnableBackup                bool
 	AllowChaos                  bool
+	ReconcileDryRun             bool
 	SingleMode                  bool
 	Scope                       scope.Scope
+	CRDReadyTimeout             time.Duration
+	BackupConfig                backup.Config
 }
 
 type Dependencies struct {
@@ -238,12 +243,27 @@ func (o *Operator) onStartBackup(stop <-chan struct{}) {
 
 	eventRecorder := event.NewEventRecorder(operatorName, kubeClientSet)
 
-	arangoInformer := arangoInformer.NewSharedInformerFactoryWithOptions(arangoClientSet, 10*time.Second, arangoInformer.WithNamespace(o.Namespace))
+	// Backups in all namespaces are watched only when enabled, cluster wide RBAC permissions are required in such case
+	informerNamespace := o.Namespace
+	if o.Config.BackupConfig.AllNamespaces {
+		informerNamespace = meta.NamespaceAll
+	}
+
+	arangoInformer := arangoInformer.NewSharedInformerFactoryWithOptions(arangoClientSet, 10*time.Second, arangoInformer.WithNamespace(informerNamespace))
+
+	// Backups are handled only till leadership is lost
+	backupConfig := o.Config.BackupConfig
+	backupConfig.Leadership = stopLeadershipGate{stop: stop}
 
-	if err = backup.RegisterInformer(operator, eventRecorder, arangoClientSet, kubeClientSet, arangoInformer); err != nil {
+	backupAdmin, err := backup.RegisterInformer(operator, eventRecorder, arangoClientSet, kubeClientSet, arangoInformer, backupConfig)
+	if err != nil {
 		panic(err)
 	}
 
+	o.Dependencies.LivenessProbe.Lock()
+	o.backupAdmin = backupAdmin
+	o.Dependencies.LivenessProbe.Unlock()
+
 	if err = policy.RegisterInformer(operator, eventRecorder, arangoClientSet, kubeClientSet, arangoInformer); err != nil {
 		panic(err)
 	}
@@ -210,6 +210,7 @@ func (o *Operator) makeDeploymentConfigAndDeps(apiObject *api.ArangoDeployment)
 		MetricsExporterImage:  o.MetricsExporterImage,
 		ArangoImage:           o.ArangoImage,
 		AllowChaos:            o.Config.AllowChaos,
+		ReconcileDryRun:       o.Config.ReconcileDryRun,
 		Scope:                 o.Scope,
 	}
 	deps := deployment.Dependencies{
@@ -186,3 +186,17 @@ func (o *Operator) setRoleLabel(log zerolog.Logger, label, role string) error {
 	}
 	return nil
 }
+
+// stopLeadershipGate reports leadership until the stop channel given to onStart function is closed
+type stopLeadershipGate struct {
+	stop <-chan struct{}
+}
+
+func (s stopLeadershipGate) IsLeader() bool {
+	select {
+	case <-s.stop:
+		return false
+	default:
+		return true
+	}
+}
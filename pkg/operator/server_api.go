@@ -26,6 +26,7 @@ import (
 	"sort"
 
 	"github.com/arangodb/kube-arangodb/pkg/server"
+	"github.com/arangodb/kube-arangodb/pkg/util/k8sutil"
 )
 
 // DeploymentOperator provides access to the deployment operator.
@@ -126,3 +127,67 @@ func (o *Operator) GetLocalStorage(name string) (server.LocalStorage, error) {
 	}
 	return nil, maskAny(server.NotFoundError)
 }
+
+// BackupOperator provides the backup operator (if any)
+func (o *Operator) BackupOperator() server.BackupOperator {
+	return o
+}
+
+// GetOrphanedBackups returns server side backups of the deployment which have no ArangoBackup resource
+func (o *Operator) GetOrphanedBackups(namespace, deployment string) ([]server.OrphanedBackup, error) {
+	o.Dependencies.LivenessProbe.Lock()
+	admin := o.backupAdmin
+	o.Dependencies.LivenessProbe.Unlock()
+
+	if admin == nil {
+		return nil, maskAny(server.NotFoundError)
+	}
+
+	backups, err := admin.OrphanedBackups(namespace, deployment)
+	if err != nil {
+		if k8sutil.IsNotFound(err) {
+			return nil, maskAny(server.NotFoundError)
+		}
+		return nil, maskAny(err)
+	}
+
+	result := make([]server.OrphanedBackup, 0, len(backups))
+	for _, b := range backups {
+		result = append(result, b)
+	}
+	return result, nil
+}
+
+// ResetDeploymentLock releases the backup lock of the deployment held by a stuck operation
+func (o *Operator) ResetDeploymentLock(namespace, deployment string) error {
+	o.Dependencies.LivenessProbe.Lock()
+	admin := o.backupAdmin
+	o.Dependencies.LivenessProbe.Unlock()
+
+	if admin == nil || !admin.ResetDeploymentLock(namespace, deployment) {
+		return maskAny(server.NotFoundError)
+	}
+
+	return nil
+}
+
+// RefreshBackups imports and prunes backups of all handled deployments immediately
+func (o *Operator) RefreshBackups() (server.RefreshInfo, error) {
+	o.Dependencies.LivenessProbe.Lock()
+	admin := o.backupAdmin
+	o.Dependencies.LivenessProbe.Unlock()
+
+	if admin == nil {
+		return server.RefreshInfo{}, maskAny(server.NotFoundError)
+	}
+
+	result, err := admin.Refresh()
+	if err != nil {
+		return server.RefreshInfo{}, maskAny(err)
+	}
+
+	return server.RefreshInfo{
+		Imported: result.Imported,
+		Pruned:   result.Pruned,
+	}, nil
+}
@@ -0,0 +1,113 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrphanedBackup is the API implemented by a server side backup without ArangoBackup resource.
+type OrphanedBackup interface {
+	ID() string
+	Version() string
+	CreationTimestamp() time.Time
+}
+
+// BackupOperator is the API implemented by the backup operator.
+type BackupOperator interface {
+	// GetOrphanedBackups returns server side backups of the deployment which have no ArangoBackup resource
+	GetOrphanedBackups(namespace, deployment string) ([]OrphanedBackup, error)
+	// ResetDeploymentLock releases the backup lock of the deployment held by a stuck operation
+	ResetDeploymentLock(namespace, deployment string) error
+	// RefreshBackups imports and prunes backups of all handled deployments immediately
+	RefreshBackups() (RefreshInfo, error)
+}
+
+// RefreshInfo is the information returned by the triggered refresh.
+type RefreshInfo struct {
+	Imported int `json:"imported"`
+	Pruned   int `json:"pruned"`
+}
+
+// OrphanedBackupInfo is the information returned per orphaned backup.
+type OrphanedBackupInfo struct {
+	ID                string    `json:"id"`
+	Version           string    `json:"version"`
+	CreationTimestamp time.Time `json:"created_at"`
+}
+
+// newOrphanedBackupInfo initializes an OrphanedBackupInfo for the given OrphanedBackup.
+func newOrphanedBackupInfo(b OrphanedBackup) OrphanedBackupInfo {
+	return OrphanedBackupInfo{
+		ID:                b.ID(),
+		Version:           b.Version(),
+		CreationTimestamp: b.CreationTimestamp(),
+	}
+}
+
+// Handle a GET /api/backup/deployment/:namespace/:deployment/orphaned request
+func (s *Server) handleGetOrphanedBackups(c *gin.Context) {
+	if o := s.deps.Operators.BackupOperator(); o != nil {
+		// Fetch orphaned backups
+		backups, err := o.GetOrphanedBackups(c.Params.ByName("namespace"), c.Params.ByName("deployment"))
+		if err != nil {
+			sendError(c, err)
+		} else {
+			result := make([]OrphanedBackupInfo, len(backups))
+			for i, b := range backups {
+				result[i] = newOrphanedBackupInfo(b)
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"backups": result,
+			})
+		}
+	}
+}
+
+// Handle a POST /api/backup/deployment/:namespace/:deployment/unlock request
+func (s *Server) handleResetDeploymentLock(c *gin.Context) {
+	if o := s.deps.Operators.BackupOperator(); o != nil {
+		// Reset lock
+		if err := o.ResetDeploymentLock(c.Params.ByName("namespace"), c.Params.ByName("deployment")); err != nil {
+			sendError(c, err)
+		} else {
+			c.JSON(http.StatusOK, gin.H{})
+		}
+	}
+}
+
+// Handle a POST /api/backup/refresh request
+func (s *Server) handleRefreshBackups(c *gin.Context) {
+	if o := s.deps.Operators.BackupOperator(); o != nil {
+		// Run refresh
+		result, err := o.RefreshBackups()
+		if err != nil {
+			sendError(c, err)
+		} else {
+			c.JSON(http.StatusOK, result)
+		}
+	}
+}
@@ -79,6 +79,8 @@ type Operators interface {
 	DeploymentReplicationOperator() DeploymentReplicationOperator
 	// Return the local storage operator (if any)
 	StorageOperator() StorageOperator
+	// Return the backup operator (if any)
+	BackupOperator() BackupOperator
 	// FindOtherOperators looks up references to other operators in the same Kubernetes cluster.
 	FindOtherOperators() []OperatorReference
 }
@@ -149,7 +151,15 @@ func NewServer(cli corev1.CoreV1Interface, cfg Config, deps Dependencies) (*Serv
 		auth:       newServerAuthentication(deps.Log, deps.Secrets, cfg.AdminSecretName, cfg.AllowAnonymous),
 	}
 
-	// Build router
+	httpServer.Handler = s.createRouter()
+
+	return s, nil
+}
+
+// createRouter builds the router with all handlers of the server.
+func (s *Server) createRouter() *gin.Engine {
+	deps := s.deps
+
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
 	r.Use(gin.Recovery())
@@ -186,6 +196,11 @@ func NewServer(cli corev1.CoreV1Interface, cfg Config, deps Dependencies) (*Serv
 		// Local storage operator
 		api.GET("/storage", s.handleGetLocalStorages)
 		api.GET("/storage/:name", s.handleGetLocalStorageDetails)
+
+		// Backup operator
+		api.POST("/backup/refresh", s.handleRefreshBackups)
+		api.GET("/backup/deployment/:namespace/:deployment/orphaned", s.handleGetOrphanedBackups)
+		api.POST("/backup/deployment/:namespace/:deployment/unlock", s.handleResetDeploymentLock)
 	}
 	// Dashboard
 	r.GET("/", createAssetFileHandler(dashboard.Assets.Files["index.html"]))
@@ -193,9 +208,8 @@ func NewServer(cli corev1.CoreV1Interface, cfg Config, deps Dependencies) (*Serv
 		localPath := "/" + strings.TrimPrefix(path, "/")
 		r.GET(localPath, createAssetFileHandler(file))
 	}
-	httpServer.Handler = r
 
-	return s, nil
+	return r
 }
 
 // createAssetFileHandler creates a gin handler to serve the content
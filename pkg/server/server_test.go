@@ -0,0 +1,158 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arangodb/kube-arangodb/pkg/util/probe"
+)
+
+type testBackupOperator struct {
+	namespace, deployment string
+	refreshed             int
+}
+
+func (t *testBackupOperator) GetOrphanedBackups(namespace, deployment string) ([]OrphanedBackup, error) {
+	t.namespace, t.deployment = namespace, deployment
+	return nil, nil
+}
+
+func (t *testBackupOperator) ResetDeploymentLock(namespace, deployment string) error {
+	t.namespace, t.deployment = namespace, deployment
+	return nil
+}
+
+func (t *testBackupOperator) RefreshBackups() (RefreshInfo, error) {
+	t.refreshed++
+	return RefreshInfo{}, nil
+}
+
+type testOperators struct {
+	backup *testBackupOperator
+}
+
+func (t testOperators) DeploymentOperator() DeploymentOperator {
+	return nil
+}
+
+func (t testOperators) DeploymentReplicationOperator() DeploymentReplicationOperator {
+	return nil
+}
+
+func (t testOperators) StorageOperator() StorageOperator {
+	return nil
+}
+
+func (t testOperators) BackupOperator() BackupOperator {
+	return t.backup
+}
+
+func (t testOperators) FindOtherOperators() []OperatorReference {
+	return nil
+}
+
+func newTestDependencies(backup *testBackupOperator) Dependencies {
+	return Dependencies{
+		Log:           zerolog.Nop(),
+		LivenessProbe: &probe.LivenessProbe{},
+		Backup: OperatorDependency{
+			Enabled: true,
+			Probe:   &probe.ReadyProbe{},
+		},
+		Operators: testOperators{backup: backup},
+	}
+}
+
+func newTestServer(backup *testBackupOperator) *Server {
+	deps := newTestDependencies(backup)
+
+	return &Server{
+		deps: deps,
+		auth: newServerAuthentication(deps.Log, nil, "", true),
+	}
+}
+
+func serve(t *testing.T, r *gin.Engine, method, path string) {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	require.Equal(t, http.StatusOK, w.Code, path)
+}
+
+func Test_Router_BackupDeploymentRoutes(t *testing.T) {
+	// Arrange
+	backup := &testBackupOperator{}
+	var r *gin.Engine
+
+	// Act
+	require.NotPanics(t, func() {
+		r = newTestServer(backup).createRouter()
+	})
+
+	// Assert
+	serve(t, r, http.MethodGet, "/api/backup/deployment/ns/orphaned-depl/orphaned")
+	require.Equal(t, "ns", backup.namespace)
+	require.Equal(t, "orphaned-depl", backup.deployment)
+
+	serve(t, r, http.MethodPost, "/api/backup/deployment/ns/unlock-depl/unlock")
+	require.Equal(t, "ns", backup.namespace)
+	require.Equal(t, "unlock-depl", backup.deployment)
+
+	serve(t, r, http.MethodPost, "/api/backup/refresh")
+	require.Equal(t, 1, backup.refreshed)
+}
+
+func Test_NewServer_Router(t *testing.T) {
+	// Arrange
+	backup := &testBackupOperator{}
+	deps := newTestDependencies(backup)
+	deps.Deployment = OperatorDependency{Enabled: true, Probe: &probe.ReadyProbe{}}
+	deps.DeploymentReplication = OperatorDependency{Enabled: true, Probe: &probe.ReadyProbe{}}
+	deps.Storage = OperatorDependency{Enabled: true, Probe: &probe.ReadyProbe{}}
+
+	var s *Server
+	var err error
+
+	// Act
+	require.NotPanics(t, func() {
+		s, err = NewServer(nil, Config{
+			PodName:        "operator",
+			PodIP:          "127.0.0.1",
+			AllowAnonymous: true,
+		}, deps)
+	})
+
+	// Assert
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	s.httpServer.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/backup/refresh", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 1, backup.refreshed)
+}
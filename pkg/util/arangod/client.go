@@ -24,11 +24,13 @@ package arangod
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"fmt"
 	"net"
 	nhttp "net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	driver "github.com/arangodb/go-driver"
@@ -46,6 +48,10 @@ type (
 	skipAuthenticationKey struct{}
 	// requireAuthenticationKey is the context key used to indicate that authentication is required
 	requireAuthenticationKey struct{}
+	// authenticationKey is the context key used to override authentication of the deployment
+	authenticationKey struct{}
+	// clientCertificateKey is the context key used to present client certificate to the servers
+	clientCertificateKey struct{}
 )
 
 // WithSkipAuthentication prepares a context that when given to functions in
@@ -60,6 +66,18 @@ func WithRequireAuthentication(ctx context.Context) context.Context {
 	return context.WithValue(ctx, requireAuthenticationKey{}, true)
 }
 
+// WithAuthentication prepares a context that when given to functions in
+// this file will use given authentication instead of the deployment authentication.
+func WithAuthentication(ctx context.Context, auth driver.Authentication) context.Context {
+	return context.WithValue(ctx, authenticationKey{}, auth)
+}
+
+// WithClientCertificate prepares a context that when given to functions in
+// this file will present given client certificate to TLS secured servers.
+func WithClientCertificate(ctx context.Context, cert tls.Certificate) context.Context {
+	return context.WithValue(ctx, clientCertificateKey{}, cert)
+}
+
 var (
 	sharedHTTPTransport = &nhttp.Transport{
 		Proxy: nhttp.ProxyFromEnvironment,
@@ -113,6 +131,61 @@ var (
 	}
 )
 
+// clientCertificateTransportsMax is the maximum number of cached client certificate transports.
+// Cache is cleared when the limit is reached, e.g. after many certificate rotations.
+const clientCertificateTransportsMax = 32
+
+type clientCertificateTransportKey struct {
+	fingerprint  [sha256.Size]byte
+	shortTimeout bool
+}
+
+var (
+	clientCertificateTransportsLock sync.Mutex
+	clientCertificateTransports     = map[clientCertificateTransportKey]*nhttp.Transport{}
+)
+
+// getClientCertificateTransport returns transport presenting given client certificate.
+// Transports are cached per certificate, so connections of the same certificate are reused.
+func getClientCertificateTransport(cert tls.Certificate, shortTimeout bool) *nhttp.Transport {
+	key := clientCertificateTransportKey{shortTimeout: shortTimeout}
+	h := sha256.New()
+	for _, c := range cert.Certificate {
+		h.Write(c)
+	}
+	copy(key.fingerprint[:], h.Sum(nil))
+
+	clientCertificateTransportsLock.Lock()
+	defer clientCertificateTransportsLock.Unlock()
+
+	if transport, ok := clientCertificateTransports[key]; ok {
+		return transport
+	}
+
+	if len(clientCertificateTransports) >= clientCertificateTransportsMax {
+		for k, transport := range clientCertificateTransports {
+			transport.CloseIdleConnections()
+			delete(clientCertificateTransports, k)
+		}
+	}
+
+	base := sharedHTTPSTransport
+	if shortTimeout {
+		base = sharedHTTPSTransportShortTimeout
+	}
+
+	// Shared transports can not be used, connections are bound to the client certificate
+	transport := base.Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: true,
+		Certificates:       []tls.Certificate{cert},
+	}
+
+	clientCertificateTransports[key] = transport
+
+	return transport
+}
+
 // CreateArangodClient creates a go-driver client for a specific member in the given group.
 func CreateArangodClient(ctx context.Context, cli corev1.CoreV1Interface, apiObject *api.ArangoDeployment, group api.ServerGroup, id string) (driver.Client, error) {
 	// Create connection
@@ -239,6 +312,9 @@ func createArangodHTTPConfigForDNSNames(ctx context.Context, apiObject *api.Aran
 		if shortTimeout {
 			transport = sharedHTTPSTransportShortTimeout
 		}
+		if cert, ok := ctx.Value(clientCertificateKey{}).(tls.Certificate); ok {
+			transport = getClientCertificateTransport(cert, shortTimeout)
+		}
 	}
 	connConfig := http.ConnectionConfig{
 		Transport:          transport,
@@ -252,6 +328,10 @@ func createArangodHTTPConfigForDNSNames(ctx context.Context, apiObject *api.Aran
 
 // createArangodClientAuthentication creates a go-driver authentication for the servers in the given deployment.
 func createArangodClientAuthentication(ctx context.Context, cli corev1.CoreV1Interface, apiObject *api.ArangoDeployment) (driver.Authentication, error) {
+	if auth, ok := ctx.Value(authenticationKey{}).(driver.Authentication); ok {
+		return auth, nil
+	}
+
 	if apiObject != nil && apiObject.Spec.IsAuthenticated() {
 		// Authentication is enabled.
 		// Should we skip using it?
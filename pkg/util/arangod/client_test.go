@@ -0,0 +1,80 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package arangod
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	nhttp "net/http"
+	"testing"
+
+	api "github.com/arangodb/kube-arangodb/pkg/apis/deployment/v1"
+	"github.com/arangodb/kube-arangodb/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateArangodHTTPConfigClientCertificate(t *testing.T) {
+	secure := &api.ArangoDeployment{}
+	insecure := &api.ArangoDeployment{Spec: api.DeploymentSpec{TLS: api.TLSSpec{CASecretName: util.NewString(api.CASecretNameDisabled)}}}
+	cert := tls.Certificate{Certificate: [][]byte{[]byte("cert")}}
+	ctx := WithClientCertificate(context.Background(), cert)
+
+	// Without client certificate shared transport is used
+	config, err := createArangodHTTPConfigForDNSNames(context.Background(), secure, []string{"db"}, false)
+	require.NoError(t, err)
+	require.Equal(t, sharedHTTPSTransport, config.Transport)
+
+	// Client certificate is presented on the dedicated transport
+	config, err = createArangodHTTPConfigForDNSNames(ctx, secure, []string{"db"}, false)
+	require.NoError(t, err)
+	require.NotEqual(t, sharedHTTPSTransport, config.Transport)
+	require.Equal(t, []string{"https://db:8529"}, config.Endpoints)
+	require.Equal(t, []tls.Certificate{cert}, config.Transport.(*nhttp.Transport).TLSClientConfig.Certificates)
+	require.Empty(t, sharedHTTPSTransport.TLSClientConfig.Certificates)
+
+	// Client certificate is not used without TLS
+	config, err = createArangodHTTPConfigForDNSNames(ctx, insecure, []string{"db"}, false)
+	require.NoError(t, err)
+	require.Equal(t, sharedHTTPTransport, config.Transport)
+}
+
+func TestClientCertificateTransportCache(t *testing.T) {
+	cert := tls.Certificate{Certificate: [][]byte{[]byte("cert")}}
+	other := tls.Certificate{Certificate: [][]byte{[]byte("other")}}
+
+	// Same certificate reuses the transport
+	transport := getClientCertificateTransport(cert, false)
+	require.True(t, transport == getClientCertificateTransport(cert, false))
+
+	// Different certificate or timeout uses another transport
+	require.False(t, transport == getClientCertificateTransport(other, false))
+	require.False(t, transport == getClientCertificateTransport(cert, true))
+	require.Equal(t, []tls.Certificate{other}, getClientCertificateTransport(other, false).TLSClientConfig.Certificates)
+
+	// Cache is bounded
+	for i := 0; i < clientCertificateTransportsMax*2; i++ {
+		getClientCertificateTransport(tls.Certificate{Certificate: [][]byte{[]byte(fmt.Sprintf("cert-%d", i))}}, false)
+	}
+	require.True(t, len(clientCertificateTransports) <= clientCertificateTransportsMax)
+}
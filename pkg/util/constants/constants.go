@@ -53,6 +53,7 @@ const (
 	FinalizerPVCMemberExists           = "pvc.database.arangodb.com/member-exists"       // Finalizer added to PVCs, indicating the need to keep is as long as its member exists
 
 	AnnotationEnforceAntiAffinity = "database.arangodb.com/enforce-anti-affinity" // Key of annotation added to PVC. Value is a boolean "true" or "false"
+	AnnotationReconcileDryRun     = "database.arangodb.com/reconcile-dry-run"     // Key of annotation added to ArangoDeployment. Value "true" logs new plan actions instead of applying them
 
 	BackupLabelRole = "backup/role"
 	LabelRole       = "role"
@@ -33,9 +33,22 @@ import (
 	"github.com/arangodb/kube-arangodb/pkg/util/retry"
 )
 
+const (
+	defaultReadyTimeout = time.Second * 30
+)
+
 // WaitReady waits for a check to be ready.
 func WaitReady(check func() error) error {
-	if err := retry.Retry(check, time.Second*30); err != nil {
+	return WaitReadyWithTimeout(check, defaultReadyTimeout)
+}
+
+// WaitReadyWithTimeout waits for a check to be ready at most for the given timeout.
+// Default timeout is used when the given timeout is not positive.
+func WaitReadyWithTimeout(check func() error, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = defaultReadyTimeout
+	}
+	if err := retry.Retry(check, timeout); err != nil {
 		return maskAny(err)
 	}
 	return nil
@@ -43,6 +56,12 @@ func WaitReady(check func() error) error {
 
 // WaitCRDReady waits for a custom resource definition with given name to be ready.
 func WaitCRDReady(clientset apiextensionsclient.Interface, crdName string) error {
+	return WaitCRDReadyWithTimeout(clientset, crdName, defaultReadyTimeout)
+}
+
+// WaitCRDReadyWithTimeout waits for a custom resource definition with given name to be ready
+// at most for the given timeout.
+func WaitCRDReadyWithTimeout(clientset apiextensionsclient.Interface, crdName string, timeout time.Duration) error {
 	op := func() error {
 		crd, err := clientset.ApiextensionsV1beta1().CustomResourceDefinitions().Get(crdName, metav1.GetOptions{})
 		if err != nil {
@@ -62,5 +81,8 @@ func WaitCRDReady(clientset apiextensionsclient.Interface, crdName string) error
 		}
 		return maskAny(fmt.Errorf("Retry needed"))
 	}
-	return WaitReady(op)
+	if err := WaitReadyWithTimeout(op, timeout); err != nil {
+		return maskAny(fmt.Errorf("CRD %s is not established after %s: %v", crdName, timeout, err))
+	}
+	return nil
 }
@@ -0,0 +1,66 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Adam Janikowski
+//
+
+package crd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testCRDName = "arangodeployments.database.arangodb.com"
+
+func newTestCRD(conditions ...apiextensionsv1beta1.CustomResourceDefinitionCondition) *apiextensionsv1beta1.CustomResourceDefinition {
+	return &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testCRDName,
+		},
+		Status: apiextensionsv1beta1.CustomResourceDefinitionStatus{
+			Conditions: conditions,
+		},
+	}
+}
+
+func TestWaitCRDReadyWithTimeoutEstablished(t *testing.T) {
+	clientset := fake.NewSimpleClientset(newTestCRD(apiextensionsv1beta1.CustomResourceDefinitionCondition{
+		Type:   apiextensionsv1beta1.Established,
+		Status: apiextensionsv1beta1.ConditionTrue,
+	}))
+
+	require.NoError(t, WaitCRDReadyWithTimeout(clientset, testCRDName, time.Second))
+}
+
+func TestWaitCRDReadyWithTimeoutNotEstablished(t *testing.T) {
+	clientset := fake.NewSimpleClientset(newTestCRD())
+
+	start := time.Now()
+	err := WaitCRDReadyWithTimeout(clientset, testCRDName, time.Second)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "CRD "+testCRDName+" is not established after 1s")
+	require.True(t, time.Since(start) < 10*time.Second)
+}
@@ -23,6 +23,8 @@
 package k8sutil
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
@@ -54,3 +56,20 @@ func IsNotFound(err error) bool {
 func IsInvalid(err error) bool {
 	return apierrors.IsInvalid(errors.Cause(err))
 }
+
+// IsTooManyRequests returns true if the given error is or is caused by a
+// kubernetes TooManyRequestsError,
+func IsTooManyRequests(err error) bool {
+	return apierrors.IsTooManyRequests(errors.Cause(err))
+}
+
+// GetRetryAfter returns the delay requested by the API server (Retry-After) if the given error
+// is or is caused by a kubernetes error which suggests client delay.
+func GetRetryAfter(err error) (time.Duration, bool) {
+	seconds, ok := apierrors.SuggestsClientDelay(errors.Cause(err))
+	if !ok {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
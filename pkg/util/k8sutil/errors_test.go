@@ -25,6 +25,7 @@ package k8sutil
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -38,6 +39,7 @@ var (
 	existsError   = apierrors.NewAlreadyExists(schema.GroupResource{"groupName", "resourceName"}, "something")
 	invalidError  = apierrors.NewInvalid(schema.GroupKind{"groupName", "kindName"}, "something", field.ErrorList{})
 	notFoundError = apierrors.NewNotFound(schema.GroupResource{"groupName", "resourceName"}, "something")
+	throttleError = apierrors.NewTooManyRequests("something", 5)
 )
 
 func TestIsAlreadyExists(t *testing.T) {
@@ -60,3 +62,19 @@ func TestIsNotFound(t *testing.T) {
 	assert.True(t, IsNotFound(notFoundError))
 	assert.True(t, IsNotFound(maskAny(notFoundError)))
 }
+
+func TestIsTooManyRequests(t *testing.T) {
+	assert.False(t, IsTooManyRequests(notFoundError))
+	assert.False(t, IsTooManyRequests(maskAny(invalidError)))
+	assert.True(t, IsTooManyRequests(throttleError))
+	assert.True(t, IsTooManyRequests(maskAny(throttleError)))
+}
+
+func TestGetRetryAfter(t *testing.T) {
+	_, ok := GetRetryAfter(notFoundError)
+	assert.False(t, ok)
+
+	delay, ok := GetRetryAfter(maskAny(throttleError))
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+}
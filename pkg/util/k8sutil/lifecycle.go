@@ -32,7 +32,7 @@ import (
 )
 
 const (
-	initLifecycleContainerName = "init-lifecycle"
+	InitLifecycleContainerName = "init-lifecycle"
 	LifecycleVolumeMountDir    = "/lifecycle/tools"
 	lifecycleVolumeName        = "lifecycle"
 )
@@ -44,7 +44,7 @@ func InitLifecycleContainer(image string, resources *v1.ResourceRequirements, se
 		return v1.Container{}, maskAny(err)
 	}
 	c := v1.Container{
-		Name:    initLifecycleContainerName,
+		Name:    InitLifecycleContainerName,
 		Image:   image,
 		Command: append([]string{binaryPath}, "lifecycle", "copy", "--target", LifecycleVolumeMountDir),
 		VolumeMounts: []v1.VolumeMount{
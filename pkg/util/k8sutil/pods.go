@@ -43,6 +43,7 @@ import (
 const (
 	ServerContainerName             = "server"
 	ExporterContainerName           = "exporter"
+	InitDataContainerName           = "uuid"
 	ArangodVolumeName               = "arangod-data"
 	TlsKeyfileVolumeName            = "tls-keyfile"
 	ClientAuthCAVolumeName          = "client-auth-ca"
@@ -193,6 +193,29 @@ func GetTLSKeyfileFromSecret(s *core.Secret) (string, error) {
 	return string(keyfile), nil
 }
 
+// GetClientCertificateSecret loads a secret with given name in the given namespace
+// and extracts the PEM encoded `tls.crt` & `tls.key` fields.
+func GetClientCertificateSecret(secrets SecretInterface, secretName string) (string, string, error) {
+	s, err := secrets.Get(secretName, meta.GetOptions{})
+	if err != nil {
+		return "", "", maskAny(err)
+	}
+	return GetClientCertificateFromSecret(s)
+}
+
+// GetClientCertificateFromSecret extracts the PEM encoded `tls.crt` & `tls.key` fields from the given secret.
+func GetClientCertificateFromSecret(s *core.Secret) (string, string, error) {
+	cert, found := s.Data[core.TLSCertKey]
+	if !found {
+		return "", "", maskAny(goErrors.Errorf("No '%s' found in secret '%s'", core.TLSCertKey, s.GetName()))
+	}
+	key, found := s.Data[core.TLSPrivateKeyKey]
+	if !found {
+		return "", "", maskAny(goErrors.Errorf("No '%s' found in secret '%s'", core.TLSPrivateKeyKey, s.GetName()))
+	}
+	return string(cert), string(key), nil
+}
+
 // CreateTLSKeyfileSecret creates a secret used to store a PEM encoded keyfile
 // in the format ArangoDB accepts it for its `--ssl.keyfile` option.
 func CreateTLSKeyfileSecret(secrets SecretInterface, secretName string, keyfile string, ownerRef *meta.OwnerReference) error {
@@ -116,6 +116,36 @@ func TestGetTokenSecret(t *testing.T) {
 	assert.True(t, IsNotFound(err))
 }
 
+// TestGetClientCertificateSecret tests GetClientCertificateSecret.
+func TestGetClientCertificateSecret(t *testing.T) {
+	cli := mocks.NewCore()
+	secrets := cli.Secrets("ns")
+
+	// Prepare mock
+	m := mocks.AsMock(cli.Secrets("ns"))
+	m.On("Get", "good", mock.Anything).Return(&v1.Secret{
+		Data: map[string][]byte{
+			v1.TLSCertKey:       []byte("cert"),
+			v1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}, nil)
+	m.On("Get", "no-key", mock.Anything).Return(&v1.Secret{
+		Data: map[string][]byte{
+			v1.TLSCertKey: []byte("cert"),
+		},
+	}, nil)
+	m.On("Get", "notfound", mock.Anything).Return(nil, apierrors.NewNotFound(schema.GroupResource{}, "notfound"))
+
+	cert, key, err := GetClientCertificateSecret(secrets, "good")
+	assert.NoError(t, err)
+	assert.Equal(t, "cert", cert)
+	assert.Equal(t, "key", key)
+	_, _, err = GetClientCertificateSecret(secrets, "no-key")
+	assert.Error(t, err)
+	_, _, err = GetClientCertificateSecret(secrets, "notfound")
+	assert.True(t, IsNotFound(err))
+}
+
 // TestCreateTokenSecret tests CreateTokenSecret
 func TestCreateTokenSecret(t *testing.T) {
 	cli := mocks.NewCore()
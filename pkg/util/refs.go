@@ -148,6 +148,30 @@ func UInt16OrDefault(input *uint16, defaultValue ...uint16) uint16 {
 	return *input
 }
 
+// NewUInt64 returns a reference to an uint64 with given value.
+func NewUInt64(input uint64) *uint64 {
+	return &input
+}
+
+// NewUInt64OrNil returns nil if input is nil, otherwise returns a clone of the given value.
+func NewUInt64OrNil(input *uint64) *uint64 {
+	if input == nil {
+		return nil
+	}
+	return NewUInt64(*input)
+}
+
+// UInt64OrDefault returns the default value (or 0) if input is nil, otherwise returns the referenced value.
+func UInt64OrDefault(input *uint64, defaultValue ...uint64) uint64 {
+	if input == nil {
+		if len(defaultValue) > 0 {
+			return defaultValue[0]
+		}
+		return 0
+	}
+	return *input
+}
+
 // NewBool returns a reference to a bool with given value.
 func NewBool(input bool) *bool {
 	return &input